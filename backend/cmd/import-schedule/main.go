@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"touchdown-tally/internal/config"
+	"touchdown-tally/internal/database"
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/schedule"
+	"touchdown-tally/internal/store"
+	"touchdown-tally/pkg/logger"
+)
+
+const usage = `Usage: import-schedule [-season YEAR] [-format csv|ics] <file>
+
+Imports a season schedule into nfl_games. The format is taken from the file
+extension unless -format is given. Importing the same file again updates
+games in place.
+
+Flags:
+`
+
+func main() {
+	cfg := config.Load()
+	log := logger.New()
+
+	flags := flag.NewFlagSet("import-schedule", flag.ExitOnError)
+	season := flags.Int("season", cfg.NFLSeasonYear, "season year the schedule belongs to")
+	format := flags.String("format", "", "csv or ics (default: from the file extension)")
+	flags.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flags.PrintDefaults()
+	}
+	flags.Parse(os.Args[1:])
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	path := flags.Arg(0)
+	if *format == "" {
+		*format = schedule.DetectFormat(path, "")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatal("Failed to open schedule", "path", path, "error", err)
+	}
+	defer file.Close()
+
+	db, err := database.Connect(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatal("Failed to connect to database", "error", err)
+	}
+	defer db.Close()
+
+	importer := schedule.NewImporter(store.New(db), nil, log)
+	result, err := importer.Import(context.Background(), *season, *format, file)
+	if err != nil {
+		log.Fatal("Schedule import failed", "path", path, "error", err)
+	}
+
+	fmt.Printf("Imported %d games for %d (%d changed, %d already under way)\n",
+		result.Games, result.SeasonYear, result.Changed, len(result.Skipped))
+	if len(result.Unmatched) > 0 {
+		printUnmatched(result.Unmatched)
+		os.Exit(1)
+	}
+}
+
+func printUnmatched(rows []models.ScheduleRowError) {
+	fmt.Printf("\n%d rows could not be imported:\n", len(rows))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LINE\tREASON\tROW")
+	for _, row := range rows {
+		fmt.Fprintf(w, "%d\t%s\t%s\n", row.Line, row.Reason, row.Row)
+	}
+	w.Flush()
+}
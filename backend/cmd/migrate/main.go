@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"touchdown-tally/internal/config"
+	"touchdown-tally/internal/database"
+	"touchdown-tally/pkg/logger"
+)
+
+const usage = `Usage: migrate <command>
+
+Commands:
+  up        apply all pending migrations (default)
+  down      revert the most recently applied migration
+  status    list migrations and whether they are applied
+  to N      migrate up or down to version N (0 reverts everything)
+`
+
+func main() {
+	cfg := config.Load()
+	log := logger.New()
+
+	args := os.Args[1:]
+	command := "up"
+	if len(args) > 0 {
+		command = args[0]
+	}
+
+	db, err := database.Connect(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatal("Failed to connect to database", "error", err)
+	}
+	defer db.Close()
+
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		log.Fatal("Failed to load migrations", "error", err)
+	}
+
+	ctx := context.Background()
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			log.Fatal("Migration failed", "error", err)
+		}
+		if err := database.VerifySchema(ctx, db); err != nil {
+			log.Fatal("Schema verification failed", "error", err)
+		}
+		log.Info("Migrations applied", "count", applied, "version", migrator.Latest())
+
+	case "down":
+		if err := migrator.Down(ctx); err != nil {
+			log.Fatal("Migration failed", "error", err)
+		}
+		version, err := migrator.Version(ctx)
+		if err != nil {
+			log.Fatal("Failed to read schema version", "error", err)
+		}
+		log.Info("Reverted one migration", "version", version)
+
+	case "to":
+		if len(args) < 2 {
+			fmt.Fprint(os.Stderr, usage)
+			os.Exit(2)
+		}
+		target, err := strconv.Atoi(args[1])
+		if err != nil || target < 0 {
+			log.Fatal("Invalid migration version", "version", args[1])
+		}
+		count, err := migrator.To(ctx, target)
+		if err != nil {
+			log.Fatal("Migration failed", "error", err)
+		}
+		log.Info("Migrated", "version", target, "steps", count)
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatal("Failed to read migration status", "error", err)
+		}
+		printStatus(statuses)
+
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func printStatus(statuses []database.MigrationStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+	}
+	w.Flush()
+}
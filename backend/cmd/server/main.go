@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"touchdown-tally/internal/config"
+	"touchdown-tally/internal/database"
+	"touchdown-tally/internal/handlers"
+	"touchdown-tally/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// version is overridden at build time with -ldflags "-X main.version=..."
+var version = "dev"
+
+// shutdownTimeout bounds how long in-flight requests may take to drain
+const shutdownTimeout = 15 * time.Second
+
+func main() {
+	cfg := config.Load()
+	log := logger.New()
+
+	if !cfg.Debug {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	db, err := database.Connect(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatal("Failed to connect to database", "error", err)
+	}
+	defer db.Close()
+
+	if err := database.Migrate(db); err != nil {
+		log.Fatal("Failed to run database migrations", "error", err)
+	}
+
+	h := handlers.New(db, cfg, log)
+
+	// Background work runs until shutdown begins
+	jobs, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	var running sync.WaitGroup
+	if cfg.EnableBackgroundJobs {
+		for _, job := range []func(context.Context){
+			h.Games.RunScoreSync,
+			h.Drafts.RunClock,
+			h.WeeklyPicks.RunGrader,
+			h.Survivor.RunProcessor,
+			h.Chat.RunRetention,
+		} {
+			running.Add(1)
+			go func(run func(context.Context)) {
+				defer running.Done()
+				run(jobs)
+			}(job)
+		}
+	} else {
+		log.Info("Background jobs disabled, scores will not sync, chat will not be pruned and drafts, weekly grading and survivor weeks will not advance")
+	}
+
+	srv := &http.Server{
+		Addr:              net.JoinHostPort(cfg.Host, cfg.Port),
+		Handler:           newRouter(db, cfg, log, h),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		log.Info("Server starting", "addr", srv.Addr, "version", version, "env", cfg.AppEnv)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal("Server failed", "error", err)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-quit
+
+	log.Info("Shutting down server", "signal", sig.String())
+	stopJobs()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	// Stop accepting connections and wait for in-flight HTTP requests
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Error("Failed to drain HTTP requests", "error", err)
+	}
+
+	// WebSocket connections are hijacked and not tracked by http.Server,
+	// so they have to be closed separately
+	h.Chat.Close()
+
+	// Let background jobs finish with the database before it is closed
+	running.Wait()
+
+	log.Info("Server stopped")
+}
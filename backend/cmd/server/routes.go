@@ -0,0 +1,134 @@
+package main
+
+import (
+	"net/http"
+
+	"touchdown-tally/internal/config"
+	"touchdown-tally/internal/database"
+	"touchdown-tally/internal/handlers"
+	"touchdown-tally/internal/middleware"
+	"touchdown-tally/pkg/logger"
+	"touchdown-tally/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newRouter builds the gin engine and mounts every handler group
+func newRouter(db *database.DB, cfg *config.Config, log *logger.Logger, h *handlers.Handlers) *gin.Engine {
+	router := gin.New()
+	router.Use(gin.Recovery())
+	router.Use(middleware.Logger(log))
+	router.Use(middleware.CORS(cfg.CORSOrigins))
+
+	router.GET("/health", func(c *gin.Context) {
+		if err := db.PingContext(c.Request.Context()); err != nil {
+			response.Error(c, http.StatusServiceUnavailable, "database_unavailable", "Database is not reachable")
+			return
+		}
+		response.Success(c, gin.H{"status": "healthy", "version": version})
+	})
+
+	requireAuth := middleware.RequireAuth(cfg)
+
+	// The Vue client opens a single socket at /ws?token=... that follows
+	// every pool the user belongs to; adding &pool_id=... joins that pool's chat
+	router.GET("/ws", requireAuth, h.Chat.WebSocketHandler)
+
+	api := router.Group("/api")
+
+	authRoutes := api.Group("/auth")
+	{
+		authRoutes.POST("/register", h.Auth.Register)
+		authRoutes.POST("/login", h.Auth.Login)
+		authRoutes.POST("/logout", requireAuth, h.Auth.Logout)
+		authRoutes.POST("/refresh", requireAuth, h.Auth.Refresh)
+		authRoutes.GET("/profile", requireAuth, h.Auth.GetProfile)
+	}
+
+	protected := api.Group("")
+	protected.Use(requireAuth)
+
+	teams := protected.Group("/teams")
+	{
+		teams.GET("", h.Teams.List)
+		teams.GET("/:id", h.Teams.Get)
+	}
+
+	games := protected.Group("/games")
+	{
+		games.GET("", h.Games.List)
+		games.GET("/current", h.Games.Current)
+		games.GET("/week/:week", h.Games.GetByWeek)
+		games.GET("/:id", h.Games.Get)
+		games.POST("/import", h.Games.Import)
+		games.GET("/:id/corrections", h.Games.Corrections)
+		games.POST("/:id/corrections", h.Games.Correct)
+	}
+
+	pools := protected.Group("/pools")
+	{
+		pools.GET("", h.Pools.GetPools)
+		pools.POST("", h.Pools.CreatePool)
+		pools.GET("/:id", h.Pools.GetPool)
+		pools.GET("/:id/members", h.Pools.GetMembers)
+		pools.PUT("/:id/members/:user_id/role", h.Pools.SetMemberRole)
+		pools.PUT("/:id/settings", h.Pools.UpdateSettings)
+		pools.POST("/:id/join", h.Pools.JoinPool)
+		pools.POST("/:id/leave", h.Pools.LeavePool)
+
+		pools.GET("/:id/draft", h.Drafts.Get)
+		pools.POST("/:id/draft", h.Drafts.Schedule)
+		pools.POST("/:id/draft/start", h.Drafts.Start)
+		pools.POST("/:id/draft/picks", h.Drafts.Pick)
+		pools.GET("/:id/draft/queue", h.Drafts.GetQueue)
+		pools.PUT("/:id/draft/queue", h.Drafts.SetQueue)
+		pools.PUT("/:id/draft/absent", h.Drafts.SetAbsent)
+
+		pools.GET("/:id/weeks/:week/picks", h.WeeklyPicks.Get)
+		pools.PUT("/:id/weeks/:week/picks", h.WeeklyPicks.Submit)
+		pools.GET("/:id/survivor", h.Survivor.Board)
+		pools.PUT("/:id/survivor/weeks/:week/pick", h.Survivor.Pick)
+		pools.GET("/:id/tiebreaker", h.Tiebreakers.Get)
+		pools.PUT("/:id/tiebreaker", h.Tiebreakers.Submit)
+	}
+
+	picks := protected.Group("/picks")
+	{
+		picks.GET("", h.Picks.List)
+		picks.GET("/pool/:pool_id", h.Picks.GetByPool)
+		picks.GET("/pool/:pool_id/lock", h.Picks.Lock)
+		picks.GET("/pool/:pool_id/overrides", h.Picks.Overrides)
+		picks.GET("/user/:id", h.Picks.ByUser)
+		picks.GET("/game/:id", h.Picks.ByGame)
+		picks.POST("", h.Picks.Create)
+		picks.PUT("/:id", h.Picks.Update)
+		picks.DELETE("/:id", h.Picks.Delete)
+	}
+
+	standings := protected.Group("/standings")
+	{
+		standings.GET("/pool/:id", h.Standings.GetPoolStandings)
+		standings.GET("/pool/:id/week/:week", h.Standings.GetPoolStandings)
+		standings.GET("/user/:userId", h.Standings.GetUserStats)
+	}
+
+	chat := protected.Group("/chat")
+	{
+		chat.POST("/send", h.Chat.SendMessage)
+		chat.GET("/pool/:id", h.Chat.GetChatHistory)
+		chat.POST("/pool/:id", h.Chat.SendMessage)
+		chat.GET("/pool/:id/history", h.Chat.GetChatHistory)
+		chat.GET("/pool/:id/ws", h.Chat.WebSocketHandler)
+
+		chat.DELETE("/pool/:id/messages/:message_id", h.Chat.DeleteMessage)
+		chat.POST("/pool/:id/mutes", h.Chat.Mute)
+		chat.DELETE("/pool/:id/mutes/:user_id", h.Chat.Unmute)
+		chat.POST("/pool/:id/timeouts", h.Chat.Timeout)
+		chat.POST("/pool/:id/clear", h.Chat.ClearMessages)
+		chat.GET("/pool/:id/moderation", h.Chat.ModerationLog)
+		chat.GET("/pool/:id/archives", h.Chat.Archives)
+		chat.GET("/pool/:id/archives/:archive_id", h.Chat.DownloadArchive)
+	}
+
+	return router
+}
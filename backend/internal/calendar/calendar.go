@@ -0,0 +1,208 @@
+// Package calendar works out where an NFL season stands from the games in
+// nfl_games.
+package calendar
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+	_ "time/tzdata" // weeks turn over in US Eastern time
+
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
+)
+
+var ErrNoSchedule = errors.New("no games are scheduled for the season")
+
+// eastern is the zone the NFL week turns over in
+var eastern = mustLoadLocation("America/New_York")
+
+// Calendar resolves the current NFL week
+type Calendar struct {
+	store *store.Store
+	now   func() time.Time
+}
+
+// New creates a calendar reading games from st
+func New(st *store.Store) *Calendar {
+	return &Calendar{
+		store: st,
+		now:   func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// week is one week's games in kickoff order
+type week struct {
+	number int
+	games  []models.GameWithTeams
+}
+
+// rollover is when the week hands over to the next: midnight Eastern on
+// the first Tuesday after its first kickoff, once Monday night is over
+func (w week) rollover() time.Time {
+	y, m, d := w.games[0].GameDate.In(eastern).Date()
+	date := time.Date(y, m, d, 0, 0, 0, 0, eastern)
+	days := (int(time.Tuesday) - int(date.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	return date.AddDate(0, 0, days)
+}
+
+func (w week) live() bool {
+	for _, game := range w.games {
+		if game.IsLive() {
+			return true
+		}
+	}
+	return false
+}
+
+// Current returns the week in play for season. A week is current from the
+// Tuesday after the previous week's first kickoff until the Tuesday after
+// its own, so an open week such as the one before the Super Bowl belongs to
+// the next week with games. A week with a game still being played stays
+// current past its rollover. Before the season the first week is current,
+// and once it is over the last week is.
+func (c *Calendar) Current(ctx context.Context, season int) (*models.SeasonWeek, error) {
+	weeks, err := c.weeks(ctx, season)
+	if err != nil {
+		return nil, err
+	}
+	if len(weeks) == 0 {
+		return nil, ErrNoSchedule
+	}
+
+	now := c.now()
+	current := -1
+	for i, w := range weeks {
+		if w.live() {
+			current = i
+			break
+		}
+	}
+	if current < 0 {
+		for i, w := range weeks {
+			if now.Before(w.rollover()) {
+				current = i
+				break
+			}
+		}
+	}
+
+	phase := ""
+	if current < 0 {
+		current = len(weeks) - 1
+		phase = models.SeasonPhaseComplete
+	}
+	w := weeks[current]
+
+	result := &models.SeasonWeek{
+		SeasonYear:   season,
+		Week:         w.number,
+		GameType:     w.games[0].GameType,
+		Phase:        phase,
+		EndsAt:       w.rollover().UTC(),
+		FirstKickoff: w.games[0].GameDate,
+		Games:        w.games,
+		ByeTeams:     []models.NFLTeam{},
+	}
+	if current > 0 {
+		startsAt := weeks[current-1].rollover().UTC()
+		result.StartsAt = &startsAt
+	}
+
+	switch {
+	case result.Phase != "":
+	case current == 0 && now.Before(result.FirstKickoff):
+		result.Phase = models.SeasonPhasePreseason
+	case result.GameType == models.GameTypeRegular:
+		result.Phase = models.SeasonPhaseRegular
+	default:
+		result.Phase = models.SeasonPhasePlayoffs
+	}
+
+	if result.GameType == models.GameTypeRegular {
+		result.ByeTeams, err = c.byes(ctx, w)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// CurrentWeek returns the number of the week in play for season
+func (c *Calendar) CurrentWeek(ctx context.Context, season int) (int, error) {
+	current, err := c.Current(ctx, season)
+	if err != nil {
+		return 0, err
+	}
+	return current.Week, nil
+}
+
+// SeasonStart returns the first kickoff of the season's opening week,
+// whatever its number
+func (c *Calendar) SeasonStart(ctx context.Context, season int) (time.Time, error) {
+	weeks, err := c.weeks(ctx, season)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(weeks) == 0 {
+		return time.Time{}, ErrNoSchedule
+	}
+	return weeks[0].games[0].GameDate, nil
+}
+
+// weeks returns the season's games grouped by week, in week order
+func (c *Calendar) weeks(ctx context.Context, season int) ([]week, error) {
+	games, err := c.store.Games.List(ctx, store.GameFilter{SeasonYear: &season})
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[int]int)
+	var weeks []week
+	for _, game := range games {
+		i, ok := index[game.Week]
+		if !ok {
+			i = len(weeks)
+			index[game.Week] = i
+			weeks = append(weeks, week{number: game.Week})
+		}
+		weeks[i].games = append(weeks[i].games, game)
+	}
+
+	sort.Slice(weeks, func(a, b int) bool { return weeks[a].number < weeks[b].number })
+	return weeks, nil
+}
+
+// byes returns the teams without a game in a regular-season week
+func (c *Calendar) byes(ctx context.Context, w week) ([]models.NFLTeam, error) {
+	teams, err := c.store.Teams.List(ctx, store.TeamFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	playing := make(map[int]bool, len(w.games)*2)
+	for _, game := range w.games {
+		playing[game.HomeTeamID] = true
+		playing[game.AwayTeamID] = true
+	}
+
+	byes := []models.NFLTeam{}
+	for _, team := range teams {
+		if !playing[team.TeamID] {
+			byes = append(byes, team)
+		}
+	}
+	return byes, nil
+}
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
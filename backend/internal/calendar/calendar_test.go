@@ -0,0 +1,126 @@
+package calendar
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store/memstore"
+)
+
+// season loads a 2025 schedule between four teams: week 1 opens on a
+// Thursday night and closes on Monday night, week 2 has teams 2 and 4 on a
+// bye, week 19 is wild card weekend and week 22 the Super Bowl, after two
+// open weeks. Live leaves week 1's Monday night game in progress.
+func season(live bool) *memstore.DB {
+	db := memstore.New()
+	for id := 1; id <= 4; id++ {
+		db.AddTeam(models.NFLTeam{TeamID: id})
+	}
+
+	monday := models.GameStatusCompleted
+	if live {
+		monday = models.GameStatusInProgress
+	}
+	games := []models.NFLGame{
+		{Week: 1, GameType: models.GameTypeRegular, HomeTeamID: 1, AwayTeamID: 2, GameDate: time.Date(2025, 9, 5, 0, 20, 0, 0, time.UTC), Status: models.GameStatusCompleted},
+		{Week: 1, GameType: models.GameTypeRegular, HomeTeamID: 3, AwayTeamID: 4, GameDate: time.Date(2025, 9, 9, 0, 15, 0, 0, time.UTC), Status: monday},
+		{Week: 2, GameType: models.GameTypeRegular, HomeTeamID: 1, AwayTeamID: 3, GameDate: time.Date(2025, 9, 14, 17, 0, 0, 0, time.UTC), Status: models.GameStatusScheduled},
+		{Week: 19, GameType: models.GameTypePlayoff, HomeTeamID: 1, AwayTeamID: 4, GameDate: time.Date(2026, 1, 10, 21, 30, 0, 0, time.UTC), Status: models.GameStatusScheduled},
+		{Week: 22, GameType: models.GameTypeSuperBowl, HomeTeamID: 1, AwayTeamID: 3, GameDate: time.Date(2026, 2, 8, 23, 30, 0, 0, time.UTC), Status: models.GameStatusScheduled},
+	}
+	for _, game := range games {
+		game.SeasonYear = 2025
+		db.AddGame(game)
+	}
+	return db
+}
+
+func TestCurrent(t *testing.T) {
+	// Weeks turn over at midnight Eastern on Tuesday
+	week1Ends := time.Date(2025, 9, 9, 4, 0, 0, 0, time.UTC)
+	week2Ends := time.Date(2025, 9, 16, 4, 0, 0, 0, time.UTC)
+	week19Ends := time.Date(2026, 1, 13, 5, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		live         bool
+		now          time.Time
+		wantWeek     int
+		wantPhase    string
+		wantStartsAt *time.Time
+		wantByes     []int
+	}{
+		{name: "before the opener", now: time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC), wantWeek: 1, wantPhase: models.SeasonPhasePreseason},
+		{name: "after the opener", now: time.Date(2025, 9, 6, 12, 0, 0, 0, time.UTC), wantWeek: 1, wantPhase: models.SeasonPhaseRegular},
+		{name: "monday night belongs to the week", now: week1Ends.Add(-time.Minute), wantWeek: 1, wantPhase: models.SeasonPhaseRegular},
+		{name: "week turns over on tuesday", now: week1Ends, wantWeek: 2, wantPhase: models.SeasonPhaseRegular, wantStartsAt: &week1Ends, wantByes: []int{2, 4}},
+		{name: "game still being played holds the week", live: true, now: week1Ends.Add(time.Hour), wantWeek: 1, wantPhase: models.SeasonPhaseRegular},
+		{name: "playoffs have no byes", now: time.Date(2026, 1, 11, 12, 0, 0, 0, time.UTC), wantWeek: 19, wantPhase: models.SeasonPhasePlayoffs, wantStartsAt: &week2Ends},
+		{name: "open weeks belong to the super bowl", now: week19Ends.Add(24 * time.Hour), wantWeek: 22, wantPhase: models.SeasonPhasePlayoffs, wantStartsAt: &week19Ends},
+		{name: "after the super bowl", now: time.Date(2026, 2, 12, 12, 0, 0, 0, time.UTC), wantWeek: 22, wantPhase: models.SeasonPhaseComplete, wantStartsAt: &week19Ends},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(season(tt.live).Store())
+			c.now = func() time.Time { return tt.now }
+
+			current, err := c.Current(context.Background(), 2025)
+			if err != nil {
+				t.Fatalf("Current: %v", err)
+			}
+			if current.Week != tt.wantWeek || current.Phase != tt.wantPhase {
+				t.Errorf("week %d in %s, want week %d in %s", current.Week, current.Phase, tt.wantWeek, tt.wantPhase)
+			}
+			if !reflect.DeepEqual(current.StartsAt, tt.wantStartsAt) {
+				t.Errorf("starts at %v, want %v", current.StartsAt, tt.wantStartsAt)
+			}
+			if !current.EndsAt.After(current.FirstKickoff) || current.EndsAt.Weekday() != time.Tuesday {
+				t.Errorf("week from %v ends %v, want the Tuesday after", current.FirstKickoff, current.EndsAt)
+			}
+
+			var byes []int
+			for _, team := range current.ByeTeams {
+				byes = append(byes, team.TeamID)
+			}
+			sort.Ints(byes)
+			if !reflect.DeepEqual(byes, tt.wantByes) {
+				t.Errorf("byes %v, want %v", byes, tt.wantByes)
+			}
+		})
+	}
+}
+
+func TestSeasonStart(t *testing.T) {
+	tests := []struct {
+		name    string
+		season  int
+		want    time.Time
+		wantErr error
+	}{
+		{name: "thursday night opener", season: 2025, want: time.Date(2025, 9, 5, 0, 20, 0, 0, time.UTC)},
+		{name: "no schedule", season: 2024, wantErr: ErrNoSchedule},
+	}
+
+	c := New(season(false).Store())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, err := c.SeasonStart(context.Background(), tt.season)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SeasonStart error = %v, want %v", err, tt.wantErr)
+			}
+			if !start.Equal(tt.want) {
+				t.Errorf("SeasonStart = %v, want %v", start, tt.want)
+			}
+
+			if _, err := c.Current(context.Background(), tt.season); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Current error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
+)
+
+// EventCommandError tells a client why its chat command failed
+const EventCommandError = "command_error"
+
+var (
+	ErrUnknownMember   = errors.New("no pool member by that name")
+	ErrAmbiguousMember = errors.New("more than one member has that name; use their username")
+
+	// errUsage reports a command typed with missing or malformed arguments
+	errUsage = errors.New("usage")
+)
+
+// CommandError explains a failed chat command to the client that sent it
+type CommandError struct {
+	Command string `json:"command"`
+	Error   string `json:"error"`
+	Usage   string `json:"usage,omitempty"`
+}
+
+// command is a moderation command typed into chat as "/name args".
+// notFound explains a store.ErrNotFound from run.
+type command struct {
+	usage    string
+	notFound string
+	run      func(m *Moderation, ctx context.Context, poolID, userID int, args []string) error
+}
+
+var commands = map[string]command{
+	"delete": {
+		usage:    "/delete <message id> [reason]",
+		notFound: "no such message",
+		run: func(m *Moderation, ctx context.Context, poolID, userID int, args []string) error {
+			if len(args) < 1 {
+				return errUsage
+			}
+			messageID, err := strconv.Atoi(args[0])
+			if err != nil {
+				return errUsage
+			}
+			_, err = m.DeleteMessage(ctx, poolID, userID, messageID, strings.Join(args[1:], " "))
+			return err
+		},
+	},
+	"mute": {
+		usage:    "/mute <member> [duration] [reason]",
+		notFound: "that user is not a member of this pool",
+		run: func(m *Moderation, ctx context.Context, poolID, userID int, args []string) error {
+			return m.muteCommand(ctx, poolID, userID, models.ModerationMute, args)
+		},
+	},
+	"timeout": {
+		usage:    "/timeout <member> <duration> [reason]",
+		notFound: "that user is not a member of this pool",
+		run: func(m *Moderation, ctx context.Context, poolID, userID int, args []string) error {
+			if len(args) < 2 {
+				return errUsage
+			}
+			return m.muteCommand(ctx, poolID, userID, models.ModerationTimeout, args)
+		},
+	},
+	"unmute": {
+		usage:    "/unmute <member> [reason]",
+		notFound: "that member is not muted",
+		run: func(m *Moderation, ctx context.Context, poolID, userID int, args []string) error {
+			if len(args) < 1 {
+				return errUsage
+			}
+			target, err := m.resolveMember(ctx, poolID, args[0])
+			if err != nil {
+				return err
+			}
+			_, err = m.Unmute(ctx, poolID, userID, target, strings.Join(args[1:], " "))
+			return err
+		},
+	},
+	"clear": {
+		usage:    "/clear <member> [within] [reason]",
+		notFound: "that member has no messages to clear",
+		run: func(m *Moderation, ctx context.Context, poolID, userID int, args []string) error {
+			if len(args) < 1 {
+				return errUsage
+			}
+			target, err := m.resolveMember(ctx, poolID, args[0])
+			if err != nil {
+				return err
+			}
+			req := models.ChatClearRequest{UserID: target}
+			rest := args[1:]
+			if len(rest) > 0 {
+				if _, err := ParseDuration(rest[0]); err == nil {
+					req.Within, rest = rest[0], rest[1:]
+				}
+			}
+			req.Reason = strings.Join(rest, " ")
+			_, err = m.Clear(ctx, poolID, userID, req)
+			return err
+		},
+	},
+}
+
+// RunCommand carries out a "/"-prefixed moderation command typed into chat
+// by userID. It reports false when text is not a known command and should
+// be posted as an ordinary message. A failed command is returned as a
+// CommandError to send back to its author.
+func (m *Moderation) RunCommand(ctx context.Context, poolID, userID int, text string) (bool, *CommandError) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 || !strings.HasPrefix(fields[0], "/") {
+		return false, nil
+	}
+	name := strings.ToLower(strings.TrimPrefix(fields[0], "/"))
+	cmd, ok := commands[name]
+	if !ok {
+		return false, nil
+	}
+
+	err := cmd.run(m, ctx, poolID, userID, fields[1:])
+	if err == nil {
+		return true, nil
+	}
+
+	reply := &CommandError{Command: name, Usage: cmd.usage}
+	switch {
+	case errors.Is(err, errUsage):
+		reply.Error = "usage: " + cmd.usage
+	case errors.Is(err, store.ErrNotFound):
+		reply.Error = cmd.notFound
+	case isModerationError(err):
+		reply.Error = err.Error()
+	default:
+		m.logger.Error("Chat command failed", "pool_id", poolID, "user_id", userID, "command", name, "error", err)
+		reply.Error = "the command could not be completed"
+	}
+	return true, reply
+}
+
+// muteCommand runs /mute and /timeout. The duration may be left out of a
+// mute, so the second argument is only taken as one when it parses.
+func (m *Moderation) muteCommand(ctx context.Context, poolID, userID int, kind string, args []string) error {
+	if len(args) < 1 {
+		return errUsage
+	}
+	target, err := m.resolveMember(ctx, poolID, args[0])
+	if err != nil {
+		return err
+	}
+
+	req := models.ChatMuteRequest{UserID: target}
+	rest := args[1:]
+	if len(rest) > 0 {
+		if _, err := ParseDuration(rest[0]); err == nil || kind == models.ModerationTimeout {
+			req.Duration, rest = rest[0], rest[1:]
+		}
+	}
+	req.Reason = strings.Join(rest, " ")
+
+	_, err = m.Mute(ctx, poolID, userID, kind, req)
+	return err
+}
+
+// resolveMember finds a pool member by user ID, username or display name,
+// with or without a leading "@"
+func (m *Moderation) resolveMember(ctx context.Context, poolID int, name string) (int, error) {
+	name = strings.TrimPrefix(name, "@")
+	members, err := m.store.Pools.ListMembers(ctx, poolID)
+	if err != nil {
+		return 0, err
+	}
+
+	if id, err := strconv.Atoi(name); err == nil {
+		for _, member := range members {
+			if member.UserID == id {
+				return id, nil
+			}
+		}
+	}
+	for _, member := range members {
+		if strings.EqualFold(member.Username, name) {
+			return member.UserID, nil
+		}
+	}
+	found := 0
+	for _, member := range members {
+		if strings.EqualFold(member.DisplayName, name) {
+			if found != 0 {
+				return 0, ErrAmbiguousMember
+			}
+			found = member.UserID
+		}
+	}
+	if found == 0 {
+		return 0, ErrUnknownMember
+	}
+	return found, nil
+}
+
+// isModerationError reports whether err is one a moderator can act on, as
+// opposed to a failure of the server
+func isModerationError(err error) bool {
+	for _, target := range []error{
+		ErrNotModerator, ErrProtectedMember, ErrSelf, ErrInvalidDuration,
+		ErrNoMessages, ErrUnknownMember, ErrAmbiguousMember,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
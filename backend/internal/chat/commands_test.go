@@ -0,0 +1,283 @@
+package chat
+
+import (
+	"context"
+	"io"
+	"log"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"touchdown-tally/internal/database"
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
+	"touchdown-tally/pkg/logger"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "10m", want: 10 * time.Minute},
+		{value: "1h30m", want: 90 * time.Minute},
+		{value: "3d", want: 72 * time.Hour},
+		{value: " 2D ", want: 48 * time.Hour},
+		{value: "0d", wantErr: true},
+		{value: "-5m", wantErr: true},
+		{value: "soon", wantErr: true},
+		{value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseDuration(%q) = %v, %v; want %v, error %t", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{d: 10 * time.Minute, want: "10m"},
+		{d: 2 * time.Hour, want: "2h"},
+		{d: 90 * time.Minute, want: "1h30m"},
+		{d: 72 * time.Hour, want: "3d"},
+		{d: 25 * time.Hour, want: "25h"},
+		{d: 45 * time.Second, want: "45s"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatDuration(tt.d); got != tt.want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+// moderated is a pool over a migrated SQLite database with a commissioner,
+// a moderator and two members who share the display name Alex. Alice has
+// posted one message.
+type moderated struct {
+	moderation *Moderation
+	store      *store.Store
+	poolID     int
+	users      map[string]int
+	message    int
+}
+
+func newModerated(t *testing.T, now time.Time) *moderated {
+	t.Helper()
+	ctx := context.Background()
+
+	db, err := database.Connect("sqlite://" + filepath.Join(t.TempDir(), "chat.db"))
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := database.Migrate(db); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	quiet := &logger.Logger{Logger: log.New(io.Discard, "", 0)}
+	hubs := NewHubs(quiet)
+	t.Cleanup(hubs.Close)
+	m := &moderated{store: store.New(db), users: make(map[string]int)}
+	m.moderation = NewModeration(m.store, hubs, quiet)
+	m.moderation.now = func() time.Time { return now }
+
+	for _, user := range []struct{ username, display string }{
+		{"commish", "Commish"}, {"mod", "Mod"}, {"alice", "Alex"}, {"bob", "Alex"},
+	} {
+		profile, err := m.store.Users.Register(ctx, user.username+"@example.com", "hash", user.username, user.display)
+		if err != nil {
+			t.Fatalf("register %s: %v", user.username, err)
+		}
+		m.users[user.username] = profile.UserID
+	}
+	m.poolID, err = m.store.Pools.Create(ctx, store.NewPool{
+		Name:           "Trash Talk",
+		CommissionerID: m.users["commish"],
+		SeasonYear:     2025,
+		MaxMembers:     10,
+		PoolType:       models.PoolTypeSeason,
+		Settings:       models.DefaultPoolSettings(),
+	})
+	if err != nil {
+		t.Fatalf("create pool: %v", err)
+	}
+	roles := map[string]string{"mod": models.RoleModerator, "alice": models.RoleMember, "bob": models.RoleMember}
+	for name, role := range roles {
+		if err := m.store.Pools.AddMember(ctx, m.poolID, m.users[name], role); err != nil {
+			t.Fatalf("add %s: %v", name, err)
+		}
+	}
+
+	message := models.ChatMessage{
+		PoolID:      m.poolID,
+		UserID:      m.users["alice"],
+		Message:     "BUF by 40",
+		MessageType: models.ChatMessageUser,
+		Timestamp:   now.Add(-10 * time.Minute),
+	}
+	if err := m.store.Chat.Save(ctx, &message); err != nil {
+		t.Fatalf("save message: %v", err)
+	}
+	m.message = message.ID
+	return m
+}
+
+func TestRunCommand(t *testing.T) {
+	now := time.Date(2025, 9, 7, 18, 0, 0, 0, time.UTC)
+	// logged is a moderation action as the test expects to find it
+	type logged struct {
+		action string
+		target string
+		reason string
+		// expiresIn is how long a mute lasts, zero until lifted
+		expiresIn time.Duration
+		// deleted is whether the action removed Alice's message
+		deleted bool
+	}
+
+	tests := []struct {
+		name string
+		from string
+		// text may refer to Alice's message as {message}
+		text        string
+		wantHandled bool
+		wantErr     string
+		// want is the action logged, when the command succeeds
+		want *logged
+	}{
+		{name: "ordinary message", from: "commish", text: "kickoff!"},
+		{name: "unknown command is posted as a message", from: "commish", text: "/shrug"},
+		{
+			name: "mute for a while", from: "commish", text: "/mute alice 10m spamming the chat", wantHandled: true,
+			want: &logged{action: models.ModerationMute, target: "alice", reason: "spamming the chat", expiresIn: 10 * time.Minute},
+		},
+		{
+			name: "mute until lifted", from: "mod", text: "/MUTE @alice being rude", wantHandled: true,
+			want: &logged{action: models.ModerationMute, target: "alice", reason: "being rude"},
+		},
+		{
+			name: "timeout in days", from: "commish", text: "/timeout bob 3d", wantHandled: true,
+			want: &logged{action: models.ModerationTimeout, target: "bob", expiresIn: 72 * time.Hour},
+		},
+		{name: "timeout needs a duration", from: "commish", text: "/timeout alice", wantHandled: true, wantErr: "usage: /timeout <member> <duration> [reason]"},
+		{name: "timeout with a bad duration", from: "commish", text: "/timeout alice soon", wantHandled: true, wantErr: ErrInvalidDuration.Error()},
+		{name: "mute longer than allowed", from: "commish", text: "/mute alice 31d", wantHandled: true, wantErr: ErrInvalidDuration.Error()},
+		{name: "display name shared by two members", from: "commish", text: "/mute Alex", wantHandled: true, wantErr: ErrAmbiguousMember.Error()},
+		{name: "unknown member", from: "commish", text: "/mute nobody", wantHandled: true, wantErr: ErrUnknownMember.Error()},
+		{name: "members cannot moderate", from: "alice", text: "/mute bob", wantHandled: true, wantErr: ErrNotModerator.Error()},
+		{name: "moderators cannot mute the commissioner", from: "mod", text: "/mute commish", wantHandled: true, wantErr: ErrProtectedMember.Error()},
+		{name: "commissioners cannot mute themselves", from: "commish", text: "/mute commish", wantHandled: true, wantErr: ErrSelf.Error()},
+		{name: "unmute without a mute", from: "commish", text: "/unmute alice", wantHandled: true, wantErr: "that member is not muted"},
+		{
+			name: "delete a message", from: "mod", text: "/delete {message} off topic", wantHandled: true,
+			want: &logged{action: models.ModerationDeleteMessage, target: "alice", reason: "off topic", deleted: true},
+		},
+		{name: "delete a missing message", from: "mod", text: "/delete 999", wantHandled: true, wantErr: "no such message"},
+		{name: "delete without a message id", from: "mod", text: "/delete last", wantHandled: true, wantErr: "usage: /delete <message id> [reason]"},
+		{
+			name: "clear recent messages", from: "commish", text: "/clear alice 2h flooding", wantHandled: true,
+			want: &logged{action: models.ModerationClearMessages, target: "alice", reason: "flooding", deleted: true},
+		},
+		{name: "clear outside the window", from: "commish", text: "/clear alice 5m", wantHandled: true, wantErr: ErrNoMessages.Error()},
+		{name: "clear a member with no messages", from: "commish", text: "/clear bob", wantHandled: true, wantErr: ErrNoMessages.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			m := newModerated(t, now)
+
+			text := strings.ReplaceAll(tt.text, "{message}", strconv.Itoa(m.message))
+			handled, reply := m.moderation.RunCommand(ctx, m.poolID, m.users[tt.from], text)
+			if handled != tt.wantHandled {
+				t.Fatalf("RunCommand handled = %t, want %t", handled, tt.wantHandled)
+			}
+			switch {
+			case reply != nil && reply.Error != tt.wantErr:
+				t.Fatalf("RunCommand error = %q, want %q", reply.Error, tt.wantErr)
+			case reply == nil && tt.wantErr != "":
+				t.Fatalf("RunCommand succeeded, want %q", tt.wantErr)
+			}
+
+			actions, err := m.store.Chat.ModerationLog(ctx, m.poolID, 10)
+			if err != nil {
+				t.Fatalf("moderation log: %v", err)
+			}
+			if tt.want == nil {
+				if len(actions) != 0 {
+					t.Errorf("logged %+v, want nothing", actions)
+				}
+				return
+			}
+			if len(actions) != 1 {
+				t.Fatalf("logged %d actions, want 1", len(actions))
+			}
+			got := actions[0]
+			var wantMessages []int
+			if tt.want.deleted {
+				wantMessages = []int{m.message}
+			}
+			if got.Action != tt.want.action || got.TargetUserID != m.users[tt.want.target] || got.Reason != tt.want.reason ||
+				got.ModeratorID != m.users[tt.from] || !reflect.DeepEqual(got.MessageIDs, wantMessages) {
+				t.Errorf("logged %+v, want %+v", got, *tt.want)
+			}
+			switch {
+			case tt.want.expiresIn == 0 && got.ExpiresAt != nil:
+				t.Errorf("mute expires %v, want it to hold until lifted", got.ExpiresAt)
+			case tt.want.expiresIn != 0 && (got.ExpiresAt == nil || !got.ExpiresAt.Equal(now.Add(tt.want.expiresIn))):
+				t.Errorf("mute expires %v, want %v", got.ExpiresAt, now.Add(tt.want.expiresIn))
+			}
+		})
+	}
+}
+
+// TestMuteLifecycle checks a mute keeps its member quiet until it expires
+// or is lifted
+func TestMuteLifecycle(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 9, 7, 18, 0, 0, 0, time.UTC)
+	m := newModerated(t, now)
+	commish, alice := m.users["commish"], m.users["alice"]
+
+	muted := func(at time.Time) bool {
+		t.Helper()
+		m.moderation.now = func() time.Time { return at }
+		mute, err := m.moderation.Muted(ctx, m.poolID, alice)
+		if err != nil {
+			t.Fatalf("muted: %v", err)
+		}
+		return mute != nil
+	}
+	run := func(text string) {
+		t.Helper()
+		m.moderation.now = func() time.Time { return now }
+		if _, reply := m.moderation.RunCommand(ctx, m.poolID, commish, text); reply != nil {
+			t.Fatalf("%s: %s", text, reply.Error)
+		}
+	}
+
+	run("/mute alice 1h")
+	if !muted(now.Add(59*time.Minute)) || muted(now.Add(time.Hour)) {
+		t.Error("a one hour mute should hold for exactly an hour")
+	}
+
+	run("/mute alice")
+	if !muted(now.Add(365 * 24 * time.Hour)) {
+		t.Error("a mute without a duration should hold until lifted")
+	}
+	run("/unmute alice")
+	if muted(now) {
+		t.Error("still muted after /unmute")
+	}
+}
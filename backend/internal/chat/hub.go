@@ -0,0 +1,442 @@
+// Package chat fans pool chat and events out to WebSocket connections. Each
+// pool has a hub goroutine that owns its set of clients, and each client has
+// its own writer so a slow connection only ever holds up itself. A client
+// following all of a user's pools joins the hub of each of them.
+package chat
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"touchdown-tally/pkg/logger"
+)
+
+const (
+	// writeWait is how long a single frame may take to write
+	writeWait = 10 * time.Second
+	// pongWait is how long a connection may stay silent before it is dropped
+	pongWait = 60 * time.Second
+	// pingPeriod is how often clients are pinged; it must be under pongWait
+	pingPeriod = pongWait * 9 / 10
+	// maxFrameSize caps the frames read from a client. Message length is
+	// limited separately by the chat policy.
+	maxFrameSize = 64 * 1024
+	// sendBuffer is how many frames may queue for a client before it is
+	// evicted as too slow
+	sendBuffer = 256
+)
+
+var ErrClosed = errors.New("chat is shutting down")
+
+// Hubs holds the hub of every pool with a client connected. A hub starts
+// with its first connection and runs until its last one leaves or Close.
+type Hubs struct {
+	logger *logger.Logger
+	mu     sync.Mutex
+	hubs   map[int]*Hub
+	done   chan struct{}
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// NewHubs creates an empty set of hubs
+func NewHubs(logger *logger.Logger) *Hubs {
+	return &Hubs{
+		logger: logger,
+		hubs:   make(map[int]*Hub),
+		done:   make(chan struct{}),
+	}
+}
+
+// Register adds a connection for userID to the pool's hub and starts its
+// writer. The caller runs the client's ReadPump.
+func (h *Hubs) Register(poolID, userID int, conn *websocket.Conn) (*Client, error) {
+	return h.start(newClient(h, conn, userID, poolID), []int{poolID})
+}
+
+// Follow adds a connection for userID to the hub of each of the given pools,
+// where it receives their events but not their chat, and starts its writer.
+// The caller runs the client's ReadPump.
+func (h *Hubs) Follow(userID int, poolIDs []int, conn *websocket.Conn) (*Client, error) {
+	return h.start(newClient(h, conn, userID, 0), poolIDs)
+}
+
+// start registers client with the hub of each pool, then starts its writer.
+// If any registration fails the client leaves the hubs it joined.
+func (h *Hubs) start(client *Client, poolIDs []int) (*Client, error) {
+	for _, poolID := range poolIDs {
+		if err := h.register(poolID, client); err != nil {
+			client.leave()
+			return nil, err
+		}
+	}
+
+	go client.writePump()
+	return client, nil
+}
+
+// register adds client to the pool's hub, starting the hub if needed
+func (h *Hubs) register(poolID int, client *Client) error {
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return ErrClosed
+	}
+	hub, ok := h.hubs[poolID]
+	if !ok {
+		hub = newHub(poolID, h.done, h.logger)
+		h.hubs[poolID] = hub
+		h.wg.Add(1)
+		go func() {
+			defer h.wg.Done()
+			hub.run()
+		}()
+	}
+	// The reference keeps the hub running until this client leaves it
+	hub.refs++
+	h.mu.Unlock()
+
+	client.joined = append(client.joined, hub)
+	select {
+	case hub.register <- client:
+		return nil
+	case <-h.done:
+		return ErrClosed
+	}
+}
+
+// release drops a client's reference to a hub, stopping the hub and
+// forgetting it once no client holds one
+func (h *Hubs) release(hub *Hub) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	hub.refs--
+	if hub.refs > 0 {
+		return
+	}
+	if h.hubs[hub.poolID] == hub {
+		delete(h.hubs, hub.poolID)
+	}
+	close(hub.stop)
+}
+
+// Broadcast sends payload as JSON to every client connected to the pool.
+// Pools with no connections are skipped.
+func (h *Hubs) Broadcast(poolID int, payload interface{}) {
+	h.send(poolID, payload, false)
+}
+
+// Publish sends an event as JSON to every client connected to the pool and
+// every client following it
+func (h *Hubs) Publish(poolID int, payload interface{}) {
+	h.send(poolID, payload, true)
+}
+
+// send delivers payload through the pool's hub if it is running. Only
+// events reach following clients.
+func (h *Hubs) send(poolID int, payload interface{}, event bool) {
+	h.mu.Lock()
+	hub, ok := h.hubs[poolID]
+	h.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	frame, err := json.Marshal(payload)
+	if err != nil {
+		h.logger.Error("Failed to encode chat frame", "pool_id", poolID, "error", err)
+		return
+	}
+	select {
+	case hub.broadcast <- poolFrame{frame: frame, event: event}:
+	case <-hub.stop:
+	case <-h.done:
+	}
+}
+
+// Disconnect hangs up every connection userID has open to the pool's chat.
+// Their sockets following the pool's events stay open.
+func (h *Hubs) Disconnect(poolID, userID int) {
+	h.mu.Lock()
+	hub, ok := h.hubs[poolID]
+	h.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	select {
+	case hub.disconnect <- userID:
+	case <-hub.stop:
+	case <-h.done:
+	}
+}
+
+// Close sends every client a close frame and stops the hubs. It is safe to
+// call more than once.
+func (h *Hubs) Close() {
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return
+	}
+	h.closed = true
+	close(h.done)
+	h.mu.Unlock()
+
+	h.wg.Wait()
+	h.logger.Info("Chat connections closed")
+}
+
+// Hub delivers frames to the clients connected to one pool and those
+// following it. Only its run goroutine touches the client set; refs counts
+// the clients holding the hub open and is guarded by Hubs.mu.
+type Hub struct {
+	poolID     int
+	clients    map[*Client]bool
+	refs       int
+	broadcast  chan poolFrame
+	register   chan *Client
+	unregister chan *Client
+	direct     chan directFrame
+	disconnect chan int
+	stop       chan struct{}
+	done       <-chan struct{}
+	logger     *logger.Logger
+}
+
+// poolFrame is a frame addressed to everyone in a pool. Following clients
+// only receive events.
+type poolFrame struct {
+	frame []byte
+	event bool
+}
+
+// directFrame is a frame for a single client, such as a reply to a command
+type directFrame struct {
+	client *Client
+	frame  []byte
+}
+
+func newHub(poolID int, done <-chan struct{}, logger *logger.Logger) *Hub {
+	return &Hub{
+		poolID:     poolID,
+		clients:    make(map[*Client]bool),
+		broadcast:  make(chan poolFrame, sendBuffer),
+		register:   make(chan *Client),
+		unregister: make(chan *Client),
+		direct:     make(chan directFrame, sendBuffer),
+		disconnect: make(chan int),
+		stop:       make(chan struct{}),
+		done:       done,
+		logger:     logger,
+	}
+}
+
+func (h *Hub) run() {
+	for {
+		select {
+		case client := <-h.register:
+			h.clients[client] = true
+			h.logger.Info("Client registered", "pool_id", h.poolID, "user_id", client.UserID)
+
+		case client := <-h.unregister:
+			if h.clients[client] {
+				h.remove(client)
+				h.logger.Info("Client unregistered", "pool_id", h.poolID, "user_id", client.UserID)
+			}
+
+		case f := <-h.broadcast:
+			for client := range h.clients {
+				if f.event || !client.following() {
+					h.deliver(client, f.frame)
+				}
+			}
+
+		case direct := <-h.direct:
+			if h.clients[direct.client] {
+				h.deliver(direct.client, direct.frame)
+			}
+
+		case userID := <-h.disconnect:
+			closeMessage := websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "removed from chat")
+			for client := range h.clients {
+				if client.UserID == userID && !client.following() {
+					client.conn.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(writeWait))
+					h.remove(client)
+					h.logger.Info("Client disconnected", "pool_id", h.poolID, "user_id", userID)
+				}
+			}
+
+		case <-h.stop:
+			return
+
+		case <-h.done:
+			h.shutdown()
+			return
+		}
+	}
+}
+
+// deliver queues a frame for a client, evicting it if its writer has
+// fallen a full buffer behind
+func (h *Hub) deliver(client *Client, frame []byte) {
+	select {
+	case client.send <- frame:
+	default:
+		h.remove(client)
+		h.logger.Warn("Evicted slow chat client", "pool_id", h.poolID, "user_id", client.UserID)
+	}
+}
+
+// remove drops a client from the hub and hangs it up, which takes it out of
+// any other hub it joined once its ReadPump returns
+func (h *Hub) remove(client *Client) {
+	delete(h.clients, client)
+	client.hangUp()
+}
+
+// shutdown tells every client the server is going away and hangs up
+func (h *Hub) shutdown() {
+	closeMessage := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down")
+	deadline := time.Now().Add(time.Second)
+	for client := range h.clients {
+		if err := client.conn.WriteControl(websocket.CloseMessage, closeMessage, deadline); err != nil {
+			h.logger.Warn("Failed to send close frame", "pool_id", h.poolID, "error", err)
+		}
+		h.remove(client)
+		client.conn.Close()
+	}
+}
+
+// Client is one WebSocket connection to a pool's hub, or to the hub of each
+// pool it follows. PoolID is zero for a following client.
+type Client struct {
+	hubs *Hubs
+	// joined lists the hubs the client registered with. Only the goroutine
+	// that registered the client and runs its ReadPump touches it.
+	joined []*Hub
+	conn   *websocket.Conn
+	send   chan []byte
+	// hangup is closed once, by the first hub to remove the client, to make
+	// its writer hang up
+	hangup chan struct{}
+	once   sync.Once
+	UserID int
+	PoolID int
+}
+
+func newClient(hubs *Hubs, conn *websocket.Conn, userID, poolID int) *Client {
+	return &Client{
+		hubs:   hubs,
+		conn:   conn,
+		send:   make(chan []byte, sendBuffer),
+		hangup: make(chan struct{}),
+		UserID: userID,
+		PoolID: poolID,
+	}
+}
+
+// following reports whether the client follows pools' events rather than
+// joining one pool's chat
+func (c *Client) following() bool {
+	return c.PoolID == 0
+}
+
+func (c *Client) hangUp() {
+	c.once.Do(func() { close(c.hangup) })
+}
+
+// leave unregisters the client from every hub it joined and releases them
+func (c *Client) leave() {
+	for _, hub := range c.joined {
+		select {
+		case hub.unregister <- c:
+		case <-hub.done:
+		}
+		c.hubs.release(hub)
+	}
+	c.joined = nil
+}
+
+// Send queues payload as JSON for this client alone. It is for clients of a
+// pool's chat; following clients have nothing to reply to.
+func (c *Client) Send(payload interface{}) {
+	if len(c.joined) == 0 {
+		return
+	}
+	hub := c.joined[0]
+
+	frame, err := json.Marshal(payload)
+	if err != nil {
+		c.hubs.logger.Error("Failed to encode chat frame", "pool_id", c.PoolID, "error", err)
+		return
+	}
+	select {
+	case hub.direct <- directFrame{client: c, frame: frame}:
+	case <-hub.stop:
+	case <-hub.done:
+	}
+}
+
+// ReadPump reads frames from the connection and hands each to handle until
+// the connection closes or stops answering pings, then removes the client
+// from its hubs. It runs on the caller's goroutine.
+func (c *Client) ReadPump(handle func(frame []byte)) {
+	defer func() {
+		c.leave()
+		c.hangUp()
+		c.conn.Close()
+	}()
+
+	c.conn.SetReadLimit(maxFrameSize)
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, frame, err := c.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				c.hubs.logger.Error("WebSocket error", "pool_id", c.PoolID, "user_id", c.UserID, "error", err)
+			}
+			return
+		}
+		handle(frame)
+	}
+}
+
+// writePump writes queued frames to the connection and pings it so dead
+// peers are noticed. It hangs up once a hub removes the client.
+func (c *Client) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case <-c.hangup:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+			return
+
+		case frame := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.TextMessage, frame); err != nil {
+				return
+			}
+
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package chat
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"touchdown-tally/pkg/logger"
+)
+
+// serve hands every connection to the server to join, and dial connects to
+// it once the connection has joined its hubs
+func serve(t *testing.T, join func(conn *websocket.Conn) (*Client, error)) (dial func() *websocket.Conn) {
+	t.Helper()
+
+	registered := make(chan struct{})
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		client, err := join(conn)
+		if err != nil {
+			conn.Close()
+			return
+		}
+		registered <- struct{}{}
+		client.ReadPump(func([]byte) {})
+	}))
+	t.Cleanup(server.Close)
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	return func() *websocket.Conn {
+		t.Helper()
+
+		conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+		if err != nil {
+			t.Fatalf("dial: %v", err)
+		}
+		<-registered
+		return conn
+	}
+}
+
+// running reports whether the pool has a hub
+func running(hubs *Hubs, poolID int) bool {
+	hubs.mu.Lock()
+	defer hubs.mu.Unlock()
+
+	_, ok := hubs.hubs[poolID]
+	return ok
+}
+
+// eventually polls cond until it holds or a second passes
+func eventually(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	for deadline := time.Now().Add(time.Second); !cond(); {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within a second")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestHubStopsWithLastClient(t *testing.T) {
+	hubs := NewHubs(&logger.Logger{Logger: log.New(io.Discard, "", 0)})
+	t.Cleanup(hubs.Close)
+	dial := serve(t, func(conn *websocket.Conn) (*Client, error) {
+		return hubs.Register(1, 1, conn)
+	})
+
+	first := dial()
+	second := dial()
+
+	first.Close()
+	// The second client still holds the hub open
+	hubs.Broadcast(1, "still here")
+	second.SetReadDeadline(time.Now().Add(time.Second))
+	var got string
+	if err := second.ReadJSON(&got); err != nil || got != "still here" {
+		t.Fatalf("read = %q, %v", got, err)
+	}
+	if !running(hubs, 1) {
+		t.Fatal("hub stopped while a client was connected")
+	}
+
+	second.Close()
+	eventually(t, func() bool { return !running(hubs, 1) })
+
+	// Sending to a pool whose hub has gone is a no-op
+	hubs.Broadcast(1, "nobody")
+	hubs.Disconnect(1, 1)
+
+	// A new connection starts a fresh hub
+	third := dial()
+	defer third.Close()
+	hubs.Broadcast(1, "welcome back")
+	third.SetReadDeadline(time.Now().Add(time.Second))
+	if err := third.ReadJSON(&got); err != nil || got != "welcome back" {
+		t.Fatalf("read = %q, %v", got, err)
+	}
+}
+
+func TestFollowerJoinsEachPoolHub(t *testing.T) {
+	hubs := NewHubs(&logger.Logger{Logger: log.New(io.Discard, "", 0)})
+	t.Cleanup(hubs.Close)
+	chat := serve(t, func(conn *websocket.Conn) (*Client, error) {
+		return hubs.Register(1, 2, conn)
+	})
+	follow := serve(t, func(conn *websocket.Conn) (*Client, error) {
+		return hubs.Follow(1, []int{1, 2}, conn)
+	})
+
+	member := chat()
+	defer member.Close()
+	follower := follow()
+	if !running(hubs, 1) || !running(hubs, 2) || running(hubs, 0) {
+		t.Fatal("follower did not join exactly the hubs of its pools")
+	}
+
+	expect := func(want string) {
+		t.Helper()
+
+		follower.SetReadDeadline(time.Now().Add(time.Second))
+		var got string
+		if err := follower.ReadJSON(&got); err != nil || got != want {
+			t.Fatalf("read = %q, %v; want %q", got, err, want)
+		}
+	}
+
+	hubs.Publish(1, "pool 1 event")
+	expect("pool 1 event")
+	hubs.Publish(2, "pool 2 event")
+	expect("pool 2 event")
+
+	// Chat and pools it does not follow never reach it
+	hubs.Broadcast(1, "chat")
+	hubs.Publish(3, "pool 3 event")
+	hubs.Publish(1, "after chat")
+	expect("after chat")
+
+	// Timing the user out of a pool's chat leaves their events flowing
+	hubs.Disconnect(1, 1)
+	hubs.Publish(1, "still following")
+	expect("still following")
+
+	// Hanging up leaves every hub, stopping those nobody else holds open
+	follower.Close()
+	eventually(t, func() bool { return !running(hubs, 2) })
+	if !running(hubs, 1) {
+		t.Fatal("pool 1 hub stopped while a member was connected")
+	}
+}
@@ -0,0 +1,361 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
+	"touchdown-tally/pkg/logger"
+)
+
+// Moderation event types pushed to a pool's clients
+const (
+	// EventMessageDeleted lists messages a moderator removed
+	EventMessageDeleted = "message_deleted"
+	// EventUserMuted announces a mute or timeout
+	EventUserMuted = "user_muted"
+	// EventUserUnmuted announces a mute or timeout lifted early
+	EventUserUnmuted = "user_unmuted"
+	// EventChatMuted tells a muted member their message was not posted
+	EventChatMuted = "chat_muted"
+)
+
+const (
+	// MaxMuteDuration is the longest a mute or timeout may be set for
+	MaxMuteDuration = 30 * 24 * time.Hour
+	// DefaultClearWindow is how far back a clear reaches by default
+	DefaultClearWindow = time.Hour
+	// MaxClearWindow is how far back a clear may reach
+	MaxClearWindow = 7 * 24 * time.Hour
+	// moderationLogLimit caps a page of the moderation log
+	moderationLogLimit = 100
+)
+
+var (
+	ErrNotModerator    = errors.New("only the commissioner or a moderator can moderate chat")
+	ErrProtectedMember = errors.New("that member cannot be moderated by you")
+	ErrSelf            = errors.New("you cannot moderate yourself")
+	ErrInvalidDuration = errors.New("duration must be like 10m, 2h or 3d and at most 30 days")
+	ErrNoMessages      = errors.New("the member has no messages to clear in that window")
+)
+
+// Moderation carries out commissioner and moderator actions in pool chat
+type Moderation struct {
+	store  *store.Store
+	hubs   *Hubs
+	logger *logger.Logger
+	now    func() time.Time
+}
+
+// NewModeration creates a moderation service that announces its actions to
+// the pool's clients through hubs
+func NewModeration(st *store.Store, hubs *Hubs, logger *logger.Logger) *Moderation {
+	return &Moderation{
+		store:  st,
+		hubs:   hubs,
+		logger: logger,
+		now:    func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// CanModerate reports whether userID is the pool's commissioner or one of
+// its moderators
+func (m *Moderation) CanModerate(ctx context.Context, poolID, userID int) (bool, error) {
+	role, err := m.store.Pools.MemberRole(ctx, poolID, userID)
+	if errors.Is(err, store.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return role == models.RoleCommissioner || role == models.RoleModerator, nil
+}
+
+// Muted returns the mute or timeout keeping userID from posting in the
+// pool, or nil when they may post
+func (m *Moderation) Muted(ctx context.Context, poolID, userID int) (*models.ChatMute, error) {
+	mute, err := m.store.Chat.ActiveMute(ctx, poolID, userID, m.now())
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, nil
+	}
+	return mute, err
+}
+
+// DeleteMessage removes a message from the pool's chat
+func (m *Moderation) DeleteMessage(ctx context.Context, poolID, moderatorID, messageID int, reason string) (*models.ChatModerationAction, error) {
+	message, err := m.store.Chat.Get(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if message.PoolID != poolID {
+		return nil, store.ErrNotFound
+	}
+	// Moderators may remove their own messages
+	target := message.UserID
+	if target == moderatorID {
+		target = 0
+	}
+	if err := m.authorize(ctx, poolID, moderatorID, target, false); err != nil {
+		return nil, err
+	}
+
+	action := &models.ChatModerationAction{
+		Action:       models.ModerationDeleteMessage,
+		TargetUserID: message.UserID,
+		MessageIDs:   []int{messageID},
+		Reason:       reason,
+	}
+	if err := m.apply(ctx, poolID, moderatorID, action); err != nil {
+		return nil, err
+	}
+
+	m.announce(ctx, action, EventMessageDeleted, fmt.Sprintf("A message from %s was removed by %s", action.TargetName, action.ModeratorName))
+	return action, nil
+}
+
+// Mute keeps a member from posting for the request's duration, or until
+// lifted when it has none. A timeout (kind models.ModerationTimeout) must
+// have a duration and also disconnects the member from the chat.
+func (m *Moderation) Mute(ctx context.Context, poolID, moderatorID int, kind string, req models.ChatMuteRequest) (*models.ChatModerationAction, error) {
+	if err := m.authorize(ctx, poolID, moderatorID, req.UserID, true); err != nil {
+		return nil, err
+	}
+
+	action := &models.ChatModerationAction{
+		Action:       kind,
+		TargetUserID: req.UserID,
+		Reason:       req.Reason,
+	}
+	var duration time.Duration
+	if req.Duration != "" || kind == models.ModerationTimeout {
+		d, err := ParseDuration(req.Duration)
+		if err != nil || d > MaxMuteDuration {
+			return nil, ErrInvalidDuration
+		}
+		duration = d
+		expiresAt := m.now().Add(d)
+		action.ExpiresAt = &expiresAt
+	}
+	if err := m.apply(ctx, poolID, moderatorID, action); err != nil {
+		return nil, err
+	}
+
+	verb := "muted"
+	if kind == models.ModerationTimeout {
+		verb = "timed out"
+	}
+	notice := fmt.Sprintf("%s was %s by %s", action.TargetName, verb, action.ModeratorName)
+	if duration > 0 {
+		notice += " for " + FormatDuration(duration)
+	}
+	m.announce(ctx, action, EventUserMuted, notice)
+
+	if kind == models.ModerationTimeout {
+		m.hubs.Disconnect(poolID, req.UserID)
+	}
+	return action, nil
+}
+
+// Unmute lifts a member's mute or timeout. It returns store.ErrNotFound if
+// they have none in force.
+func (m *Moderation) Unmute(ctx context.Context, poolID, moderatorID, userID int, reason string) (*models.ChatModerationAction, error) {
+	if err := m.authorize(ctx, poolID, moderatorID, userID, true); err != nil {
+		return nil, err
+	}
+
+	action := &models.ChatModerationAction{
+		Action:       models.ModerationUnmute,
+		TargetUserID: userID,
+		Reason:       reason,
+	}
+	if err := m.apply(ctx, poolID, moderatorID, action); err != nil {
+		return nil, err
+	}
+
+	m.announce(ctx, action, EventUserUnmuted, fmt.Sprintf("%s lifted %s's mute", action.ModeratorName, action.TargetName))
+	return action, nil
+}
+
+// Clear removes a member's messages posted within the request's window
+func (m *Moderation) Clear(ctx context.Context, poolID, moderatorID int, req models.ChatClearRequest) (*models.ChatModerationAction, error) {
+	if err := m.authorize(ctx, poolID, moderatorID, req.UserID, false); err != nil {
+		return nil, err
+	}
+
+	within := DefaultClearWindow
+	if req.Within != "" {
+		d, err := ParseDuration(req.Within)
+		if err != nil || d > MaxClearWindow {
+			return nil, ErrInvalidDuration
+		}
+		within = d
+	}
+
+	ids, err := m.store.Chat.Recent(ctx, poolID, req.UserID, m.now().Add(-within))
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, ErrNoMessages
+	}
+
+	action := &models.ChatModerationAction{
+		Action:       models.ModerationClearMessages,
+		TargetUserID: req.UserID,
+		MessageIDs:   ids,
+		Reason:       req.Reason,
+	}
+	if err := m.apply(ctx, poolID, moderatorID, action); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil, ErrNoMessages
+		}
+		return nil, err
+	}
+
+	m.announce(ctx, action, EventMessageDeleted, fmt.Sprintf("%d messages from %s were removed by %s",
+		len(action.MessageIDs), action.TargetName, action.ModeratorName))
+	return action, nil
+}
+
+// Log returns the pool's most recent moderation actions, newest first
+func (m *Moderation) Log(ctx context.Context, poolID, moderatorID, limit int) ([]models.ChatModerationAction, error) {
+	if err := m.authorize(ctx, poolID, moderatorID, 0, false); err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > moderationLogLimit {
+		limit = moderationLogLimit
+	}
+	return m.store.Chat.ModerationLog(ctx, poolID, limit)
+}
+
+// authorize checks that moderatorID may act on targetID in the pool, where
+// targetID 0 means the action has no target. Commissioners cannot be
+// moderated, and moderators only by a commissioner. A target who has left
+// the pool may still have their messages removed, but mutes need a member.
+func (m *Moderation) authorize(ctx context.Context, poolID, moderatorID, targetID int, needMember bool) error {
+	role, err := m.store.Pools.MemberRole(ctx, poolID, moderatorID)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		return err
+	}
+	if role != models.RoleCommissioner && role != models.RoleModerator {
+		return ErrNotModerator
+	}
+	if targetID == 0 {
+		return nil
+	}
+	if targetID == moderatorID {
+		return ErrSelf
+	}
+
+	targetRole, err := m.store.Pools.MemberRole(ctx, poolID, targetID)
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		if needMember {
+			return err
+		}
+		return nil
+	case err != nil:
+		return err
+	}
+	if targetRole == models.RoleCommissioner || (targetRole == models.RoleModerator && role != models.RoleCommissioner) {
+		return ErrProtectedMember
+	}
+	return nil
+}
+
+// apply fills in the action's pool, moderator and names and records it
+func (m *Moderation) apply(ctx context.Context, poolID, moderatorID int, action *models.ChatModerationAction) error {
+	action.PoolID = poolID
+	action.ModeratorID = moderatorID
+	action.CreatedAt = m.now()
+	action.ModeratorName = m.displayName(ctx, moderatorID)
+	if action.TargetUserID != 0 {
+		action.TargetName = m.displayName(ctx, action.TargetUserID)
+	}
+
+	if err := m.store.Chat.Moderate(ctx, action); err != nil {
+		return err
+	}
+
+	m.logger.Info("Chat moderated", "pool_id", poolID, "moderator_id", moderatorID,
+		"action", action.Action, "target_user_id", action.TargetUserID, "messages", len(action.MessageIDs))
+	return nil
+}
+
+// announce pushes the action to the pool's clients as eventType and posts
+// notice in the chat as a moderation_action message
+func (m *Moderation) announce(ctx context.Context, action *models.ChatModerationAction, eventType, notice string) {
+	if action.Reason != "" {
+		notice += ": " + action.Reason
+	}
+
+	m.hubs.Broadcast(action.PoolID, models.Event{
+		Type:      eventType,
+		PoolID:    action.PoolID,
+		Data:      action,
+		Timestamp: action.CreatedAt,
+	})
+
+	message := models.ChatMessage{
+		PoolID:      action.PoolID,
+		UserID:      action.ModeratorID,
+		DisplayName: action.ModeratorName,
+		Message:     notice,
+		MessageType: models.ChatMessageModeration,
+		Timestamp:   action.CreatedAt,
+	}
+	if err := m.store.Chat.Save(ctx, &message); err != nil {
+		m.logger.Error("Failed to save moderation notice", "pool_id", action.PoolID, "error", err)
+		return
+	}
+	m.hubs.Broadcast(action.PoolID, message)
+}
+
+func (m *Moderation) displayName(ctx context.Context, userID int) string {
+	profile, err := m.store.Users.GetProfile(ctx, userID)
+	if err != nil {
+		m.logger.Warn("Failed to get user display name", "user_id", userID, "error", err)
+		return "A member"
+	}
+	return profile.DisplayName
+}
+
+// ParseDuration reads a moderation duration: a Go duration such as "10m"
+// or "1h30m", or a whole number of days such as "3d"
+func ParseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(strings.ToLower(value))
+	if strings.HasSuffix(value, "d") {
+		n, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err != nil || n <= 0 {
+			return 0, ErrInvalidDuration
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, ErrInvalidDuration
+	}
+	return d, nil
+}
+
+// FormatDuration writes a duration the way ParseDuration reads it, using
+// days when it is a whole number of them
+func FormatDuration(d time.Duration) string {
+	if d >= 24*time.Hour && d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+	s := d.Round(time.Second).String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
@@ -0,0 +1,183 @@
+package chat
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// EventRateLimited tells a client its message was refused for posting
+	// too fast
+	EventRateLimited = "rate_limited"
+	// EventMessageTooLong tells a client its message was over the size limit
+	EventMessageTooLong = "message_too_long"
+)
+
+const (
+	// maxCooldown caps how long repeated violations can lock a user out
+	maxCooldown = 10 * time.Minute
+	// violationMemory is how long a violation counts toward the next
+	// cooldown; a user who keeps within the limit that long starts over
+	violationMemory = 10 * time.Minute
+)
+
+// Rejection explains why a message was not posted. It is sent as the data
+// of a WebSocket frame whose type is Code, and as the error of a REST
+// response with Status.
+type Rejection struct {
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	Limit      int    `json:"limit,omitempty"`
+	RetryAfter int    `json:"retry_after,omitempty"` // seconds
+	Status     int    `json:"-"`
+}
+
+// Policy decides whether a user may post a message: it must be within the
+// size limit and the user within their rate limit for the pool. The same
+// policy applies to messages sent over WebSocket and REST.
+type Policy struct {
+	maxLength int
+	limiter   *Limiter
+}
+
+// NewPolicy creates a policy allowing messages of up to maxLength
+// characters and perMinute messages a minute from each user in each pool.
+// A limit of zero or less is not enforced.
+func NewPolicy(maxLength, perMinute int) *Policy {
+	return &Policy{
+		maxLength: maxLength,
+		limiter:   NewLimiter(perMinute),
+	}
+}
+
+// Check admits a message from userID to the pool, returning why not when it
+// is refused. An admitted message uses up one of the user's tokens.
+func (p *Policy) Check(poolID, userID int, text string) *Rejection {
+	if p.maxLength > 0 && utf8.RuneCountInString(text) > p.maxLength {
+		return &Rejection{
+			Code:    EventMessageTooLong,
+			Message: fmt.Sprintf("Messages can be at most %d characters", p.maxLength),
+			Limit:   p.maxLength,
+			Status:  http.StatusBadRequest,
+		}
+	}
+
+	if wait, ok := p.limiter.Allow(poolID, userID); !ok {
+		seconds := int(math.Ceil(wait.Seconds()))
+		return &Rejection{
+			Code:       EventRateLimited,
+			Message:    fmt.Sprintf("You are sending messages too fast; try again in %ds", seconds),
+			Limit:      p.limiter.perMinute,
+			RetryAfter: seconds,
+			Status:     http.StatusTooManyRequests,
+		}
+	}
+	return nil
+}
+
+// Limiter is a token bucket per user and pool. Each bucket holds up to a
+// minute's allowance and refills continuously. A user who empties their
+// bucket waits for the next token the first time; each further violation
+// while the last is remembered doubles the wait, up to maxCooldown.
+type Limiter struct {
+	perMinute int
+	mu        sync.Mutex
+	buckets   map[bucketKey]*bucket
+	swept     time.Time
+	now       func() time.Time
+}
+
+type bucketKey struct {
+	poolID, userID int
+}
+
+type bucket struct {
+	tokens        float64
+	updated       time.Time
+	violations    int
+	lastViolation time.Time
+	cooldownUntil time.Time
+}
+
+// NewLimiter creates a limiter allowing perMinute messages a minute. A
+// limit of zero or less allows everything.
+func NewLimiter(perMinute int) *Limiter {
+	return &Limiter{
+		perMinute: perMinute,
+		buckets:   make(map[bucketKey]*bucket),
+		now:       time.Now,
+	}
+}
+
+// Allow takes a token for userID in the pool. When there is none it
+// reports how long the user must wait before trying again.
+func (l *Limiter) Allow(poolID, userID int) (time.Duration, bool) {
+	if l.perMinute <= 0 {
+		return 0, true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	key := bucketKey{poolID: poolID, userID: userID}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.perMinute), updated: now}
+		l.buckets[key] = b
+	}
+
+	if now.Before(b.cooldownUntil) {
+		return b.cooldownUntil.Sub(now), false
+	}
+
+	b.tokens = math.Min(float64(l.perMinute), b.tokens+now.Sub(b.updated).Seconds()*l.rate())
+	b.updated = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, true
+	}
+
+	if now.Sub(b.lastViolation) > violationMemory {
+		b.violations = 0
+	}
+	b.violations++
+	b.lastViolation = now
+
+	// The first violation waits for the next token; each one after doubles it
+	wait := time.Duration((1 - b.tokens) / l.rate() * float64(time.Second))
+	for i := 1; i < b.violations && wait < maxCooldown; i++ {
+		wait *= 2
+	}
+	if wait > maxCooldown {
+		wait = maxCooldown
+	}
+	b.cooldownUntil = now.Add(wait)
+	return wait, false
+}
+
+// rate is the refill rate in tokens a second
+func (l *Limiter) rate() float64 {
+	return float64(l.perMinute) / 60
+}
+
+// sweep drops buckets that have refilled and whose violations are
+// forgotten, at most once a minute
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.swept) < time.Minute {
+		return
+	}
+	l.swept = now
+
+	for key, b := range l.buckets {
+		if now.Sub(b.updated) > time.Minute && now.After(b.cooldownUntil) && now.Sub(b.lastViolation) > violationMemory {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package chat
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"time"
+
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
+	"touchdown-tally/pkg/logger"
+)
+
+const (
+	// retentionInterval is how often pools' chat history is pruned
+	retentionInterval = time.Hour
+	// archiveBatch caps the messages written to a single archive
+	archiveBatch = 5000
+)
+
+// Retention enforces each pool's chat retention settings. Messages past a
+// pool's limits are written to a compressed archive the commissioner can
+// download, then deleted. Archives are themselves deleted once they are
+// older than the archive lifetime.
+type Retention struct {
+	store       *store.Store
+	defaultKeep int
+	archiveFor  time.Duration
+	logger      *logger.Logger
+	now         func() time.Time
+}
+
+// NewRetention creates a retention service. Pools that set no limit of
+// their own keep their newest defaultKeep messages; zero keeps everything.
+// Archives are deleted after archiveDays days, or kept for good when it
+// is zero.
+func NewRetention(st *store.Store, defaultKeep, archiveDays int, logger *logger.Logger) *Retention {
+	return &Retention{
+		store:       st,
+		defaultKeep: defaultKeep,
+		archiveFor:  time.Duration(archiveDays) * 24 * time.Hour,
+		logger:      logger,
+		now:         func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Run prunes chat history until ctx is cancelled
+func (r *Retention) Run(ctx context.Context) {
+	ticker := time.NewTicker(retentionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Prune(ctx); err != nil {
+				r.logger.Error("Failed to prune chat history", "error", err)
+			}
+		}
+	}
+}
+
+// Prune archives and deletes the messages every active pool no longer
+// keeps, then deletes expired archives
+func (r *Retention) Prune(ctx context.Context) error {
+	pools, err := r.store.Pools.ListActive(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	for i := range pools {
+		if err := r.prune(ctx, &pools[i]); err != nil {
+			r.logger.Error("Failed to prune pool chat", "pool_id", pools[i].ID, "error", err)
+		}
+	}
+
+	if r.archiveFor > 0 {
+		n, err := r.store.Chat.DeleteArchives(ctx, r.now().Add(-r.archiveFor))
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			r.logger.Info("Expired chat archives deleted", "count", n)
+		}
+	}
+	return nil
+}
+
+// prune archives a pool's expired messages in batches until none are left
+func (r *Retention) prune(ctx context.Context, pool *models.Pool) error {
+	keep, before := r.limits(pool.Settings.Chat)
+	if keep == 0 && before.IsZero() {
+		return nil
+	}
+
+	for {
+		messages, err := r.store.Chat.Expired(ctx, pool.ID, keep, before, archiveBatch)
+		if err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			return nil
+		}
+
+		data, err := compress(messages)
+		if err != nil {
+			return err
+		}
+		ids := make([]int, len(messages))
+		for i, msg := range messages {
+			ids[i] = msg.ID
+		}
+
+		archive := &models.ChatArchive{
+			PoolID:         pool.ID,
+			MessageCount:   len(messages),
+			FirstMessageAt: messages[0].Timestamp,
+			LastMessageAt:  messages[len(messages)-1].Timestamp,
+			CreatedAt:      r.now(),
+		}
+		if err := r.store.Chat.SaveArchive(ctx, archive, data, ids); err != nil {
+			return err
+		}
+		r.logger.Info("Chat messages archived", "pool_id", pool.ID, "archive_id", archive.ArchiveID,
+			"messages", archive.MessageCount, "bytes", archive.SizeBytes)
+
+		if len(messages) < archiveBatch {
+			return nil
+		}
+	}
+}
+
+// limits turns a pool's settings into the number of messages to keep and
+// the time before which messages are dropped. A pool with neither limit
+// set keeps the default number of messages.
+func (r *Retention) limits(settings models.ChatSettings) (int, time.Time) {
+	if settings.KeepMessages == 0 && settings.KeepDays == 0 {
+		return r.defaultKeep, time.Time{}
+	}
+
+	var before time.Time
+	if settings.KeepDays > 0 {
+		before = r.now().AddDate(0, 0, -settings.KeepDays)
+	}
+	return settings.KeepMessages, before
+}
+
+// Archives lists a pool's chat archives, newest first
+func (r *Retention) Archives(ctx context.Context, poolID int) ([]models.ChatArchive, error) {
+	archives, err := r.store.Chat.Archives(ctx, poolID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range archives {
+		r.setExpiry(&archives[i])
+	}
+	return archives, nil
+}
+
+// Archive returns one of a pool's chat archives with its gzip-compressed
+// export
+func (r *Retention) Archive(ctx context.Context, poolID, archiveID int) (*models.ChatArchive, []byte, error) {
+	archive, data, err := r.store.Chat.ArchiveData(ctx, poolID, archiveID)
+	if err != nil {
+		return nil, nil, err
+	}
+	r.setExpiry(archive)
+	return archive, data, nil
+}
+
+func (r *Retention) setExpiry(archive *models.ChatArchive) {
+	if r.archiveFor > 0 {
+		expiresAt := archive.CreatedAt.Add(r.archiveFor)
+		archive.ExpiresAt = &expiresAt
+	}
+}
+
+// compress writes messages as gzip-compressed JSON Lines
+func compress(messages []models.ArchivedChatMessage) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	w := bufio.NewWriter(gz)
+	enc := json.NewEncoder(w)
+	for i := range messages {
+		if err := enc.Encode(&messages[i]); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,139 @@
+// Package corrections lets admins fix a game's score or status when the
+// score feed has it wrong.
+package corrections
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/scores"
+	"touchdown-tally/internal/store"
+	"touchdown-tally/pkg/logger"
+)
+
+var (
+	ErrInvalidStatus  = errors.New("unknown game status")
+	ErrNoChange       = errors.New("correction does not change the game")
+	ErrScoreNotPlayed = errors.New("a game that has not kicked off cannot have a score")
+)
+
+// Service records hand corrections to games
+type Service struct {
+	store  *store.Store
+	events scores.Publisher
+	logger *logger.Logger
+	now    func() time.Time
+}
+
+// NewService creates a corrections service that publishes the status
+// changes it makes to events
+func NewService(st *store.Store, events scores.Publisher, logger *logger.Logger) *Service {
+	return &Service{
+		store:  st,
+		events: events,
+		logger: logger,
+		now:    func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Correct overrides a game's score and status on behalf of userID. A
+// corrected game is no longer updated by the score feed. Changing the score
+// of a final game makes it corrected, as it would from the feed.
+//
+// Corrections are the one write not held to models.GameStatusPath: a game
+// may be moved to any known status, since a correction may have to undo a
+// wrong one. Status changes are published like a feed's, one allowed step
+// at a time where the state machine has a path and as a single step where
+// it does not. A new result for a
+// game already corrected is published as corrected to corrected so that
+// standings are recomputed.
+func (s *Service) Correct(ctx context.Context, gameID, userID int, req models.GameCorrectionRequest) (*models.GameCorrection, error) {
+	game, err := s.store.Games.Get(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	correction := &models.GameCorrection{
+		GameID:            gameID,
+		CorrectedBy:       userID,
+		Reason:            req.Reason,
+		OriginalHomeScore: game.HomeScore,
+		OriginalAwayScore: game.AwayScore,
+		OriginalStatus:    game.Status,
+		HomeScore:         game.HomeScore,
+		AwayScore:         game.AwayScore,
+		Status:            game.Status,
+	}
+	if req.HomeScore != nil {
+		correction.HomeScore = *req.HomeScore
+	}
+	if req.AwayScore != nil {
+		correction.AwayScore = *req.AwayScore
+	}
+	if req.Status != "" {
+		if !models.ValidGameStatus(req.Status) {
+			return nil, ErrInvalidStatus
+		}
+		correction.Status = req.Status
+	}
+
+	rescored := correction.HomeScore != game.HomeScore || correction.AwayScore != game.AwayScore
+	if rescored && models.IsFinalStatus(game.Status) && models.IsFinalStatus(correction.Status) {
+		correction.Status = models.GameStatusCorrected
+	}
+	if models.IsPendingStatus(correction.Status) && (correction.HomeScore != 0 || correction.AwayScore != 0) {
+		return nil, ErrScoreNotPlayed
+	}
+	if !rescored && correction.Status == game.Status {
+		return nil, ErrNoChange
+	}
+
+	if err := s.store.Games.Correct(ctx, correction); err != nil {
+		return nil, err
+	}
+	correction.CreatedAt = s.now()
+
+	s.logger.Info("Game corrected", "game_id", gameID, "user_id", userID,
+		"from_status", game.Status, "to_status", correction.Status,
+		"home_score", correction.HomeScore, "away_score", correction.AwayScore)
+
+	s.events.Publish(ctx, s.transitions(game.NFLGame, correction, rescored))
+	s.events.PublishUpdates(ctx, []int{gameID})
+	return correction, nil
+}
+
+// History returns a game's corrections, newest first
+func (s *Service) History(ctx context.Context, gameID int) ([]models.GameCorrection, error) {
+	if _, err := s.store.Games.Get(ctx, gameID); err != nil {
+		return nil, err
+	}
+	return s.store.Games.Corrections(ctx, gameID)
+}
+
+func (s *Service) transitions(game models.NFLGame, correction *models.GameCorrection, rescored bool) []models.GameTransition {
+	transition := models.GameTransition{
+		GameID:     game.GameID,
+		SeasonYear: game.SeasonYear,
+		Week:       game.Week,
+		From:       game.Status,
+		At:         s.now(),
+	}
+
+	path, ok := models.GameStatusPath(game.Status, correction.Status)
+	if !ok {
+		path = []string{correction.Status}
+	}
+	if len(path) == 0 && rescored && models.IsFinalStatus(correction.Status) {
+		path = []string{correction.Status}
+	}
+
+	transitions := make([]models.GameTransition, 0, len(path))
+	for _, status := range path {
+		transition.To = status
+		transitions = append(transitions, transition)
+		transition.From = status
+	}
+	return transitions
+}
@@ -0,0 +1,149 @@
+package corrections
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store/memstore"
+	"touchdown-tally/pkg/logger"
+)
+
+// recorder collects what the service publishes
+type recorder struct {
+	transitions []models.GameTransition
+	updates     []int
+}
+
+func (r *recorder) Publish(ctx context.Context, transitions []models.GameTransition) {
+	r.transitions = append(r.transitions, transitions...)
+}
+
+func (r *recorder) PublishUpdates(ctx context.Context, gameIDs []int) {
+	r.updates = append(r.updates, gameIDs...)
+}
+
+// newService returns a service over an in-memory store holding one game
+// with the given status and score
+func newService(t *testing.T, status string, home, away int) (*Service, *memstore.DB, *recorder, int) {
+	t.Helper()
+
+	db := memstore.New()
+	db.AddTeam(models.NFLTeam{TeamID: 1, TeamName: "Bills"})
+	db.AddTeam(models.NFLTeam{TeamID: 2, TeamName: "Dolphins"})
+	gameID := db.AddGame(models.NFLGame{
+		SeasonYear: 2025,
+		Week:       3,
+		HomeTeamID: 1,
+		AwayTeamID: 2,
+		GameDate:   time.Date(2025, 9, 21, 17, 0, 0, 0, time.UTC),
+		HomeScore:  home,
+		AwayScore:  away,
+		Status:     status,
+	})
+
+	events := &recorder{}
+	quiet := &logger.Logger{Logger: log.New(io.Discard, "", 0)}
+	return NewService(db.Store(), events, quiet), db, events, gameID
+}
+
+func score(n int) *int {
+	return &n
+}
+
+// TestCorrectOutsideStatusPath checks the deliberate exception to the game
+// status state machine: a correction may undo a status the feed got wrong
+func TestCorrectOutsideStatusPath(t *testing.T) {
+	if _, ok := models.GameStatusPath(models.GameStatusCompleted, models.GameStatusInProgress); ok {
+		t.Fatal("completed to in_progress is an allowed transition; pick another for this test")
+	}
+
+	s, db, events, gameID := newService(t, models.GameStatusCompleted, 21, 14)
+	ctx := context.Background()
+
+	correction, err := s.Correct(ctx, gameID, 7, models.GameCorrectionRequest{
+		Status: models.GameStatusInProgress,
+		Reason: "Feed ended the game at halftime",
+	})
+	if err != nil {
+		t.Fatalf("Correct: %v", err)
+	}
+	if correction.OriginalStatus != models.GameStatusCompleted || correction.Status != models.GameStatusInProgress {
+		t.Fatalf("correction = %+v", correction)
+	}
+
+	game, err := db.Store().Games.Get(ctx, gameID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if game.Status != models.GameStatusInProgress || !game.Overridden {
+		t.Fatalf("game status %q overridden %v", game.Status, game.Overridden)
+	}
+
+	want := models.GameTransition{GameID: gameID, SeasonYear: 2025, Week: 3, From: models.GameStatusCompleted, To: models.GameStatusInProgress}
+	if len(events.transitions) != 1 {
+		t.Fatalf("published %d transitions, want 1", len(events.transitions))
+	}
+	got := events.transitions[0]
+	got.At = time.Time{}
+	if got != want {
+		t.Fatalf("transition = %+v, want %+v", got, want)
+	}
+	if len(events.updates) != 1 || events.updates[0] != gameID {
+		t.Fatalf("updates = %v", events.updates)
+	}
+
+	history, err := s.History(ctx, gameID)
+	if err != nil || len(history) != 1 || history[0].Reason != "Feed ended the game at halftime" {
+		t.Fatalf("History = %+v, %v", history, err)
+	}
+}
+
+func TestCorrectRescoresFinalGame(t *testing.T) {
+	s, _, events, gameID := newService(t, models.GameStatusCompleted, 21, 14)
+
+	correction, err := s.Correct(context.Background(), gameID, 7, models.GameCorrectionRequest{
+		HomeScore: score(24),
+		Reason:    "Late field goal missing",
+	})
+	if err != nil {
+		t.Fatalf("Correct: %v", err)
+	}
+	if correction.Status != models.GameStatusCorrected || correction.HomeScore != 24 || correction.AwayScore != 14 {
+		t.Fatalf("correction = %+v", correction)
+	}
+	if len(events.transitions) != 1 || events.transitions[0].To != models.GameStatusCorrected {
+		t.Fatalf("transitions = %+v", events.transitions)
+	}
+}
+
+func TestCorrectRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		status string
+		req    models.GameCorrectionRequest
+		want   error
+	}{
+		{"unknown status", models.GameStatusCompleted, models.GameCorrectionRequest{Status: "final"}, ErrInvalidStatus},
+		{"score before kickoff", models.GameStatusScheduled, models.GameCorrectionRequest{HomeScore: score(3)}, ErrScoreNotPlayed},
+		{"no change", models.GameStatusCompleted, models.GameCorrectionRequest{HomeScore: score(0)}, ErrNoChange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _, events, gameID := newService(t, tt.status, 0, 0)
+			tt.req.Reason = "test"
+
+			if _, err := s.Correct(context.Background(), gameID, 7, tt.req); !errors.Is(err, tt.want) {
+				t.Fatalf("Correct error = %v, want %v", err, tt.want)
+			}
+			if len(events.transitions) != 0 || len(events.updates) != 0 {
+				t.Fatalf("published %+v and %v for a rejected correction", events.transitions, events.updates)
+			}
+		})
+	}
+}
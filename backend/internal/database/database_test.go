@@ -0,0 +1,181 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// postgresURLEnv names a PostgreSQL database the tests may use. Each test
+// works in a schema of its own that is dropped afterwards.
+const postgresURLEnv = "TEST_POSTGRES_URL"
+
+// forEachDriver runs fn against a fresh SQLite database and, when
+// TEST_POSTGRES_URL is set, a fresh PostgreSQL schema
+func forEachDriver(t *testing.T, fn func(t *testing.T, db *DB)) {
+	t.Run(DriverSQLite, func(t *testing.T) {
+		fn(t, openSQLite(t))
+	})
+	t.Run(DriverPostgres, func(t *testing.T) {
+		fn(t, openPostgres(t))
+	})
+}
+
+func openSQLite(t *testing.T) *DB {
+	t.Helper()
+	db, err := Connect("sqlite://" + filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("connect to sqlite: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func openPostgres(t *testing.T) *DB {
+	t.Helper()
+	rawURL := os.Getenv(postgresURLEnv)
+	if rawURL == "" {
+		t.Skipf("%s is not set", postgresURLEnv)
+	}
+
+	admin, err := Connect(rawURL)
+	if err != nil {
+		t.Fatalf("connect to postgres: %v", err)
+	}
+	t.Cleanup(func() { admin.Close() })
+
+	schema := fmt.Sprintf("test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := admin.Exec("DROP SCHEMA " + schema + " CASCADE"); err != nil {
+			t.Errorf("drop schema: %v", err)
+		}
+	})
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatalf("parse %s: %v", postgresURLEnv, err)
+	}
+	query := u.Query()
+	query.Set("search_path", schema)
+	u.RawQuery = query.Encode()
+
+	db, err := Connect(u.String())
+	if err != nil {
+		t.Fatalf("connect to postgres schema: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestMigrationRoundTrip(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, db *DB) {
+		ctx := context.Background()
+		migrator, err := NewMigrator(db)
+		if err != nil {
+			t.Fatalf("NewMigrator: %v", err)
+		}
+
+		if _, err := migrator.Up(ctx); err != nil {
+			t.Fatalf("Up: %v", err)
+		}
+		if version, err := migrator.Version(ctx); err != nil || version != migrator.Latest() {
+			t.Fatalf("Version = %d, %v; want %d", version, err, migrator.Latest())
+		}
+		if err := VerifySchema(ctx, db); err != nil {
+			t.Fatalf("VerifySchema: %v", err)
+		}
+		migrated, err := tableColumns(ctx, db)
+		if err != nil {
+			t.Fatalf("tableColumns: %v", err)
+		}
+
+		if _, err := migrator.To(ctx, 0); err != nil {
+			t.Fatalf("To(0): %v", err)
+		}
+		empty, err := tableColumns(ctx, db)
+		if err != nil {
+			t.Fatalf("tableColumns: %v", err)
+		}
+		for table := range empty {
+			if table != "schema_migrations" {
+				t.Errorf("table %s left behind after reverting every migration", table)
+			}
+		}
+
+		if _, err := migrator.Up(ctx); err != nil {
+			t.Fatalf("Up after revert: %v", err)
+		}
+		again, err := tableColumns(ctx, db)
+		if err != nil {
+			t.Fatalf("tableColumns: %v", err)
+		}
+		if !reflect.DeepEqual(migrated, again) {
+			t.Errorf("schema differs after reapplying migrations:\nfirst  %v\nsecond %v", migrated, again)
+		}
+	})
+}
+
+func TestMigrateAdoptsLegacyPools(t *testing.T) {
+	db := openPostgres(t)
+	legacy := []string{
+		`CREATE TABLE email_accounts (
+			email_id SERIAL PRIMARY KEY,
+			email_address VARCHAR(255) UNIQUE NOT NULL,
+			password_hash VARCHAR(255) NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE user_profiles (
+			user_id SERIAL PRIMARY KEY,
+			email_id INTEGER REFERENCES email_accounts(email_id) ON DELETE CASCADE,
+			username VARCHAR(50) NOT NULL,
+			display_name VARCHAR(100) NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE(email_id, username)
+		)`,
+		`CREATE TABLE pools (
+			pool_id SERIAL PRIMARY KEY,
+			pool_name VARCHAR(100) NOT NULL,
+			pool_code VARCHAR(20) UNIQUE NOT NULL,
+			season_year INTEGER NOT NULL,
+			created_by INTEGER REFERENCES user_profiles(user_id) ON DELETE SET NULL,
+			max_members INTEGER DEFAULT 50,
+			entry_fee DECIMAL(10,2) DEFAULT 0.00,
+			prize_structure JSONB,
+			settings JSONB,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`INSERT INTO email_accounts (email_address, password_hash) VALUES ('old@example.com', 'hash')`,
+		`INSERT INTO user_profiles (email_id, username, display_name) VALUES (1, 'old', 'Old Timer')`,
+		`INSERT INTO pools (pool_name, pool_code, season_year, created_by) VALUES ('Legacy League', 'LEGACY', 2024, 1)`,
+	}
+	for _, statement := range legacy {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("create legacy schema: %v", err)
+		}
+	}
+
+	if err := Migrate(db); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	var name string
+	var commissionerID int
+	if err := db.QueryRow("SELECT pool_name, commissioner_id FROM pools WHERE pool_code = 'LEGACY'").Scan(&name, &commissionerID); err != nil {
+		t.Fatalf("read legacy pool: %v", err)
+	}
+	if name != "Legacy League" || commissionerID != 1 {
+		t.Errorf("legacy pool = %q commissioned by %d", name, commissionerID)
+	}
+	if _, err := db.Exec("INSERT INTO pools (pool_name, season_year, commissioner_id) VALUES ('New League', 2025, 1)"); err != nil {
+		t.Errorf("create pool without a code: %v", err)
+	}
+}
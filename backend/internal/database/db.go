@@ -0,0 +1,118 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+)
+
+// DB wraps *sql.DB and rebinds every query for the connected dialect, so
+// callers write portable SQL with ? placeholders
+type DB struct {
+	*sql.DB
+	dialect Dialect
+}
+
+// Tx wraps *sql.Tx with the same rebinding behaviour as DB
+type Tx struct {
+	*sql.Tx
+	dialect Dialect
+}
+
+// Dialect returns the SQL dialect of the connected database
+func (db *DB) Dialect() Dialect {
+	return db.dialect
+}
+
+// Exec executes a query without returning any rows
+func (db *DB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return db.ExecContext(context.Background(), query, args...)
+}
+
+// ExecContext executes a query without returning any rows
+func (db *DB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return db.DB.ExecContext(ctx, db.dialect.Rebind(query), args...)
+}
+
+// Query executes a query that returns rows
+func (db *DB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return db.QueryContext(context.Background(), query, args...)
+}
+
+// QueryContext executes a query that returns rows
+func (db *DB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return db.DB.QueryContext(ctx, db.dialect.Rebind(query), args...)
+}
+
+// QueryRow executes a query that is expected to return at most one row
+func (db *DB) QueryRow(query string, args ...interface{}) *sql.Row {
+	return db.QueryRowContext(context.Background(), query, args...)
+}
+
+// QueryRowContext executes a query that is expected to return at most one row
+func (db *DB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return db.DB.QueryRowContext(ctx, db.dialect.Rebind(query), args...)
+}
+
+// InsertReturningID executes an INSERT and returns the generated idColumn value
+func (db *DB) InsertReturningID(ctx context.Context, query, idColumn string, args ...interface{}) (int64, error) {
+	return db.dialect.InsertReturningID(ctx, db.DB, db.dialect.Rebind(query), idColumn, args...)
+}
+
+// IsUniqueViolation reports whether err was caused by a unique constraint
+func (db *DB) IsUniqueViolation(err error) bool {
+	return db.dialect.IsUniqueViolation(err)
+}
+
+// Begin starts a transaction
+func (db *DB) Begin() (*Tx, error) {
+	return db.BeginTx(context.Background(), nil)
+}
+
+// BeginTx starts a transaction with the given options
+func (db *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	tx, err := db.DB.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx, dialect: db.dialect}, nil
+}
+
+// Exec executes a query without returning any rows
+func (tx *Tx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return tx.ExecContext(context.Background(), query, args...)
+}
+
+// ExecContext executes a query without returning any rows
+func (tx *Tx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return tx.Tx.ExecContext(ctx, tx.dialect.Rebind(query), args...)
+}
+
+// Query executes a query that returns rows
+func (tx *Tx) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return tx.QueryContext(context.Background(), query, args...)
+}
+
+// QueryContext executes a query that returns rows
+func (tx *Tx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return tx.Tx.QueryContext(ctx, tx.dialect.Rebind(query), args...)
+}
+
+// QueryRow executes a query that is expected to return at most one row
+func (tx *Tx) QueryRow(query string, args ...interface{}) *sql.Row {
+	return tx.QueryRowContext(context.Background(), query, args...)
+}
+
+// QueryRowContext executes a query that is expected to return at most one row
+func (tx *Tx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return tx.Tx.QueryRowContext(ctx, tx.dialect.Rebind(query), args...)
+}
+
+// InsertReturningID executes an INSERT and returns the generated idColumn value
+func (tx *Tx) InsertReturningID(ctx context.Context, query, idColumn string, args ...interface{}) (int64, error) {
+	return tx.dialect.InsertReturningID(ctx, tx.Tx, tx.dialect.Rebind(query), idColumn, args...)
+}
+
+// IsUniqueViolation reports whether err was caused by a unique constraint
+func (tx *Tx) IsUniqueViolation(err error) bool {
+	return tx.dialect.IsUniqueViolation(err)
+}
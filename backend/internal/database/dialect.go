@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+)
+
+// Driver names as registered with database/sql
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite3"
+)
+
+// execQueryer is satisfied by both *sql.DB and *sql.Tx
+type execQueryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Dialect captures the SQL differences between the supported databases.
+// Queries are written once with ? placeholders and adapted per dialect.
+type Dialect interface {
+	// Name returns the database/sql driver name
+	Name() string
+
+	// Rebind rewrites ? placeholders into the dialect's bind syntax
+	Rebind(query string) string
+
+	// InsertReturningID executes an already rebound INSERT and returns the
+	// generated value of idColumn
+	InsertReturningID(ctx context.Context, q execQueryer, query, idColumn string, args ...interface{}) (int64, error)
+
+	// IsUniqueViolation reports whether err was caused by a unique constraint
+	IsUniqueViolation(err error) bool
+}
+
+// dialectFor returns the Dialect for a driver name
+func dialectFor(driverName string) Dialect {
+	if driverName == DriverSQLite {
+		return sqliteDialect{}
+	}
+	return postgresDialect{}
+}
+
+type postgresDialect struct{}
+
+func (postgresDialect) Name() string { return DriverPostgres }
+
+// Rebind replaces each ? outside of quoted strings and identifiers with $n
+func (postgresDialect) Rebind(query string) string {
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+
+	n := 0
+	var quote byte
+	for i := 0; i < len(query); i++ {
+		ch := query[i]
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '\'' || ch == '"':
+			quote = ch
+		case ch == '?':
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+		b.WriteByte(ch)
+	}
+
+	return b.String()
+}
+
+func (postgresDialect) InsertReturningID(ctx context.Context, q execQueryer, query, idColumn string, args ...interface{}) (int64, error) {
+	var id int64
+	err := q.QueryRowContext(ctx, query+" RETURNING "+idColumn, args...).Scan(&id)
+	return id, err
+}
+
+func (postgresDialect) IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string { return DriverSQLite }
+
+// Rebind is a no-op because SQLite accepts ? placeholders natively
+func (sqliteDialect) Rebind(query string) string { return query }
+
+func (sqliteDialect) InsertReturningID(ctx context.Context, q execQueryer, query, idColumn string, args ...interface{}) (int64, error) {
+	result, err := q.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (sqliteDialect) IsUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	return sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique ||
+		sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey
+}
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestPostgresRebind(t *testing.T) {
+	tests := []struct {
+		name, query, want string
+	}{
+		{"no placeholders", "SELECT 1", "SELECT 1"},
+		{"numbered in order", "SELECT * FROM t WHERE a = ? AND b = ?", "SELECT * FROM t WHERE a = $1 AND b = $2"},
+		{"string literal", "SELECT '?' FROM t WHERE a = ?", "SELECT '?' FROM t WHERE a = $1"},
+		{"quoted identifier", `SELECT "a?" FROM t WHERE b = ?`, `SELECT "a?" FROM t WHERE b = $1`},
+		{"escaped quote", "SELECT 'it''s ?' FROM t WHERE a = ?", "SELECT 'it''s ?' FROM t WHERE a = $1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (postgresDialect{}).Rebind(tt.query); got != tt.want {
+				t.Errorf("Rebind(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLiteRebind(t *testing.T) {
+	query := "SELECT * FROM t WHERE a = ? AND b = '?'"
+	if got := (sqliteDialect{}).Rebind(query); got != query {
+		t.Errorf("Rebind(%q) = %q, want it unchanged", query, got)
+	}
+}
+
+// createItems creates a table with a generated key and a unique name
+func createItems(t *testing.T, db *DB) {
+	t.Helper()
+	ddl := `CREATE TABLE items (item_id SERIAL PRIMARY KEY, name VARCHAR(50) NOT NULL UNIQUE)`
+	if db.Dialect().Name() == DriverSQLite {
+		ddl = `CREATE TABLE items (item_id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL UNIQUE)`
+	}
+	if _, err := db.Exec(ddl); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+}
+
+func TestInsertReturningID(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, db *DB) {
+		ctx := context.Background()
+		createItems(t, db)
+
+		first, err := db.InsertReturningID(ctx, `INSERT INTO items (name) VALUES (?)`, "item_id", "first")
+		if err != nil {
+			t.Fatalf("insert: %v", err)
+		}
+
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			t.Fatalf("begin: %v", err)
+		}
+		defer tx.Rollback()
+		second, err := tx.InsertReturningID(ctx, `INSERT INTO items (name) VALUES (?)`, "item_id", "second")
+		if err != nil {
+			t.Fatalf("insert in transaction: %v", err)
+		}
+		if err := tx.Commit(); err != nil {
+			t.Fatalf("commit: %v", err)
+		}
+
+		if first <= 0 || second <= first {
+			t.Fatalf("ids = %d, %d; want increasing positive ids", first, second)
+		}
+		var name string
+		if err := db.QueryRow(`SELECT name FROM items WHERE item_id = ?`, second).Scan(&name); err != nil || name != "second" {
+			t.Errorf("item %d = %q, %v; want \"second\"", second, name, err)
+		}
+	})
+}
+
+func TestIsUniqueViolation(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, db *DB) {
+		createItems(t, db)
+		if _, err := db.Exec(`INSERT INTO items (name) VALUES (?)`, "taken"); err != nil {
+			t.Fatalf("insert: %v", err)
+		}
+
+		_, err := db.Exec(`INSERT INTO items (name) VALUES (?)`, "taken")
+		if err == nil {
+			t.Fatal("duplicate insert succeeded")
+		}
+		if !db.IsUniqueViolation(err) {
+			t.Errorf("IsUniqueViolation(%v) = false, want true", err)
+		}
+
+		_, err = db.Exec(`INSERT INTO items (name) VALUES (?)`, nil)
+		if err == nil {
+			t.Fatal("insert of a null name succeeded")
+		}
+		if db.IsUniqueViolation(err) {
+			t.Errorf("IsUniqueViolation(%v) = true for a not-null violation", err)
+		}
+
+		if db.IsUniqueViolation(errors.New("other")) || db.IsUniqueViolation(nil) {
+			t.Error("IsUniqueViolation reported an unrelated error")
+		}
+	})
+}
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS chat_messages;
+DROP TABLE IF EXISTS season_picks;
+DROP TABLE IF EXISTS nfl_games;
+DROP TABLE IF EXISTS pool_memberships;
+DROP TABLE IF EXISTS pools;
+DROP TABLE IF EXISTS nfl_teams;
+DROP TABLE IF EXISTS roles;
+DROP TABLE IF EXISTS user_profiles;
+DROP TABLE IF EXISTS email_accounts;
@@ -0,0 +1,126 @@
+-- Tables use IF NOT EXISTS so databases created before versioned migrations
+-- can adopt this baseline without losing data. Their pools table predates
+-- the current columns and is brought up to date below.
+
+CREATE TABLE IF NOT EXISTS email_accounts (
+	email_id SERIAL PRIMARY KEY,
+	email_address VARCHAR(255) UNIQUE NOT NULL,
+	password_hash VARCHAR(255) NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS user_profiles (
+	user_id SERIAL PRIMARY KEY,
+	email_id INTEGER REFERENCES email_accounts(email_id) ON DELETE CASCADE,
+	username VARCHAR(50) NOT NULL,
+	display_name VARCHAR(100) NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE(email_id, username)
+);
+
+CREATE TABLE IF NOT EXISTS roles (
+	role_id SERIAL PRIMARY KEY,
+	role_name VARCHAR(50) UNIQUE NOT NULL,
+	description TEXT
+);
+
+CREATE TABLE IF NOT EXISTS nfl_teams (
+	team_id SERIAL PRIMARY KEY,
+	team_name VARCHAR(100) NOT NULL,
+	team_abbreviation VARCHAR(10) UNIQUE NOT NULL,
+	city VARCHAR(100) NOT NULL,
+	conference VARCHAR(10) NOT NULL CHECK (conference IN ('NFC', 'AFC')),
+	division VARCHAR(10) NOT NULL,
+	logo_url TEXT,
+	primary_color VARCHAR(7),
+	secondary_color VARCHAR(7),
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS pools (
+	pool_id SERIAL PRIMARY KEY,
+	pool_name VARCHAR(100) NOT NULL,
+	pool_code VARCHAR(20) UNIQUE,
+	description TEXT,
+	commissioner_id INTEGER REFERENCES user_profiles(user_id) ON DELETE SET NULL,
+	season_year INTEGER NOT NULL,
+	max_members INTEGER DEFAULT 50,
+	entry_fee DECIMAL(10,2) DEFAULT 0.00,
+	prize_structure JSONB,
+	pool_type VARCHAR(20) DEFAULT 'survivor',
+	status VARCHAR(20) DEFAULT 'active',
+	settings JSONB,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Earlier pools named the commissioner created_by, required a pool code and
+-- had no description, type or status
+DO $$
+BEGIN
+	IF EXISTS (
+		SELECT 1 FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = 'pools' AND column_name = 'created_by'
+	) THEN
+		ALTER TABLE pools RENAME COLUMN created_by TO commissioner_id;
+	END IF;
+END $$;
+ALTER TABLE pools ADD COLUMN IF NOT EXISTS description TEXT;
+ALTER TABLE pools ADD COLUMN IF NOT EXISTS commissioner_id INTEGER REFERENCES user_profiles(user_id) ON DELETE SET NULL;
+ALTER TABLE pools ADD COLUMN IF NOT EXISTS pool_type VARCHAR(20) DEFAULT 'survivor';
+ALTER TABLE pools ADD COLUMN IF NOT EXISTS status VARCHAR(20) DEFAULT 'active';
+ALTER TABLE pools ALTER COLUMN pool_code DROP NOT NULL;
+
+CREATE TABLE IF NOT EXISTS pool_memberships (
+	membership_id SERIAL PRIMARY KEY,
+	pool_id INTEGER REFERENCES pools(pool_id) ON DELETE CASCADE,
+	user_id INTEGER REFERENCES user_profiles(user_id) ON DELETE CASCADE,
+	role_id INTEGER REFERENCES roles(role_id) DEFAULT 2, -- Default to member role
+	joined_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE(pool_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS nfl_games (
+	game_id SERIAL PRIMARY KEY,
+	external_id VARCHAR(50) UNIQUE, -- MySportsFeeds game ID
+	season_year INTEGER NOT NULL,
+	week INTEGER NOT NULL,
+	game_type VARCHAR(20) DEFAULT 'regular', -- regular, playoff, superbowl
+	home_team_id INTEGER REFERENCES nfl_teams(team_id),
+	away_team_id INTEGER REFERENCES nfl_teams(team_id),
+	game_date TIMESTAMP NOT NULL,
+	home_score INTEGER DEFAULT 0,
+	away_score INTEGER DEFAULT 0,
+	status VARCHAR(20) DEFAULT 'scheduled', -- scheduled, in_progress, completed, postponed
+	quarter INTEGER DEFAULT 0,
+	time_remaining VARCHAR(10),
+	last_updated TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS season_picks (
+	pick_id SERIAL PRIMARY KEY,
+	pool_id INTEGER REFERENCES pools(pool_id) ON DELETE CASCADE,
+	user_id INTEGER REFERENCES user_profiles(user_id) ON DELETE CASCADE,
+	team_id INTEGER REFERENCES nfl_teams(team_id),
+	pick_order INTEGER CHECK (pick_order BETWEEN 1 AND 4),
+	points_scored INTEGER DEFAULT 0,
+	is_eliminated BOOLEAN DEFAULT FALSE,
+	elimination_week INTEGER,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE(pool_id, user_id, pick_order),
+	UNIQUE(pool_id, team_id) -- Each team can only be picked once per pool
+);
+
+CREATE TABLE IF NOT EXISTS chat_messages (
+	message_id SERIAL PRIMARY KEY,
+	pool_id INTEGER REFERENCES pools(pool_id) ON DELETE CASCADE,
+	user_id INTEGER REFERENCES user_profiles(user_id) ON DELETE CASCADE,
+	content TEXT NOT NULL,
+	message_type VARCHAR(20) DEFAULT 'user_message', -- user_message, system_message, moderation_action
+	is_deleted BOOLEAN DEFAULT FALSE,
+	deleted_by INTEGER REFERENCES user_profiles(user_id),
+	deleted_at TIMESTAMP,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
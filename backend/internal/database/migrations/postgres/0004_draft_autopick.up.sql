@@ -0,0 +1,20 @@
+-- Ranked team queues used to pick for members who miss their turn
+CREATE TABLE draft_queues (
+	pool_id INTEGER NOT NULL REFERENCES pools(pool_id) ON DELETE CASCADE,
+	user_id INTEGER NOT NULL REFERENCES user_profiles(user_id) ON DELETE CASCADE,
+	rank INTEGER NOT NULL,
+	team_id INTEGER NOT NULL REFERENCES nfl_teams(team_id),
+	PRIMARY KEY (pool_id, user_id, rank),
+	UNIQUE (pool_id, user_id, team_id)
+);
+
+-- Members whose picks are always made automatically
+CREATE TABLE draft_absences (
+	pool_id INTEGER NOT NULL REFERENCES pools(pool_id) ON DELETE CASCADE,
+	user_id INTEGER NOT NULL REFERENCES user_profiles(user_id) ON DELETE CASCADE,
+	flagged_by INTEGER REFERENCES user_profiles(user_id) ON DELETE SET NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (pool_id, user_id)
+);
+
+ALTER TABLE draft_picks ADD COLUMN is_auto BOOLEAN NOT NULL DEFAULT FALSE;
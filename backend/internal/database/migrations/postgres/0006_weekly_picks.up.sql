@@ -0,0 +1,14 @@
+-- Per-game winner picks for weekly pick'em pools
+CREATE TABLE weekly_picks (
+	weekly_pick_id SERIAL PRIMARY KEY,
+	pool_id INTEGER NOT NULL REFERENCES pools(pool_id) ON DELETE CASCADE,
+	user_id INTEGER NOT NULL REFERENCES user_profiles(user_id) ON DELETE CASCADE,
+	game_id INTEGER NOT NULL REFERENCES nfl_games(game_id) ON DELETE CASCADE,
+	picked_team_id INTEGER NOT NULL REFERENCES nfl_teams(team_id),
+	is_correct BOOLEAN, -- NULL until the game is final
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE (pool_id, user_id, game_id)
+);
+
+CREATE INDEX idx_weekly_picks_game ON weekly_picks(game_id);
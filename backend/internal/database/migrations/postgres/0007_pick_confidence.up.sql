@@ -0,0 +1,2 @@
+-- Confidence pools rank each week's games from N down to 1
+ALTER TABLE weekly_picks ADD COLUMN confidence INTEGER;
@@ -0,0 +1,26 @@
+-- One team per member per week in survivor pools; a team can only be used once
+CREATE TABLE survivor_picks (
+	survivor_pick_id SERIAL PRIMARY KEY,
+	pool_id INTEGER NOT NULL REFERENCES pools(pool_id) ON DELETE CASCADE,
+	user_id INTEGER NOT NULL REFERENCES user_profiles(user_id) ON DELETE CASCADE,
+	week INTEGER NOT NULL,
+	team_id INTEGER NOT NULL REFERENCES nfl_teams(team_id),
+	game_id INTEGER NOT NULL REFERENCES nfl_games(game_id) ON DELETE CASCADE,
+	result VARCHAR(10) NOT NULL DEFAULT 'pending', -- pending, win, loss, tie
+	is_auto BOOLEAN NOT NULL DEFAULT FALSE,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE (pool_id, user_id, week),
+	UNIQUE (pool_id, user_id, team_id)
+);
+
+CREATE TABLE survivor_eliminations (
+	pool_id INTEGER NOT NULL REFERENCES pools(pool_id) ON DELETE CASCADE,
+	user_id INTEGER NOT NULL REFERENCES user_profiles(user_id) ON DELETE CASCADE,
+	week INTEGER NOT NULL,
+	reason VARCHAR(20) NOT NULL, -- loss, tie, missed_pick
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (pool_id, user_id)
+);
+
+CREATE INDEX idx_survivor_picks_game ON survivor_picks(game_id);
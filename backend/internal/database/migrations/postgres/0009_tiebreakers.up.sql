@@ -0,0 +1,11 @@
+-- Predicted total points in a pool's tiebreaker game, one per member per game
+CREATE TABLE tiebreakers (
+	tiebreaker_id SERIAL PRIMARY KEY,
+	pool_id INTEGER NOT NULL REFERENCES pools(pool_id) ON DELETE CASCADE,
+	user_id INTEGER NOT NULL REFERENCES user_profiles(user_id) ON DELETE CASCADE,
+	game_id INTEGER NOT NULL REFERENCES nfl_games(game_id) ON DELETE CASCADE,
+	total_points INTEGER NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE (pool_id, user_id, game_id)
+);
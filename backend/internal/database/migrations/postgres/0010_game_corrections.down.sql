@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS game_corrections;
+ALTER TABLE nfl_games DROP COLUMN overridden;
@@ -0,0 +1,19 @@
+-- Commissioner and admin changes to a game's score or status. overridden
+-- marks games the score feed must no longer update.
+ALTER TABLE nfl_games ADD COLUMN overridden BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE game_corrections (
+	correction_id SERIAL PRIMARY KEY,
+	game_id INTEGER NOT NULL REFERENCES nfl_games(game_id) ON DELETE CASCADE,
+	corrected_by INTEGER REFERENCES user_profiles(user_id) ON DELETE SET NULL,
+	reason TEXT NOT NULL,
+	original_home_score INTEGER NOT NULL,
+	original_away_score INTEGER NOT NULL,
+	original_status VARCHAR(20) NOT NULL,
+	home_score INTEGER NOT NULL,
+	away_score INTEGER NOT NULL,
+	status VARCHAR(20) NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_game_corrections_game ON game_corrections(game_id, created_at);
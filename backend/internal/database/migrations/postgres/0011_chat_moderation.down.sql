@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_chat_messages_pool_user;
+DROP TABLE IF EXISTS chat_mutes;
+DROP TABLE IF EXISTS chat_moderation_actions;
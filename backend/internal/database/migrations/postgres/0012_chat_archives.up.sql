@@ -0,0 +1,14 @@
+-- Chat messages pruned by a pool's retention policy. Each row is one
+-- gzip-compressed JSON Lines export the commissioner can download.
+CREATE TABLE chat_archives (
+	archive_id SERIAL PRIMARY KEY,
+	pool_id INTEGER NOT NULL REFERENCES pools(pool_id) ON DELETE CASCADE,
+	message_count INTEGER NOT NULL,
+	first_message_at TIMESTAMP NOT NULL,
+	last_message_at TIMESTAMP NOT NULL,
+	size_bytes INTEGER NOT NULL,
+	data BYTEA NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_chat_archives_pool ON chat_archives(pool_id, created_at);
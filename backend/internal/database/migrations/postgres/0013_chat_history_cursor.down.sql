@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_chat_messages_pool_cursor;
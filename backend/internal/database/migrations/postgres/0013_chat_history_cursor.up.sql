@@ -0,0 +1,2 @@
+-- Chat history is paged by (created_at, message_id) within a pool
+CREATE INDEX idx_chat_messages_pool_cursor ON chat_messages(pool_id, created_at, message_id);
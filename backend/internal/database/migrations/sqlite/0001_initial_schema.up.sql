@@ -0,0 +1,109 @@
+-- Tables use IF NOT EXISTS so the baseline applies cleanly over tables that
+-- already match it. SQLite cannot alter columns in place, so databases whose
+-- tables predate this baseline must be rebuilt.
+
+CREATE TABLE IF NOT EXISTS email_accounts (
+	email_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	email_address TEXT UNIQUE NOT NULL,
+	password_hash TEXT NOT NULL,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS user_profiles (
+	user_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	email_id INTEGER REFERENCES email_accounts(email_id) ON DELETE CASCADE,
+	username TEXT NOT NULL,
+	display_name TEXT NOT NULL,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE(email_id, username)
+);
+
+CREATE TABLE IF NOT EXISTS roles (
+	role_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	role_name TEXT UNIQUE NOT NULL,
+	description TEXT
+);
+
+CREATE TABLE IF NOT EXISTS nfl_teams (
+	team_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	team_name TEXT NOT NULL,
+	team_abbreviation TEXT UNIQUE NOT NULL,
+	city TEXT NOT NULL,
+	conference TEXT NOT NULL CHECK (conference IN ('NFC', 'AFC')),
+	division TEXT NOT NULL,
+	logo_url TEXT,
+	primary_color TEXT,
+	secondary_color TEXT,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS pools (
+	pool_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	pool_name TEXT NOT NULL,
+	pool_code TEXT UNIQUE,
+	description TEXT,
+	commissioner_id INTEGER REFERENCES user_profiles(user_id) ON DELETE SET NULL,
+	season_year INTEGER NOT NULL,
+	max_members INTEGER DEFAULT 50,
+	entry_fee REAL DEFAULT 0.00,
+	prize_structure TEXT,
+	pool_type TEXT DEFAULT 'survivor',
+	status TEXT DEFAULT 'active',
+	settings TEXT,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS pool_memberships (
+	membership_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	pool_id INTEGER REFERENCES pools(pool_id) ON DELETE CASCADE,
+	user_id INTEGER REFERENCES user_profiles(user_id) ON DELETE CASCADE,
+	role_id INTEGER REFERENCES roles(role_id) DEFAULT 2,
+	joined_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE(pool_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS nfl_games (
+	game_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	external_id TEXT UNIQUE,
+	season_year INTEGER NOT NULL,
+	week INTEGER NOT NULL,
+	game_type TEXT DEFAULT 'regular',
+	home_team_id INTEGER REFERENCES nfl_teams(team_id),
+	away_team_id INTEGER REFERENCES nfl_teams(team_id),
+	game_date DATETIME NOT NULL,
+	home_score INTEGER DEFAULT 0,
+	away_score INTEGER DEFAULT 0,
+	status TEXT DEFAULT 'scheduled',
+	quarter INTEGER DEFAULT 0,
+	time_remaining TEXT,
+	last_updated DATETIME DEFAULT CURRENT_TIMESTAMP,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS season_picks (
+	pick_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	pool_id INTEGER REFERENCES pools(pool_id) ON DELETE CASCADE,
+	user_id INTEGER REFERENCES user_profiles(user_id) ON DELETE CASCADE,
+	team_id INTEGER REFERENCES nfl_teams(team_id),
+	pick_order INTEGER CHECK (pick_order BETWEEN 1 AND 4),
+	points_scored INTEGER DEFAULT 0,
+	is_eliminated INTEGER DEFAULT 0,
+	elimination_week INTEGER,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE(pool_id, user_id, pick_order),
+	UNIQUE(pool_id, team_id)
+);
+
+CREATE TABLE IF NOT EXISTS chat_messages (
+	message_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	pool_id INTEGER REFERENCES pools(pool_id) ON DELETE CASCADE,
+	user_id INTEGER REFERENCES user_profiles(user_id) ON DELETE CASCADE,
+	content TEXT NOT NULL,
+	message_type TEXT DEFAULT 'user_message',
+	is_deleted INTEGER DEFAULT 0,
+	deleted_by INTEGER REFERENCES user_profiles(user_id),
+	deleted_at DATETIME,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
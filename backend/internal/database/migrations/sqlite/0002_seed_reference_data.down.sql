@@ -0,0 +1,8 @@
+DELETE FROM nfl_teams WHERE team_abbreviation IN (
+	'ARI', 'ATL', 'BAL', 'BUF', 'CAR', 'CHI', 'CIN', 'CLE',
+	'DAL', 'DEN', 'DET', 'GB', 'HOU', 'IND', 'JAX', 'KC',
+	'LV', 'LAC', 'LAR', 'MIA', 'MIN', 'NE', 'NO', 'NYG',
+	'NYJ', 'PHI', 'PIT', 'SF', 'SEA', 'TB', 'TEN', 'WAS'
+);
+
+DELETE FROM roles WHERE role_name IN ('commissioner', 'member', 'moderator');
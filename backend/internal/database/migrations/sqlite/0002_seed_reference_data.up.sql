@@ -0,0 +1,40 @@
+INSERT INTO roles (role_name, description) VALUES
+('commissioner', 'Pool commissioner with full administrative rights'),
+('member', 'Regular pool member'),
+('moderator', 'Chat moderator with limited administrative rights')
+ON CONFLICT (role_name) DO NOTHING;
+
+INSERT INTO nfl_teams (team_name, team_abbreviation, city, conference, division, primary_color, secondary_color) VALUES
+('Cardinals', 'ARI', 'Arizona', 'NFC', 'West', '#97233F', '#000000'),
+('Falcons', 'ATL', 'Atlanta', 'NFC', 'South', '#A71930', '#000000'),
+('Ravens', 'BAL', 'Baltimore', 'AFC', 'North', '#241773', '#9E7C0C'),
+('Bills', 'BUF', 'Buffalo', 'AFC', 'East', '#00338D', '#C60C30'),
+('Panthers', 'CAR', 'Carolina', 'NFC', 'South', '#0085CA', '#101820'),
+('Bears', 'CHI', 'Chicago', 'NFC', 'North', '#0B162A', '#C83803'),
+('Bengals', 'CIN', 'Cincinnati', 'AFC', 'North', '#FB4F14', '#000000'),
+('Browns', 'CLE', 'Cleveland', 'AFC', 'North', '#311D00', '#FF3C00'),
+('Cowboys', 'DAL', 'Dallas', 'NFC', 'East', '#003594', '#041E42'),
+('Broncos', 'DEN', 'Denver', 'AFC', 'West', '#FB4F14', '#002244'),
+('Lions', 'DET', 'Detroit', 'NFC', 'North', '#0076B6', '#B0B7BC'),
+('Packers', 'GB', 'Green Bay', 'NFC', 'North', '#203731', '#FFB612'),
+('Texans', 'HOU', 'Houston', 'AFC', 'South', '#03202F', '#A71930'),
+('Colts', 'IND', 'Indianapolis', 'AFC', 'South', '#002C5F', '#A2AAAD'),
+('Jaguars', 'JAX', 'Jacksonville', 'AFC', 'South', '#006778', '#9F792C'),
+('Chiefs', 'KC', 'Kansas City', 'AFC', 'West', '#E31837', '#FFB81C'),
+('Raiders', 'LV', 'Las Vegas', 'AFC', 'West', '#000000', '#A5ACAF'),
+('Chargers', 'LAC', 'Los Angeles', 'AFC', 'West', '#0080C6', '#FFC20E'),
+('Rams', 'LAR', 'Los Angeles', 'NFC', 'West', '#003594', '#FFA300'),
+('Dolphins', 'MIA', 'Miami', 'AFC', 'East', '#008E97', '#FC4C02'),
+('Vikings', 'MIN', 'Minnesota', 'NFC', 'North', '#4F2683', '#FFC62F'),
+('Patriots', 'NE', 'New England', 'AFC', 'East', '#002244', '#C60C30'),
+('Saints', 'NO', 'New Orleans', 'NFC', 'South', '#D3BC8D', '#101820'),
+('Giants', 'NYG', 'New York', 'NFC', 'East', '#0B2265', '#A71930'),
+('Jets', 'NYJ', 'New York', 'AFC', 'East', '#125740', '#000000'),
+('Eagles', 'PHI', 'Philadelphia', 'NFC', 'East', '#004C54', '#A5ACAF'),
+('Steelers', 'PIT', 'Pittsburgh', 'AFC', 'North', '#FFB612', '#101820'),
+('49ers', 'SF', 'San Francisco', 'NFC', 'West', '#AA0000', '#B3995D'),
+('Seahawks', 'SEA', 'Seattle', 'NFC', 'West', '#002244', '#69BE28'),
+('Buccaneers', 'TB', 'Tampa Bay', 'NFC', 'South', '#D50A0A', '#FF7900'),
+('Titans', 'TEN', 'Tennessee', 'AFC', 'South', '#0C2340', '#4B92DB'),
+('Commanders', 'WAS', 'Washington', 'NFC', 'East', '#5A1414', '#FFB612')
+ON CONFLICT (team_abbreviation) DO NOTHING;
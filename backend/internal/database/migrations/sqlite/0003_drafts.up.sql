@@ -0,0 +1,38 @@
+-- Snake drafts that assign each member's season teams in turn
+CREATE TABLE drafts (
+	draft_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	pool_id INTEGER NOT NULL UNIQUE REFERENCES pools(pool_id) ON DELETE CASCADE,
+	status TEXT NOT NULL DEFAULT 'scheduled', -- scheduled, in_progress, completed
+	rounds INTEGER NOT NULL DEFAULT 4,
+	pick_seconds INTEGER NOT NULL,
+	scheduled_at DATETIME NOT NULL,
+	started_at DATETIME,
+	completed_at DATETIME,
+	current_pick INTEGER NOT NULL DEFAULT 0, -- overall number of the pick on the clock
+	pick_deadline DATETIME,
+	created_by INTEGER REFERENCES user_profiles(user_id) ON DELETE SET NULL,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE draft_order (
+	draft_id INTEGER NOT NULL REFERENCES drafts(draft_id) ON DELETE CASCADE,
+	slot INTEGER NOT NULL,
+	user_id INTEGER NOT NULL REFERENCES user_profiles(user_id) ON DELETE CASCADE,
+	PRIMARY KEY (draft_id, slot),
+	UNIQUE (draft_id, user_id)
+);
+
+CREATE TABLE draft_picks (
+	draft_pick_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	draft_id INTEGER NOT NULL REFERENCES drafts(draft_id) ON DELETE CASCADE,
+	overall_pick INTEGER NOT NULL,
+	round INTEGER NOT NULL,
+	user_id INTEGER NOT NULL REFERENCES user_profiles(user_id) ON DELETE CASCADE,
+	team_id INTEGER NOT NULL REFERENCES nfl_teams(team_id),
+	picked_at DATETIME NOT NULL,
+	UNIQUE (draft_id, overall_pick),
+	UNIQUE (draft_id, team_id)
+);
+
+CREATE INDEX idx_drafts_status ON drafts(status);
@@ -0,0 +1,3 @@
+ALTER TABLE draft_picks DROP COLUMN is_auto;
+DROP TABLE IF EXISTS draft_absences;
+DROP TABLE IF EXISTS draft_queues;
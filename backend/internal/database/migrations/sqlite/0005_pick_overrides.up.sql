@@ -0,0 +1,15 @@
+-- Commissioner changes to season picks made after the pool's lock deadline
+CREATE TABLE pick_overrides (
+	override_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	pool_id INTEGER NOT NULL REFERENCES pools(pool_id) ON DELETE CASCADE,
+	pick_id INTEGER, -- not a foreign key so deleted picks keep their history
+	user_id INTEGER REFERENCES user_profiles(user_id) ON DELETE SET NULL, -- owner of the pick
+	commissioner_id INTEGER REFERENCES user_profiles(user_id) ON DELETE SET NULL,
+	action TEXT NOT NULL, -- create, update, delete
+	team_id INTEGER REFERENCES nfl_teams(team_id),
+	pick_order INTEGER,
+	note TEXT NOT NULL,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_pick_overrides_pool ON pick_overrides(pool_id, created_at);
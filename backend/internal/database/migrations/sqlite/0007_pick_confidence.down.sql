@@ -0,0 +1 @@
+ALTER TABLE weekly_picks DROP COLUMN confidence;
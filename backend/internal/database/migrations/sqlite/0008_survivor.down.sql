@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS survivor_eliminations;
+DROP TABLE IF EXISTS survivor_picks;
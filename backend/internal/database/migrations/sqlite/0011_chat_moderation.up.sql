@@ -0,0 +1,28 @@
+-- Commissioner and moderator actions in pool chat. chat_moderation_actions
+-- is the audit log; chat_mutes holds the mutes and timeouts in force.
+CREATE TABLE chat_moderation_actions (
+	action_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	pool_id INTEGER NOT NULL REFERENCES pools(pool_id) ON DELETE CASCADE,
+	moderator_id INTEGER REFERENCES user_profiles(user_id) ON DELETE SET NULL,
+	action TEXT NOT NULL,
+	target_user_id INTEGER REFERENCES user_profiles(user_id) ON DELETE SET NULL,
+	message_ids TEXT,
+	reason TEXT,
+	expires_at DATETIME,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_chat_moderation_actions_pool ON chat_moderation_actions(pool_id, created_at);
+
+CREATE TABLE chat_mutes (
+	pool_id INTEGER NOT NULL REFERENCES pools(pool_id) ON DELETE CASCADE,
+	user_id INTEGER NOT NULL REFERENCES user_profiles(user_id) ON DELETE CASCADE,
+	kind TEXT NOT NULL,
+	muted_by INTEGER REFERENCES user_profiles(user_id) ON DELETE SET NULL,
+	reason TEXT,
+	expires_at DATETIME,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (pool_id, user_id)
+);
+
+CREATE INDEX idx_chat_messages_pool_user ON chat_messages(pool_id, user_id, created_at);
@@ -0,0 +1,168 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// VerifySchema fails when the two dialects' migrations disagree or when the
+// live schema no longer matches the SQLite reference schema.
+//
+// The reference is built by applying the embedded SQLite migrations to an
+// in-memory database at the same version as the live database, so running
+// against PostgreSQL catches any column drift between the two script sets.
+func VerifySchema(ctx context.Context, db *DB) error {
+	if err := checkMigrationParity(); err != nil {
+		return err
+	}
+
+	version, err := (&Migrator{db: db}).Version(ctx)
+	if err != nil {
+		return err
+	}
+
+	reference, err := referenceSchema(ctx, version)
+	if err != nil {
+		return fmt.Errorf("failed to build reference schema: %w", err)
+	}
+
+	live, err := tableColumns(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to inspect database schema: %w", err)
+	}
+
+	var problems []string
+	for _, table := range sortedKeys(reference) {
+		liveColumns, ok := live[table]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("table %s is missing", table))
+			continue
+		}
+
+		if missing := difference(reference[table], liveColumns); len(missing) > 0 {
+			problems = append(problems, fmt.Sprintf("%s is missing columns %s", table, strings.Join(missing, ", ")))
+		}
+		if extra := difference(liveColumns, reference[table]); len(extra) > 0 {
+			problems = append(problems, fmt.Sprintf("%s has unexpected columns %s", table, strings.Join(extra, ", ")))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%s schema has drifted from the reference schema: %s",
+			db.Dialect().Name(), strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// checkMigrationParity ensures both dialects define the same migrations
+func checkMigrationParity() error {
+	postgres, err := loadMigrations(DriverPostgres)
+	if err != nil {
+		return err
+	}
+	sqlite, err := loadMigrations(DriverSQLite)
+	if err != nil {
+		return err
+	}
+
+	describe := func(migrations []Migration) []string {
+		names := make([]string, 0, len(migrations))
+		for _, migration := range migrations {
+			names = append(names, fmt.Sprintf("%04d_%s", migration.Version, migration.Name))
+		}
+		return names
+	}
+
+	pgNames, sqliteNames := describe(postgres), describe(sqlite)
+	if strings.Join(pgNames, ",") != strings.Join(sqliteNames, ",") {
+		return fmt.Errorf("postgres and sqlite migrations differ: postgres has [%s], sqlite has [%s]",
+			strings.Join(pgNames, ", "), strings.Join(sqliteNames, ", "))
+	}
+
+	return nil
+}
+
+// referenceSchema applies the SQLite migrations up to version in memory
+func referenceSchema(ctx context.Context, version int) (map[string][]string, error) {
+	conn, err := sql.Open(DriverSQLite, ":memory:")
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Each connection to :memory: is a separate database
+	conn.SetMaxOpenConns(1)
+
+	db := &DB{DB: conn, dialect: sqliteDialect{}}
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := migrator.migrateTo(ctx, version); err != nil {
+		return nil, err
+	}
+
+	return tableColumns(ctx, db)
+}
+
+// tableColumns returns the column names of every table in the database
+func tableColumns(ctx context.Context, db *DB) (map[string][]string, error) {
+	query := `
+		SELECT table_name, column_name
+		FROM information_schema.columns
+		WHERE table_schema = current_schema()`
+	if db.Dialect().Name() == DriverSQLite {
+		query = `
+			SELECT m.name, p.name
+			FROM sqlite_master m
+			JOIN pragma_table_info(m.name) p
+			WHERE m.type = 'table' AND m.name NOT LIKE 'sqlite_%'`
+	}
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := make(map[string][]string)
+	for rows.Next() {
+		var table, column string
+		if err := rows.Scan(&table, &column); err != nil {
+			return nil, err
+		}
+		columns[table] = append(columns[table], column)
+	}
+
+	return columns, rows.Err()
+}
+
+// difference returns the sorted values of a that are not in b
+func difference(a, b []string) []string {
+	seen := make(map[string]bool, len(b))
+	for _, v := range b {
+		seen[v] = true
+	}
+
+	var diff []string
+	for _, v := range a {
+		if !seen[v] {
+			diff = append(diff, v)
+		}
+	}
+	sort.Strings(diff)
+	return diff
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
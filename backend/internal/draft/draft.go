@@ -0,0 +1,602 @@
+// Package draft runs snake drafts that hand out each member's season teams.
+package draft
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
+	"touchdown-tally/pkg/logger"
+)
+
+const (
+	// MaxRounds matches the four season_picks slots each member fills
+	MaxRounds = 4
+
+	// DefaultPickSeconds is the pick clock used when the commissioner does not set one
+	DefaultPickSeconds = 90
+
+	// clockInterval is how often scheduled starts and expired clocks are checked
+	clockInterval = time.Second
+)
+
+// Events broadcast to the pool WebSocket
+const (
+	EventDraftStarted      = "draft_started"
+	EventDraftPick         = "draft_pick"
+	EventDraftClockExpired = "draft_clock_expired"
+	EventDraftCompleted    = "draft_completed"
+)
+
+var (
+	ErrDraftExists        = errors.New("pool already has a draft")
+	ErrDraftStarted       = errors.New("draft has already started")
+	ErrDraftNotRunning    = errors.New("draft is not in progress")
+	ErrPicksExist         = errors.New("pool already has season picks")
+	ErrNotEnoughMembers   = errors.New("a draft needs at least two members")
+	ErrTooManyMembers     = errors.New("not enough teams for every member to draft one")
+	ErrNotYourTurn        = errors.New("it is not your turn to pick")
+	ErrTeamTaken          = errors.New("team has already been drafted")
+	ErrUnknownTeam        = errors.New("team does not exist")
+	ErrScheduledInThePast = errors.New("draft must be scheduled in the future")
+	ErrDraftCompleted     = errors.New("draft has already finished")
+	ErrDuplicateTeam      = errors.New("queue lists a team more than once")
+	ErrNoTeamsLeft        = errors.New("no undrafted teams are left")
+)
+
+// Reasons an automatic pick was made, recorded in the log
+const (
+	autoReasonClockExpired = "clock_expired"
+	autoReasonAbsent       = "absent"
+)
+
+// Broadcaster pushes draft events to everyone connected to a pool
+type Broadcaster interface {
+	Broadcast(poolID int, eventType string, data interface{})
+}
+
+// Service schedules, runs and finalizes drafts. All state lives in the
+// database, so a restarted server picks up running drafts where they were.
+type Service struct {
+	store       *store.Store
+	broadcaster Broadcaster
+	logger      *logger.Logger
+
+	// mu serializes draft mutations so a pick and a clock expiry for the
+	// same slot cannot interleave
+	mu  sync.Mutex
+	rng *rand.Rand
+	now func() time.Time
+}
+
+// NewService creates a draft service
+func NewService(st *store.Store, broadcaster Broadcaster, logger *logger.Logger) *Service {
+	return &Service{
+		store:       st,
+		broadcaster: broadcaster,
+		logger:      logger,
+		rng:         rand.New(rand.NewSource(time.Now().UnixNano())),
+		now:         func() time.Time { return time.Now().UTC().Truncate(time.Second) },
+	}
+}
+
+// Schedule creates the pool's draft, or moves it if it has not started yet
+func (s *Service) Schedule(ctx context.Context, poolID, commissionerID int, scheduledAt time.Time, pickSeconds int) (*models.Draft, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if pickSeconds == 0 {
+		pickSeconds = DefaultPickSeconds
+	}
+	if !scheduledAt.After(s.now()) {
+		return nil, ErrScheduledInThePast
+	}
+
+	if err := s.checkNoSeasonPicks(ctx, poolID); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.store.Drafts.GetByPool(ctx, poolID)
+	switch {
+	case err == nil:
+		if existing.Status != models.DraftStatusScheduled {
+			return nil, ErrDraftStarted
+		}
+		if err := s.store.Drafts.Reschedule(ctx, existing.DraftID, scheduledAt, pickSeconds); err != nil {
+			if errors.Is(err, store.ErrConflict) {
+				return nil, ErrDraftStarted
+			}
+			return nil, err
+		}
+	case errors.Is(err, store.ErrNotFound):
+		_, err := s.store.Drafts.Create(ctx, models.Draft{
+			PoolID:      poolID,
+			Rounds:      MaxRounds,
+			PickSeconds: pickSeconds,
+			ScheduledAt: scheduledAt,
+			CreatedBy:   commissionerID,
+		})
+		if errors.Is(err, store.ErrConflict) {
+			return nil, ErrDraftExists
+		}
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	return s.store.Drafts.GetByPool(ctx, poolID)
+}
+
+// Start begins the pool's draft immediately instead of waiting for its
+// scheduled time
+func (s *Service) Start(ctx context.Context, poolID int) (*models.DraftBoard, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	draft, err := s.store.Drafts.GetByPool(ctx, poolID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.start(ctx, draft); err != nil {
+		return nil, err
+	}
+
+	return s.board(ctx, poolID)
+}
+
+// start randomizes the order over the pool's current members and puts the
+// first pick on the clock. Callers must hold s.mu.
+func (s *Service) start(ctx context.Context, draft *models.Draft) error {
+	if draft.Status != models.DraftStatusScheduled {
+		return ErrDraftStarted
+	}
+
+	if err := s.checkNoSeasonPicks(ctx, draft.PoolID); err != nil {
+		return err
+	}
+
+	members, err := s.store.Pools.ListMembers(ctx, draft.PoolID)
+	if err != nil {
+		return err
+	}
+	if len(members) < 2 {
+		return ErrNotEnoughMembers
+	}
+
+	teams, err := s.store.Teams.List(ctx, store.TeamFilter{})
+	if err != nil {
+		return err
+	}
+
+	// Large pools draft fewer rounds so every member gets the same number of teams
+	rounds := len(teams) / len(members)
+	if rounds > MaxRounds {
+		rounds = MaxRounds
+	}
+	if rounds == 0 {
+		return ErrTooManyMembers
+	}
+
+	order := make([]int, len(members))
+	for i, member := range members {
+		order[i] = member.UserID
+	}
+	s.rng.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+
+	now := s.now()
+	deadline := now.Add(time.Duration(draft.PickSeconds) * time.Second)
+	if err := s.store.Drafts.Start(ctx, draft.DraftID, order, rounds, now, deadline); err != nil {
+		if errors.Is(err, store.ErrConflict) {
+			return ErrDraftStarted
+		}
+		return err
+	}
+
+	s.logger.Info("Draft started", "pool_id", draft.PoolID, "draft_id", draft.DraftID, "members", len(order), "rounds", rounds)
+
+	board, err := s.board(ctx, draft.PoolID)
+	if err != nil {
+		return err
+	}
+	s.broadcaster.Broadcast(draft.PoolID, EventDraftStarted, board)
+
+	s.pickForAbsent(ctx, draft.PoolID)
+	return nil
+}
+
+// Pick records the member's selection if they are on the clock
+func (s *Service) Pick(ctx context.Context, poolID, userID, teamID int) (*models.DraftPick, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	draft, err := s.store.Drafts.GetByPool(ctx, poolID)
+	if err != nil {
+		return nil, err
+	}
+	if draft.Status != models.DraftStatusInProgress {
+		return nil, ErrDraftNotRunning
+	}
+
+	order, err := s.store.Drafts.Order(ctx, draft.DraftID)
+	if err != nil {
+		return nil, err
+	}
+
+	round, slot := SnakeSlot(draft.CurrentPick, len(order))
+	if order[slot].UserID != userID {
+		return nil, ErrNotYourTurn
+	}
+
+	team, err := s.store.Teams.Get(ctx, teamID)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, ErrUnknownTeam
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pick, err := s.record(ctx, draft, len(order), models.DraftPick{
+		OverallPick:      draft.CurrentPick,
+		Round:            round,
+		UserID:           userID,
+		TeamID:           team.TeamID,
+		TeamAbbreviation: team.TeamAbbreviation,
+		TeamName:         team.TeamName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.pickForAbsent(ctx, poolID)
+	return pick, nil
+}
+
+// Queue returns the member's autopick queue for the pool, best team first
+func (s *Service) Queue(ctx context.Context, poolID, userID int) ([]models.DraftQueueEntry, error) {
+	return s.store.Drafts.Queue(ctx, poolID, userID)
+}
+
+// SetQueue replaces the member's autopick queue. It can be changed at any
+// time until the draft finishes; teams drafted since are simply skipped.
+func (s *Service) SetQueue(ctx context.Context, poolID, userID int, teamIDs []int) ([]models.DraftQueueEntry, error) {
+	draft, err := s.store.Drafts.GetByPool(ctx, poolID)
+	if err != nil {
+		return nil, err
+	}
+	if draft.Status == models.DraftStatusCompleted {
+		return nil, ErrDraftCompleted
+	}
+
+	seen := make(map[int]bool, len(teamIDs))
+	for _, teamID := range teamIDs {
+		if seen[teamID] {
+			return nil, ErrDuplicateTeam
+		}
+		seen[teamID] = true
+
+		if _, err := s.store.Teams.Get(ctx, teamID); err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				return nil, ErrUnknownTeam
+			}
+			return nil, err
+		}
+	}
+
+	if err := s.store.Drafts.SetQueue(ctx, poolID, userID, teamIDs); err != nil {
+		if errors.Is(err, store.ErrConflict) {
+			return nil, ErrDuplicateTeam
+		}
+		return nil, err
+	}
+
+	return s.store.Drafts.Queue(ctx, poolID, userID)
+}
+
+// SetAbsent flags or unflags a member as absent. Absent members are picked
+// for as soon as they come on the clock, including right now.
+func (s *Service) SetAbsent(ctx context.Context, poolID, userID, flaggedBy int, absent bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	draft, err := s.store.Drafts.GetByPool(ctx, poolID)
+	if err != nil {
+		return err
+	}
+	if draft.Status == models.DraftStatusCompleted {
+		return ErrDraftCompleted
+	}
+
+	if err := s.store.Drafts.SetAbsent(ctx, poolID, userID, flaggedBy, absent); err != nil {
+		return err
+	}
+
+	s.logger.Info("Draft absence updated", "pool_id", poolID, "user_id", userID, "absent", absent, "flagged_by", flaggedBy)
+
+	if absent {
+		s.pickForAbsent(ctx, poolID)
+	}
+	return nil
+}
+
+// record stores a pick, advances the clock and broadcasts the result.
+// Callers must hold s.mu.
+func (s *Service) record(ctx context.Context, draft *models.Draft, members int, pick models.DraftPick) (*models.DraftPick, error) {
+	now := s.now()
+	pick.PickedAt = now
+
+	var deadline *time.Time
+	last := pick.OverallPick >= draft.Rounds*members
+	if !last {
+		next := now.Add(time.Duration(draft.PickSeconds) * time.Second)
+		deadline = &next
+	}
+
+	if err := s.store.Drafts.RecordPick(ctx, draft.DraftID, pick, deadline); err != nil {
+		if errors.Is(err, store.ErrConflict) {
+			return nil, ErrTeamTaken
+		}
+		return nil, err
+	}
+
+	s.logger.Info("Draft pick made", "pool_id", draft.PoolID, "overall_pick", pick.OverallPick, "user_id", pick.UserID, "team_id", pick.TeamID, "auto", pick.IsAuto)
+
+	board, err := s.board(ctx, draft.PoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.broadcaster.Broadcast(draft.PoolID, EventDraftPick, map[string]interface{}{"pick": pick, "board": board})
+	if last {
+		s.logger.Info("Draft completed", "pool_id", draft.PoolID, "draft_id", draft.DraftID)
+		s.broadcaster.Broadcast(draft.PoolID, EventDraftCompleted, board)
+	}
+
+	return &pick, nil
+}
+
+// Board returns the pool's draft with its order, picks and who is on the clock
+func (s *Service) Board(ctx context.Context, poolID int) (*models.DraftBoard, error) {
+	return s.board(ctx, poolID)
+}
+
+func (s *Service) board(ctx context.Context, poolID int) (*models.DraftBoard, error) {
+	draft, err := s.store.Drafts.GetByPool(ctx, poolID)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := s.store.Drafts.Order(ctx, draft.DraftID)
+	if err != nil {
+		return nil, err
+	}
+
+	picks, err := s.store.Drafts.Picks(ctx, draft.DraftID)
+	if err != nil {
+		return nil, err
+	}
+
+	board := &models.DraftBoard{
+		Draft: *draft,
+		Order: order,
+		Picks: picks,
+	}
+	if draft.Status == models.DraftStatusInProgress && len(order) > 0 {
+		_, slot := SnakeSlot(draft.CurrentPick, len(order))
+		board.OnTheClock = &order[slot].UserID
+	}
+	return board, nil
+}
+
+// Run starts scheduled drafts and enforces pick clocks until ctx is cancelled
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(clockInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.tick(ctx)
+		}
+	}
+}
+
+func (s *Service) tick(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+
+	due, err := s.store.Drafts.ListDueToStart(ctx, now)
+	if err != nil {
+		s.logger.Error("Failed to list drafts due to start", "error", err)
+		return
+	}
+	for i := range due {
+		if err := s.start(ctx, &due[i]); err != nil {
+			s.logger.Warn("Scheduled draft could not start", "pool_id", due[i].PoolID, "error", err)
+		}
+	}
+
+	expired, err := s.store.Drafts.ListExpired(ctx, now)
+	if err != nil {
+		s.logger.Error("Failed to list expired draft clocks", "error", err)
+		return
+	}
+	for i := range expired {
+		s.expire(ctx, &expired[i])
+	}
+}
+
+// expire picks for a member whose clock ran out. Callers must hold s.mu.
+func (s *Service) expire(ctx context.Context, draft *models.Draft) {
+	order, err := s.store.Drafts.Order(ctx, draft.DraftID)
+	if err != nil || len(order) == 0 {
+		s.logger.Error("Failed to load draft order", "pool_id", draft.PoolID, "error", err)
+		return
+	}
+
+	_, slot := SnakeSlot(draft.CurrentPick, len(order))
+	s.logger.Info("Draft clock expired", "pool_id", draft.PoolID, "overall_pick", draft.CurrentPick, "user_id", order[slot].UserID)
+	s.broadcaster.Broadcast(draft.PoolID, EventDraftClockExpired, map[string]int{
+		"overall_pick": draft.CurrentPick,
+		"user_id":      order[slot].UserID,
+	})
+
+	if _, err := s.autopick(ctx, draft, order, autoReasonClockExpired); err != nil {
+		s.logger.Error("Failed to autopick", "pool_id", draft.PoolID, "overall_pick", draft.CurrentPick, "error", err)
+		return
+	}
+
+	s.pickForAbsent(ctx, draft.PoolID)
+}
+
+// pickForAbsent keeps picking while the member on the clock is flagged as
+// absent. Errors are logged rather than returned because the caller's own
+// action has already succeeded. Callers must hold s.mu.
+func (s *Service) pickForAbsent(ctx context.Context, poolID int) {
+	for {
+		draft, err := s.store.Drafts.GetByPool(ctx, poolID)
+		if err != nil {
+			s.logger.Error("Failed to load draft", "pool_id", poolID, "error", err)
+			return
+		}
+		if draft.Status != models.DraftStatusInProgress {
+			return
+		}
+
+		order, err := s.store.Drafts.Order(ctx, draft.DraftID)
+		if err != nil || len(order) == 0 {
+			s.logger.Error("Failed to load draft order", "pool_id", poolID, "error", err)
+			return
+		}
+
+		_, slot := SnakeSlot(draft.CurrentPick, len(order))
+		if !order[slot].Absent {
+			return
+		}
+
+		if _, err := s.autopick(ctx, draft, order, autoReasonAbsent); err != nil {
+			s.logger.Error("Failed to autopick", "pool_id", poolID, "overall_pick", draft.CurrentPick, "error", err)
+			return
+		}
+	}
+}
+
+// autopick selects for the member on the clock: the best available team in
+// their queue, then in the pool's default ranking, then in team listing
+// order. Callers must hold s.mu.
+func (s *Service) autopick(ctx context.Context, draft *models.Draft, order []models.DraftSlot, reason string) (*models.DraftPick, error) {
+	round, slot := SnakeSlot(draft.CurrentPick, len(order))
+	userID := order[slot].UserID
+
+	picks, err := s.store.Drafts.Picks(ctx, draft.DraftID)
+	if err != nil {
+		return nil, err
+	}
+	taken := make(map[int]bool, len(picks))
+	for _, pick := range picks {
+		taken[pick.TeamID] = true
+	}
+
+	queue, err := s.store.Drafts.Queue(ctx, draft.PoolID, userID)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := s.store.Pools.Get(ctx, draft.PoolID)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := s.store.Teams.List(ctx, store.TeamFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	queued := make([]int, len(queue))
+	for i, entry := range queue {
+		queued[i] = entry.TeamID
+	}
+	listed := make([]int, len(teams))
+	byID := make(map[int]models.NFLTeam, len(teams))
+	for i, team := range teams {
+		listed[i] = team.TeamID
+		byID[team.TeamID] = team
+	}
+
+	var team models.NFLTeam
+	var source string
+	for _, ranking := range []struct {
+		source  string
+		teamIDs []int
+	}{
+		{"queue", queued},
+		{"default_ranking", pool.Settings.Draft.DefaultRanking},
+		{"team_list", listed},
+	} {
+		if teamID, ok := firstAvailable(ranking.teamIDs, byID, taken); ok {
+			team, source = byID[teamID], ranking.source
+			break
+		}
+	}
+	if source == "" {
+		return nil, ErrNoTeamsLeft
+	}
+
+	pick, err := s.record(ctx, draft, len(order), models.DraftPick{
+		OverallPick:      draft.CurrentPick,
+		Round:            round,
+		UserID:           userID,
+		TeamID:           team.TeamID,
+		TeamAbbreviation: team.TeamAbbreviation,
+		TeamName:         team.TeamName,
+		IsAuto:           true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Draft pick made automatically", "pool_id", draft.PoolID, "overall_pick", pick.OverallPick,
+		"user_id", userID, "team_id", team.TeamID, "reason", reason, "source", source)
+	return pick, nil
+}
+
+// firstAvailable returns the first team in ranking that exists and has not
+// been drafted
+func firstAvailable(ranking []int, teams map[int]models.NFLTeam, taken map[int]bool) (int, bool) {
+	for _, teamID := range ranking {
+		if _, exists := teams[teamID]; exists && !taken[teamID] {
+			return teamID, true
+		}
+	}
+	return 0, false
+}
+
+func (s *Service) checkNoSeasonPicks(ctx context.Context, poolID int) error {
+	picks, err := s.store.Picks.ListByPool(ctx, poolID)
+	if err != nil {
+		return err
+	}
+	if len(picks) > 0 {
+		return ErrPicksExist
+	}
+	return nil
+}
+
+// SnakeSlot maps a 1-based overall pick number to its 1-based round and the
+// 0-based index into the first-round order. Even rounds run in reverse.
+func SnakeSlot(overallPick, members int) (round, slot int) {
+	index := overallPick - 1
+	round = index/members + 1
+	slot = index % members
+	if round%2 == 0 {
+		slot = members - 1 - slot
+	}
+	return round, slot
+}
@@ -0,0 +1,263 @@
+package draft
+
+import (
+	"context"
+	"io"
+	"log"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"touchdown-tally/internal/database"
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
+	"touchdown-tally/pkg/logger"
+)
+
+func TestSnakeSlot(t *testing.T) {
+	tests := []struct {
+		name        string
+		overallPick int
+		members     int
+		wantRound   int
+		wantSlot    int
+	}{
+		{name: "first pick", overallPick: 1, members: 4, wantRound: 1, wantSlot: 0},
+		{name: "end of round one", overallPick: 4, members: 4, wantRound: 1, wantSlot: 3},
+		{name: "turn keeps the last picker on the clock", overallPick: 5, members: 4, wantRound: 2, wantSlot: 3},
+		{name: "end of round two", overallPick: 8, members: 4, wantRound: 2, wantSlot: 0},
+		{name: "round three runs forward again", overallPick: 9, members: 4, wantRound: 3, wantSlot: 0},
+		{name: "middle of round four", overallPick: 14, members: 4, wantRound: 4, wantSlot: 2},
+		{name: "two members", overallPick: 3, members: 2, wantRound: 2, wantSlot: 1},
+		{name: "odd pool size", overallPick: 7, members: 3, wantRound: 3, wantSlot: 0},
+		{name: "single member", overallPick: 3, members: 1, wantRound: 3, wantSlot: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			round, slot := SnakeSlot(tt.overallPick, tt.members)
+			if round != tt.wantRound || slot != tt.wantSlot {
+				t.Errorf("SnakeSlot(%d, %d) = round %d slot %d, want round %d slot %d",
+					tt.overallPick, tt.members, round, slot, tt.wantRound, tt.wantSlot)
+			}
+		})
+	}
+}
+
+// TestSnakeOrder walks whole drafts and checks every member picks once a
+// round, in first-round order on odd rounds and reversed on even ones
+func TestSnakeOrder(t *testing.T) {
+	tests := []struct {
+		members int
+		rounds  int
+		want    []int
+	}{
+		{members: 2, rounds: 4, want: []int{0, 1, 1, 0, 0, 1, 1, 0}},
+		{members: 3, rounds: 3, want: []int{0, 1, 2, 2, 1, 0, 0, 1, 2}},
+		{members: 4, rounds: 2, want: []int{0, 1, 2, 3, 3, 2, 1, 0}},
+	}
+
+	for _, tt := range tests {
+		var got []int
+		for pick := 1; pick <= tt.members*tt.rounds; pick++ {
+			round, slot := SnakeSlot(pick, tt.members)
+			if want := (pick-1)/tt.members + 1; round != want {
+				t.Errorf("%d members: pick %d in round %d, want %d", tt.members, pick, round, want)
+			}
+			got = append(got, slot)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%d members over %d rounds picked in order %v, want %v", tt.members, tt.rounds, got, tt.want)
+		}
+	}
+}
+
+func TestFirstAvailable(t *testing.T) {
+	teams := map[int]models.NFLTeam{1: {TeamID: 1}, 2: {TeamID: 2}, 3: {TeamID: 3}}
+
+	tests := []struct {
+		name    string
+		ranking []int
+		taken   map[int]bool
+		want    int
+		wantOK  bool
+	}{
+		{name: "best ranked team", ranking: []int{2, 1}, want: 2, wantOK: true},
+		{name: "drafted teams are skipped", ranking: []int{2, 1, 3}, taken: map[int]bool{2: true}, want: 1, wantOK: true},
+		{name: "unknown teams are skipped", ranking: []int{99, 3}, want: 3, wantOK: true},
+		{name: "everything ranked is gone", ranking: []int{1, 2}, taken: map[int]bool{1: true, 2: true}},
+		{name: "empty ranking", ranking: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := firstAvailable(tt.ranking, teams, tt.taken)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("firstAvailable = %d, %t; want %d, %t", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+// discard drops draft events
+type discard struct{}
+
+func (discard) Broadcast(poolID int, eventType string, data interface{}) {}
+
+// startDraft starts a draft between two members over a migrated SQLite
+// database and returns the service, the draft's board and the teams by
+// abbreviation
+func startDraft(t *testing.T, ranking []string) (*Service, *models.DraftBoard, map[string]int) {
+	t.Helper()
+	ctx := context.Background()
+
+	db, err := database.Connect("sqlite://" + filepath.Join(t.TempDir(), "draft.db"))
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := database.Migrate(db); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	st := store.New(db)
+
+	teams, err := st.Teams.List(ctx, store.TeamFilter{})
+	if err != nil {
+		t.Fatalf("list teams: %v", err)
+	}
+	ids := make(map[string]int, len(teams))
+	for _, team := range teams {
+		ids[team.TeamAbbreviation] = team.TeamID
+	}
+
+	var users []int
+	for _, name := range []string{"commish", "member"} {
+		profile, err := st.Users.Register(ctx, name+"@example.com", "hash", name, name)
+		if err != nil {
+			t.Fatalf("register %s: %v", name, err)
+		}
+		users = append(users, profile.UserID)
+	}
+	settings := models.DefaultPoolSettings()
+	for _, abbr := range ranking {
+		settings.Draft.DefaultRanking = append(settings.Draft.DefaultRanking, ids[abbr])
+	}
+	poolID, err := st.Pools.Create(ctx, store.NewPool{
+		Name:           "Draft Night",
+		CommissionerID: users[0],
+		SeasonYear:     2025,
+		MaxMembers:     10,
+		PoolType:       models.PoolTypeSeason,
+		Settings:       settings,
+	})
+	if err != nil {
+		t.Fatalf("create pool: %v", err)
+	}
+	if err := st.Pools.AddMember(ctx, poolID, users[1], models.RoleMember); err != nil {
+		t.Fatalf("add member: %v", err)
+	}
+
+	s := NewService(st, discard{}, &logger.Logger{Logger: log.New(io.Discard, "", 0)})
+	if _, err := s.Schedule(ctx, poolID, users[0], time.Now().Add(time.Hour), 0); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	board, err := s.Start(ctx, poolID)
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	return s, board, ids
+}
+
+func TestAutopick(t *testing.T) {
+	tests := []struct {
+		name    string
+		queue   []string
+		ranking []string
+		// want is the team picked, or the first team listed when empty
+		want string
+	}{
+		{name: "best queued team", queue: []string{"KC", "DEN"}, ranking: []string{"MIA"}, want: "KC"},
+		{name: "queued team already drafted", queue: []string{"BUF", "DEN"}, want: "DEN"},
+		{name: "exhausted queue falls back to the default ranking", queue: []string{"BUF"}, ranking: []string{"BUF", "MIA", "KC"}, want: "MIA"},
+		{name: "no queue or ranking takes the first team listed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s, board, ids := startDraft(t, tt.ranking)
+			poolID := board.Draft.PoolID
+			first, second := board.Order[0].UserID, board.Order[1].UserID
+
+			// The first member takes BUF before the second's clock runs out
+			if _, err := s.Pick(ctx, poolID, first, ids["BUF"]); err != nil {
+				t.Fatalf("first pick: %v", err)
+			}
+			var queue []int
+			for _, abbr := range tt.queue {
+				queue = append(queue, ids[abbr])
+			}
+			if err := s.store.Drafts.SetQueue(ctx, poolID, second, queue); err != nil {
+				t.Fatalf("set queue: %v", err)
+			}
+
+			draft, err := s.store.Drafts.GetByPool(ctx, poolID)
+			if err != nil {
+				t.Fatalf("get draft: %v", err)
+			}
+			s.mu.Lock()
+			pick, err := s.autopick(ctx, draft, board.Order, autoReasonClockExpired)
+			s.mu.Unlock()
+			if err != nil {
+				t.Fatalf("autopick: %v", err)
+			}
+
+			want := tt.want
+			if want == "" {
+				teams, err := s.store.Teams.List(ctx, store.TeamFilter{})
+				if err != nil {
+					t.Fatalf("list teams: %v", err)
+				}
+				for _, team := range teams {
+					if team.TeamAbbreviation != "BUF" {
+						want = team.TeamAbbreviation
+						break
+					}
+				}
+			}
+			if pick.UserID != second || pick.TeamAbbreviation != want || !pick.IsAuto || pick.OverallPick != 2 {
+				t.Errorf("autopick = %+v, want %s for user %d at pick 2", pick, want, second)
+			}
+		})
+	}
+}
+
+func TestAbsentMembersArePickedFor(t *testing.T) {
+	ctx := context.Background()
+	s, board, ids := startDraft(t, []string{"KC", "DEN"})
+	poolID := board.Draft.PoolID
+	first, second := board.Order[0].UserID, board.Order[1].UserID
+
+	if err := s.SetAbsent(ctx, poolID, second, first, true); err != nil {
+		t.Fatalf("set absent: %v", err)
+	}
+	if _, err := s.Pick(ctx, poolID, first, ids["KC"]); err != nil {
+		t.Fatalf("first pick: %v", err)
+	}
+
+	// Round two opens with the absent member, who is picked for twice in a row
+	board, err := s.Board(ctx, poolID)
+	if err != nil {
+		t.Fatalf("board: %v", err)
+	}
+	var got []string
+	for _, pick := range board.Picks {
+		if pick.UserID == second && pick.IsAuto {
+			got = append(got, pick.TeamAbbreviation)
+		}
+	}
+	if len(got) != 2 || got[0] != "DEN" || board.OnTheClock == nil || *board.OnTheClock != first {
+		t.Errorf("absent member drafted %v; on the clock %v", got, board.OnTheClock)
+	}
+}
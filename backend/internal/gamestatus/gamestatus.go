@@ -0,0 +1,208 @@
+// Package gamestatus delivers game status changes and score updates to the
+// parts of the server that react to them.
+package gamestatus
+
+import (
+	"context"
+	"sync"
+
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/standings"
+	"touchdown-tally/internal/store"
+	"touchdown-tally/pkg/logger"
+)
+
+// Pool event types pushed to connected clients
+const (
+	// EventGameStatus announces a status change
+	EventGameStatus = "game_status"
+	// EventGameUpdate carries a game's live score, clock and status
+	EventGameUpdate = "game_update"
+	// EventStandingsUpdate carries a pool's standings while its teams play
+	EventStandingsUpdate = "standings_update"
+)
+
+// Subscriber reacts to one status change. Subscribers run in the order they
+// subscribed and should log rather than return their own failures.
+type Subscriber func(ctx context.Context, transition models.GameTransition)
+
+// UpdateSubscriber reacts to games being added or updated in nfl_games
+type UpdateSubscriber func(ctx context.Context, gameIDs []int)
+
+// Bus fans status changes and game updates out to subscribers
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers []Subscriber
+	updates     []UpdateSubscriber
+}
+
+// NewBus creates a bus with no subscribers
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe adds a subscriber for every later status change
+func (b *Bus) Subscribe(subscriber Subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers = append(b.subscribers, subscriber)
+}
+
+// Publish delivers each transition, in order, to every subscriber
+func (b *Bus) Publish(ctx context.Context, transitions []models.GameTransition) {
+	b.mu.RLock()
+	subscribers := append([]Subscriber(nil), b.subscribers...)
+	b.mu.RUnlock()
+
+	for _, transition := range transitions {
+		for _, subscriber := range subscribers {
+			subscriber(ctx, transition)
+		}
+	}
+}
+
+// SubscribeUpdates adds a subscriber for every later batch of game updates
+func (b *Bus) SubscribeUpdates(subscriber UpdateSubscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.updates = append(b.updates, subscriber)
+}
+
+// PublishUpdates delivers the IDs of games just added or updated to every
+// update subscriber. Empty batches are dropped.
+func (b *Bus) PublishUpdates(ctx context.Context, gameIDs []int) {
+	if len(gameIDs) == 0 {
+		return
+	}
+
+	b.mu.RLock()
+	subscribers := append([]UpdateSubscriber(nil), b.updates...)
+	b.mu.RUnlock()
+
+	for _, subscriber := range subscribers {
+		subscriber(ctx, gameIDs)
+	}
+}
+
+// Broadcaster pushes events to everyone connected to a pool
+type Broadcaster interface {
+	Broadcast(poolID int, eventType string, data interface{})
+}
+
+// Announce returns a subscriber that tells every active pool of the game's
+// season about the change
+func Announce(st *store.Store, broadcaster Broadcaster, log *logger.Logger) Subscriber {
+	return func(ctx context.Context, transition models.GameTransition) {
+		pools, err := st.Pools.ListActive(ctx, "")
+		if err != nil {
+			log.Error("Failed to list pools for game announcement", "game_id", transition.GameID, "error", err)
+			return
+		}
+
+		for _, pool := range pools {
+			if pool.Season == transition.SeasonYear {
+				broadcaster.Broadcast(pool.ID, EventGameStatus, transition)
+			}
+		}
+	}
+}
+
+// RecomputeStandings returns a subscriber that rescores every active pool of
+// the game's season when a game becomes final or its result is corrected
+func RecomputeStandings(st *store.Store, engine *standings.Engine, log *logger.Logger) Subscriber {
+	return func(ctx context.Context, transition models.GameTransition) {
+		if !models.IsFinalStatus(transition.To) {
+			return
+		}
+
+		pools, err := st.Pools.ListActive(ctx, "")
+		if err != nil {
+			log.Error("Failed to list pools for standings", "game_id", transition.GameID, "error", err)
+			return
+		}
+
+		for _, pool := range pools {
+			if pool.Season != transition.SeasonYear {
+				continue
+			}
+			if _, err := engine.Season(ctx, pool.ID); err != nil {
+				log.Error("Failed to recompute standings", "pool_id", pool.ID, "game_id", transition.GameID, "error", err)
+			}
+		}
+	}
+}
+
+// PushGameUpdates returns an update subscriber that sends each changed
+// game's live state to every active pool of its season, and the season
+// standings to every pool in which a member owns a team in a game that has
+// kicked off
+func PushGameUpdates(st *store.Store, engine *standings.Engine, broadcaster Broadcaster, log *logger.Logger) UpdateSubscriber {
+	return func(ctx context.Context, gameIDs []int) {
+		pools, err := st.Pools.ListActive(ctx, "")
+		if err != nil {
+			log.Error("Failed to list pools for game updates", "error", err)
+			return
+		}
+
+		var (
+			played  []models.GameUpdate
+			teamIDs []int
+		)
+		for _, gameID := range gameIDs {
+			game, err := st.Games.Get(ctx, gameID)
+			if err != nil {
+				log.Error("Failed to load updated game", "game_id", gameID, "error", err)
+				continue
+			}
+
+			update := models.NewGameUpdate(*game)
+			for _, pool := range pools {
+				if pool.Season == game.SeasonYear {
+					broadcaster.Broadcast(pool.ID, EventGameUpdate, update)
+				}
+			}
+
+			if !models.IsPendingStatus(game.Status) {
+				played = append(played, update)
+				teamIDs = append(teamIDs, game.HomeTeamID, game.AwayTeamID)
+			}
+		}
+
+		owning, err := st.Picks.PoolsOwning(ctx, teamIDs)
+		if err != nil {
+			log.Error("Failed to find pools owning updated teams", "error", err)
+			return
+		}
+		for _, poolID := range owning {
+			pushStandings(ctx, st, engine, broadcaster, log, poolID, played)
+		}
+	}
+}
+
+// pushStandings sends a pool its season standings with the games in play
+// that involve its members' teams
+func pushStandings(ctx context.Context, st *store.Store, engine *standings.Engine, broadcaster Broadcaster, log *logger.Logger, poolID int, played []models.GameUpdate) {
+	picks, err := st.Picks.ListByPool(ctx, poolID)
+	if err != nil {
+		log.Error("Failed to load pool picks for standings update", "pool_id", poolID, "error", err)
+		return
+	}
+	owned := make(map[int]bool, len(picks))
+	for _, pick := range picks {
+		owned[pick.TeamID] = true
+	}
+
+	update := models.StandingsUpdate{PoolID: poolID}
+	for _, game := range played {
+		if owned[game.HomeTeamID] || owned[game.AwayTeamID] {
+			update.Games = append(update.Games, game)
+		}
+	}
+
+	update.Standings, err = engine.Season(ctx, poolID)
+	if err != nil {
+		log.Error("Failed to calculate standings update", "pool_id", poolID, "error", err)
+		return
+	}
+	broadcaster.Broadcast(poolID, EventStandingsUpdate, update)
+}
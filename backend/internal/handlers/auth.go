@@ -1,29 +1,30 @@
 package handlers
 
 import (
-	"database/sql"
+	"errors"
+	"strings"
 
 	"touchdown-tally/internal/auth"
 	"touchdown-tally/internal/config"
 	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
 	"touchdown-tally/pkg/logger"
 	"touchdown-tally/pkg/response"
 
 	"github.com/gin-gonic/gin"
-	"github.com/lib/pq"
 )
 
 // AuthHandler handles authentication-related requests
 type AuthHandler struct {
-	db     *sql.DB
+	store  *store.Store
 	config *config.Config
 	logger *logger.Logger
 }
 
 // NewAuthHandler creates a new AuthHandler
-func NewAuthHandler(db *sql.DB, cfg *config.Config, logger *logger.Logger) *AuthHandler {
+func NewAuthHandler(st *store.Store, cfg *config.Config, logger *logger.Logger) *AuthHandler {
 	return &AuthHandler{
-		db:     db,
+		store:  st,
 		config: cfg,
 		logger: logger,
 	}
@@ -45,64 +46,22 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	tx, err := h.db.Begin()
+	user, err := h.store.Users.Register(c.Request.Context(), req.Email, hashedPassword, req.Username, req.DisplayName)
 	if err != nil {
-		h.logger.Error("Failed to begin transaction", "error", err)
-		response.InternalServerError(c, "transaction_failed", "Failed to start registration process")
-		return
-	}
-	defer tx.Rollback()
-
-	// Create email account
-	var emailID int
-	err = tx.QueryRow(
-		"INSERT INTO email_accounts (email_address, password_hash) VALUES ($1, $2) RETURNING email_id",
-		req.Email, hashedPassword,
-	).Scan(&emailID)
-
-	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" { // unique violation
+		switch {
+		case errors.Is(err, store.ErrEmailExists):
 			response.Conflict(c, "email_exists", "Email address already registered")
-			return
-		}
-		h.logger.Error("Failed to create email account", "error", err)
-		response.InternalServerError(c, "account_creation_failed", "Failed to create account")
-		return
-	}
-
-	// Create user profile
-	var userID int
-	err = tx.QueryRow(
-		"INSERT INTO user_profiles (email_id, username, display_name) VALUES ($1, $2, $3) RETURNING user_id",
-		emailID, req.Username, req.DisplayName,
-	).Scan(&userID)
-
-	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" { // unique violation
+		case errors.Is(err, store.ErrUsernameExists):
 			response.Conflict(c, "username_exists", "Username already exists for this email")
-			return
+		default:
+			h.logger.Error("Failed to register user", "error", err)
+			response.InternalServerError(c, "registration_failed", "Failed to complete registration")
 		}
-		h.logger.Error("Failed to create user profile", "error", err)
-		response.InternalServerError(c, "profile_creation_failed", "Failed to create user profile")
-		return
-	}
-
-	if err = tx.Commit(); err != nil {
-		h.logger.Error("Failed to commit transaction", "error", err)
-		response.InternalServerError(c, "registration_failed", "Failed to complete registration")
 		return
 	}
 
-	// Create user object for response
-	user := models.UserProfile{
-		UserID:      userID,
-		EmailID:     emailID,
-		Username:    req.Username,
-		DisplayName: req.DisplayName,
-	}
-
 	// Generate JWT token
-	token, err := auth.GenerateJWT(user, h.config.JWTSecret)
+	token, err := auth.GenerateJWT(*user, h.config.JWTSecret)
 	if err != nil {
 		h.logger.Error("Failed to generate JWT token", "error", err)
 		response.InternalServerError(c, "token_generation_failed", "Failed to generate authentication token")
@@ -110,15 +69,15 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	}
 
 	// Get all profiles for this email (for now just the one we created)
-	profiles := []models.UserProfile{user}
+	profiles := []models.UserProfile{*user}
 
 	loginResponse := models.LoginResponse{
 		Token:    token,
-		User:     user,
+		User:     *user,
 		Profiles: profiles,
 	}
 
-	h.logger.Info("User registered successfully", "user_id", userID, "email", req.Email)
+	h.logger.Info("User registered successfully", "user_id", user.UserID, "email", req.Email)
 	response.Created(c, loginResponse, "User registered successfully")
 }
 
@@ -131,14 +90,9 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}
 
 	// Get email account
-	var account models.EmailAccount
-	err := h.db.QueryRow(
-		"SELECT email_id, email_address, password_hash FROM email_accounts WHERE email_address = $1",
-		req.Email,
-	).Scan(&account.EmailID, &account.EmailAddress, &account.PasswordHash)
-
+	account, err := h.store.Users.GetAccountByEmail(c.Request.Context(), req.Email)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, store.ErrNotFound) {
 			response.Unauthorized(c, "invalid_credentials", "Invalid email or password")
 			return
 		}
@@ -154,28 +108,12 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}
 
 	// Get all profiles for this email
-	rows, err := h.db.Query(
-		"SELECT user_id, username, display_name, created_at FROM user_profiles WHERE email_id = $1 ORDER BY created_at",
-		account.EmailID,
-	)
+	profiles, err := h.store.Users.ListProfiles(c.Request.Context(), account.EmailID)
 	if err != nil {
 		h.logger.Error("Failed to query user profiles", "error", err)
 		response.InternalServerError(c, "login_failed", "Failed to load user profiles")
 		return
 	}
-	defer rows.Close()
-
-	var profiles []models.UserProfile
-	for rows.Next() {
-		var profile models.UserProfile
-		err := rows.Scan(&profile.UserID, &profile.Username, &profile.DisplayName, &profile.CreatedAt)
-		if err != nil {
-			h.logger.Error("Failed to scan user profile", "error", err)
-			continue
-		}
-		profile.EmailID = account.EmailID
-		profiles = append(profiles, profile)
-	}
 
 	if len(profiles) == 0 {
 		response.InternalServerError(c, "no_profiles", "No user profiles found")
@@ -215,22 +153,29 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 	response.Success(c, nil, "Logout successful")
 }
 
+// Refresh issues a new token for an authenticated user
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	tokenString := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+
+	token, err := auth.RefreshJWT(tokenString, h.config.JWTSecret)
+	if err != nil {
+		response.Unauthorized(c, "invalid_token", "Invalid or expired token")
+		return
+	}
+
+	response.Success(c, gin.H{"token": token}, "Token refreshed")
+}
+
 // GetProfile returns the current user's profile
 func (h *AuthHandler) GetProfile(c *gin.Context) {
-	userID, exists := c.Get("user_id")
-	if !exists {
-		response.Unauthorized(c, "authentication_required", "User must be authenticated")
+	userID, ok := currentUserID(c)
+	if !ok {
 		return
 	}
 
-	var profile models.UserProfile
-	err := h.db.QueryRow(
-		"SELECT user_id, email_id, username, display_name, created_at FROM user_profiles WHERE user_id = $1",
-		userID,
-	).Scan(&profile.UserID, &profile.EmailID, &profile.Username, &profile.DisplayName, &profile.CreatedAt)
-
+	profile, err := h.store.Users.GetProfile(c.Request.Context(), userID)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, store.ErrNotFound) {
 			response.NotFound(c, "user_not_found", "User profile not found")
 			return
 		}
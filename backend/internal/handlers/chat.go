@@ -1,31 +1,45 @@
 package handlers
 
 import (
-	"database/sql"
+	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
+	"touchdown-tally/internal/chat"
 	"touchdown-tally/internal/config"
 	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
 	"touchdown-tally/pkg/logger"
 	"touchdown-tally/pkg/response"
 )
 
+// Chat history page sizes
+const (
+	defaultHistoryLimit = 50
+	maxHistoryLimit     = 100
+)
+
 type ChatHandler struct {
-	db       *sql.DB
-	config   *config.Config
-	logger   *logger.Logger
-	upgrader websocket.Upgrader
-	clients  map[string]map[*websocket.Conn]string // poolID -> conn -> userID
-	messages chan models.ChatMessage
+	store      *store.Store
+	config     *config.Config
+	logger     *logger.Logger
+	upgrader   websocket.Upgrader
+	hubs       *chat.Hubs
+	moderation *chat.Moderation
+	policy     *chat.Policy
+	retention  *chat.Retention
 }
 
-func NewChatHandler(db *sql.DB, config *config.Config, logger *logger.Logger) *ChatHandler {
-	handler := &ChatHandler{
-		db:     db,
+func NewChatHandler(st *store.Store, config *config.Config, logger *logger.Logger) *ChatHandler {
+	hubs := chat.NewHubs(logger)
+	return &ChatHandler{
+		store:  st,
 		config: config,
 		logger: logger,
 		upgrader: websocket.Upgrader{
@@ -34,35 +48,47 @@ func NewChatHandler(db *sql.DB, config *config.Config, logger *logger.Logger) *C
 				return true
 			},
 		},
-		clients:  make(map[string]map[*websocket.Conn]string),
-		messages: make(chan models.ChatMessage, 256),
+		hubs:       hubs,
+		moderation: chat.NewModeration(st, hubs, logger),
+		policy:     chat.NewPolicy(config.ChatMaxMessageLength, config.ChatRateLimit),
+		retention:  chat.NewRetention(st, config.ChatMessageLimit, config.ChatArchiveDays, logger),
 	}
-
-	// Start the message broadcasting goroutine
-	go handler.handleMessages()
-
-	return handler
 }
 
-// WebSocket endpoint for real-time chat
+// WebSocket endpoint for real-time chat. A socket opened without a pool
+// follows the events of every pool the user belongs to instead.
 func (h *ChatHandler) WebSocketHandler(c *gin.Context) {
-	poolID := c.Param("id")
-	userID := c.GetString("user_id")
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
 
-	// Verify user has access to this pool
-	var memberRole string
-	err := h.db.QueryRow(`
-		SELECT role FROM pool_memberships 
-		WHERE pool_id = $1 AND user_id = $2 AND is_active = true
-	`, poolID, userID).Scan(&memberRole)
-
-	if err == sql.ErrNoRows {
-		response.Error(c, http.StatusForbidden, "Access denied to this pool")
+	rawPoolID := c.Param("id")
+	if rawPoolID == "" {
+		rawPoolID = c.Query("pool_id")
+	}
+	if rawPoolID == "" {
+		h.follow(c, userID)
 		return
 	}
+	poolID, err := strconv.Atoi(rawPoolID)
 	if err != nil {
-		h.logger.Error("Failed to check pool membership", "pool_id", poolID, "user_id", userID, "error", err)
-		response.Error(c, http.StatusInternalServerError, "Failed to validate access")
+		response.BadRequest(c, "invalid_pool_id", "Pool ID must be a number")
+		return
+	}
+
+	// Verify user has access to this pool
+	if _, ok := requireMembership(c, h.store, h.logger, poolID, userID); !ok {
+		return
+	}
+
+	// Members on a timeout are kept out of the chat until it ends
+	mute, ok := h.checkMute(c, poolID, userID)
+	if !ok {
+		return
+	}
+	if mute != nil && mute.Kind == models.ModerationTimeout {
+		h.mutedResponse(c, mute)
 		return
 	}
 
@@ -72,20 +98,20 @@ func (h *ChatHandler) WebSocketHandler(c *gin.Context) {
 		h.logger.Error("Failed to upgrade to websocket", "error", err)
 		return
 	}
-	defer conn.Close()
 
 	// Register client
-	h.registerClient(poolID, userID, conn)
-	defer h.unregisterClient(poolID, conn)
+	client, err := h.hubs.Register(poolID, userID, conn)
+	if err != nil {
+		conn.Close()
+		return
+	}
 
 	// Get user display name
-	var displayName string
-	err = h.db.QueryRow(`
-		SELECT display_name FROM user_profiles WHERE id = $1
-	`, userID).Scan(&displayName)
-	if err != nil {
+	displayName := "Unknown User"
+	if profile, err := h.store.Users.GetProfile(c.Request.Context(), userID); err != nil {
 		h.logger.Error("Failed to get user display name", "user_id", userID, "error", err)
-		displayName = "Unknown User"
+	} else {
+		displayName = profile.DisplayName
 	}
 
 	// Send join notification
@@ -97,31 +123,51 @@ func (h *ChatHandler) WebSocketHandler(c *gin.Context) {
 		MessageType: "system",
 		Timestamp:   time.Now(),
 	}
-	h.messages <- joinMessage
+	h.broadcast(joinMessage)
 
-	// Listen for messages from this client
-	for {
+	// Listen for messages from this client until it disconnects
+	client.ReadPump(func(frame []byte) {
 		var msg struct {
 			Message string `json:"message"`
 			Type    string `json:"type"`
 		}
-		
-		err := conn.ReadJSON(&msg)
-		if err != nil {
-			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
-				h.logger.Error("WebSocket error", "error", err)
-			}
-			break
+		if err := json.Unmarshal(frame, &msg); err != nil {
+			return
 		}
 
 		// Validate message
-		if len(msg.Message) == 0 || len(msg.Message) > 1000 {
-			continue
+		if len(msg.Message) == 0 || reservedMessageType(msg.Type) {
+			return
+		}
+
+		// Moderation commands are carried out rather than posted, and are
+		// not held to the size or rate limits
+		ctx := c.Request.Context()
+		if handled, failed := h.moderation.RunCommand(ctx, poolID, userID, msg.Message); handled {
+			if failed != nil {
+				client.Send(h.event(poolID, chat.EventCommandError, failed))
+			}
+			return
+		}
+
+		mute, err := h.moderation.Muted(ctx, poolID, userID)
+		if err != nil {
+			h.logger.Error("Failed to check chat mute", "pool_id", poolID, "user_id", userID, "error", err)
+			return
+		}
+		if mute != nil {
+			client.Send(h.event(poolID, chat.EventChatMuted, mute))
+			return
+		}
+
+		if rejected := h.policy.Check(poolID, userID, msg.Message); rejected != nil {
+			client.Send(h.event(poolID, rejected.Code, rejected))
+			return
 		}
 
 		// Default to user message type
 		if msg.Type == "" {
-			msg.Type = "user"
+			msg.Type = models.ChatMessageUser
 		}
 
 		// Create chat message
@@ -135,15 +181,14 @@ func (h *ChatHandler) WebSocketHandler(c *gin.Context) {
 		}
 
 		// Save to database
-		err = h.saveMessage(chatMessage)
-		if err != nil {
+		if err := h.store.Chat.Save(c.Request.Context(), &chatMessage); err != nil {
 			h.logger.Error("Failed to save chat message", "error", err)
-			continue
+			return
 		}
 
 		// Broadcast to all clients in this pool
-		h.messages <- chatMessage
-	}
+		h.broadcast(chatMessage)
+	})
 
 	// Send leave notification
 	leaveMessage := models.ChatMessage{
@@ -154,86 +199,189 @@ func (h *ChatHandler) WebSocketHandler(c *gin.Context) {
 		MessageType: "system",
 		Timestamp:   time.Now(),
 	}
-	h.messages <- leaveMessage
+	h.broadcast(leaveMessage)
 }
 
-// GetChatHistory returns chat message history for a pool
-func (h *ChatHandler) GetChatHistory(c *gin.Context) {
-	poolID := c.Param("id")
-	userID := c.GetString("user_id")
+// follow opens a socket that receives the game, standings and draft events
+// of every pool the user belongs to when it connects. It carries no chat,
+// and frames sent on it are ignored.
+func (h *ChatHandler) follow(c *gin.Context, userID int) {
+	pools, err := h.store.Pools.ListForMember(c.Request.Context(), userID)
+	if err != nil {
+		h.logger.Error("Failed to list pools to follow", "user_id", userID, "error", err)
+		response.InternalServerError(c, "pools_failed", "Failed to retrieve pools")
+		return
+	}
+	poolIDs := make([]int, len(pools))
+	for i, pool := range pools {
+		poolIDs[i] = pool.ID
+	}
 
-	// Verify user has access to this pool
-	var memberRole string
-	err := h.db.QueryRow(`
-		SELECT role FROM pool_memberships 
-		WHERE pool_id = $1 AND user_id = $2 AND is_active = true
-	`, poolID, userID).Scan(&memberRole)
-
-	if err == sql.ErrNoRows {
-		response.Error(c, http.StatusForbidden, "Access denied to this pool")
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		h.logger.Error("Failed to upgrade to websocket", "error", err)
 		return
 	}
+
+	client, err := h.hubs.Follow(userID, poolIDs, conn)
 	if err != nil {
-		h.logger.Error("Failed to check pool membership", "pool_id", poolID, "user_id", userID, "error", err)
-		response.Error(c, http.StatusInternalServerError, "Failed to validate access")
+		conn.Close()
 		return
 	}
+	client.ReadPump(func([]byte) {})
+}
 
-	// Get pagination parameters
-	limit := 50 // Default limit
+// GetChatHistory returns a page of a pool's chat history, oldest first.
+// Without a cursor it is the newest messages. The before, after and around
+// query parameters take a message ID and return the messages just before
+// it, just after it, or on either side of it including the message itself.
+// has_older and has_newer report whether there is more history beyond the
+// page in each direction.
+func (h *ChatHandler) GetChatHistory(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	poolID, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	// Verify user has access to this pool
+	if _, ok := requireMembership(c, h.store, h.logger, poolID, userID); !ok {
+		return
+	}
+
+	limit := defaultHistoryLimit
 	if limitStr := c.Query("limit"); limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= maxHistoryLimit {
 			limit = l
 		}
 	}
 
-	offset := 0
-	if offsetStr := c.Query("offset"); offsetStr != "" {
-		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
-			offset = o
+	anchor, messageID := "", 0
+	for _, name := range []string{"before", "after", "around"} {
+		raw := c.Query(name)
+		if raw == "" {
+			continue
+		}
+		if anchor != "" {
+			response.BadRequest(c, "invalid_cursor", "Use only one of before, after and around")
+			return
+		}
+		id, err := strconv.Atoi(raw)
+		if err != nil || id <= 0 {
+			response.BadRequest(c, "invalid_cursor", name+" must be a message ID")
+			return
 		}
+		anchor, messageID = name, id
 	}
 
-	// Get chat messages
-	messages, err := h.getChatHistory(poolID, limit, offset)
+	messages, hasOlder, hasNewer, err := h.history(c.Request.Context(), poolID, anchor, messageID, limit)
+	if errors.Is(err, store.ErrNotFound) {
+		response.NotFound(c, "message_not_found", "Message not found")
+		return
+	}
 	if err != nil {
 		h.logger.Error("Failed to get chat history", "pool_id", poolID, "error", err)
-		response.Error(c, http.StatusInternalServerError, "Failed to retrieve chat history")
+		response.InternalServerError(c, "history_failed", "Failed to retrieve chat history")
 		return
 	}
 
 	response.Success(c, gin.H{
-		"pool_id":  poolID,
-		"messages": messages,
-		"limit":    limit,
-		"offset":   offset,
+		"pool_id":   poolID,
+		"messages":  messages,
+		"limit":     limit,
+		"has_older": hasOlder,
+		"has_newer": hasNewer,
 	})
 }
 
-// SendMessage allows sending a chat message via REST API (alternative to WebSocket)
-func (h *ChatHandler) SendMessage(c *gin.Context) {
-	poolID := c.Param("id")
-	userID := c.GetString("user_id")
+// history reads the page of chat history anchored at messageID, as
+// described on GetChatHistory
+func (h *ChatHandler) history(ctx context.Context, poolID int, anchor string, messageID, limit int) (messages []models.ChatMessage, hasOlder, hasNewer bool, err error) {
+	if anchor == "" {
+		messages, hasOlder, err = h.historyPage(ctx, poolID, nil, false, limit)
+		return messages, hasOlder, false, err
+	}
 
-	// Verify user has access to this pool
-	var memberRole string
-	err := h.db.QueryRow(`
-		SELECT role FROM pool_memberships 
-		WHERE pool_id = $1 AND user_id = $2 AND is_active = true
-	`, poolID, userID).Scan(&memberRole)
-
-	if err == sql.ErrNoRows {
-		response.Error(c, http.StatusForbidden, "Access denied to this pool")
-		return
+	cursor, err := h.store.Chat.Cursor(ctx, poolID, messageID)
+	if err != nil {
+		return nil, false, false, err
 	}
+
+	switch anchor {
+	case "before":
+		if messages, hasOlder, err = h.historyPage(ctx, poolID, cursor, false, limit); err != nil {
+			return nil, false, false, err
+		}
+		_, hasNewer, err = h.historyPage(ctx, poolID, cursor, true, 0)
+
+	case "after":
+		if messages, hasNewer, err = h.historyPage(ctx, poolID, cursor, true, limit); err != nil {
+			return nil, false, false, err
+		}
+		_, hasOlder, err = h.historyPage(ctx, poolID, cursor, false, 0)
+
+	case "around":
+		older, olderMore, err := h.historyPage(ctx, poolID, cursor, false, limit)
+		if err != nil {
+			return nil, false, false, err
+		}
+		// Starting one ID short of the anchor takes it in on the way forward
+		from := &models.ChatCursor{CreatedAt: cursor.CreatedAt, MessageID: cursor.MessageID - 1}
+		newer, newerMore, err := h.historyPage(ctx, poolID, from, true, limit)
+		if err != nil {
+			return nil, false, false, err
+		}
+
+		// Split the page evenly around the anchor, giving either side's
+		// unused share to the other
+		keepNewer := limit - limit/2
+		if len(older) < limit/2 {
+			keepNewer = limit - len(older)
+		}
+		if len(newer) > keepNewer {
+			newer, newerMore = newer[:keepNewer], true
+		}
+		if keepOlder := limit - len(newer); len(older) > keepOlder {
+			older, olderMore = older[len(older)-keepOlder:], true
+		}
+		messages, hasOlder, hasNewer = append(older, newer...), olderMore, newerMore
+	}
+
+	return messages, hasOlder, hasNewer, err
+}
+
+// historyPage reads up to limit messages on one side of the cursor and
+// reports whether there are more beyond them
+func (h *ChatHandler) historyPage(ctx context.Context, poolID int, cursor *models.ChatCursor, after bool, limit int) ([]models.ChatMessage, bool, error) {
+	messages, err := h.store.Chat.History(ctx, poolID, cursor, after, limit+1)
 	if err != nil {
-		h.logger.Error("Failed to check pool membership", "pool_id", poolID, "user_id", userID, "error", err)
-		response.Error(c, http.StatusInternalServerError, "Failed to validate access")
+		return nil, false, err
+	}
+	if len(messages) <= limit {
+		return messages, false, nil
+	}
+
+	// Drop the extra message from the far end of the page
+	if after {
+		return messages[:limit], true, nil
+	}
+	return messages[1:], true, nil
+}
+
+// SendMessage allows sending a chat message via REST API (alternative to WebSocket)
+func (h *ChatHandler) SendMessage(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
 		return
 	}
 
 	var req struct {
-		Message string `json:"message" binding:"required,min=1,max=1000"`
+		PoolID  int    `json:"pool_id"`
+		Message string `json:"message" binding:"required"`
 		Type    string `json:"type"`
 	}
 
@@ -242,16 +390,66 @@ func (h *ChatHandler) SendMessage(c *gin.Context) {
 		return
 	}
 
+	// The pool comes from the URL on /chat/pool/:id and from the body on /chat/send
+	poolID := req.PoolID
+	if raw := c.Param("id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil {
+			response.BadRequest(c, "invalid_id", "Invalid id")
+			return
+		}
+		poolID = id
+	}
+	if poolID <= 0 {
+		response.BadRequest(c, "pool_id_required", "Pool ID is required")
+		return
+	}
+
+	// Verify user has access to this pool
+	if _, ok := requireMembership(c, h.store, h.logger, poolID, userID); !ok {
+		return
+	}
+
+	if reservedMessageType(req.Type) {
+		response.BadRequest(c, "invalid_message_type", "Messages of type "+req.Type+" are posted by the server")
+		return
+	}
+
+	// Moderation commands are carried out rather than posted, and are not
+	// held to the size or rate limits
+	if handled, failed := h.moderation.RunCommand(c.Request.Context(), poolID, userID, req.Message); handled {
+		if failed != nil {
+			response.BadRequest(c, "command_failed", failed.Error)
+			return
+		}
+		response.Success(c, gin.H{"command": strings.Fields(req.Message)[0]}, "Command carried out")
+		return
+	}
+
+	mute, ok := h.checkMute(c, poolID, userID)
+	if !ok {
+		return
+	}
+	if mute != nil {
+		h.mutedResponse(c, mute)
+		return
+	}
+
+	if rejected := h.policy.Check(poolID, userID, req.Message); rejected != nil {
+		if rejected.RetryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(rejected.RetryAfter))
+		}
+		response.Error(c, rejected.Status, rejected.Code, rejected.Message)
+		return
+	}
+
 	// Default to user message type
 	if req.Type == "" {
-		req.Type = "user"
+		req.Type = models.ChatMessageUser
 	}
 
 	// Get user display name
-	var displayName string
-	err = h.db.QueryRow(`
-		SELECT display_name FROM user_profiles WHERE id = $1
-	`, userID).Scan(&displayName)
+	profile, err := h.store.Users.GetProfile(c.Request.Context(), userID)
 	if err != nil {
 		h.logger.Error("Failed to get user display name", "user_id", userID, "error", err)
 		response.Error(c, http.StatusInternalServerError, "Failed to get user information")
@@ -262,14 +460,14 @@ func (h *ChatHandler) SendMessage(c *gin.Context) {
 	chatMessage := models.ChatMessage{
 		PoolID:      poolID,
 		UserID:      userID,
-		DisplayName: displayName,
+		DisplayName: profile.DisplayName,
 		Message:     req.Message,
 		MessageType: req.Type,
 		Timestamp:   time.Now(),
 	}
 
 	// Save to database
-	err = h.saveMessage(chatMessage)
+	err = h.store.Chat.Save(c.Request.Context(), &chatMessage)
 	if err != nil {
 		h.logger.Error("Failed to save chat message", "error", err)
 		response.Error(c, http.StatusInternalServerError, "Failed to send message")
@@ -277,7 +475,7 @@ func (h *ChatHandler) SendMessage(c *gin.Context) {
 	}
 
 	// Broadcast to WebSocket clients
-	h.messages <- chatMessage
+	h.broadcast(chatMessage)
 
 	response.Success(c, gin.H{
 		"message": "Message sent successfully",
@@ -287,88 +485,34 @@ func (h *ChatHandler) SendMessage(c *gin.Context) {
 
 // Helper functions
 
-func (h *ChatHandler) registerClient(poolID, userID string, conn *websocket.Conn) {
-	if h.clients[poolID] == nil {
-		h.clients[poolID] = make(map[*websocket.Conn]string)
-	}
-	h.clients[poolID][conn] = userID
-	h.logger.Info("Client registered", "pool_id", poolID, "user_id", userID)
+// RunRetention prunes each pool's chat history to its retention settings
+// until ctx is cancelled
+func (h *ChatHandler) RunRetention(ctx context.Context) {
+	h.retention.Run(ctx)
 }
 
-func (h *ChatHandler) unregisterClient(poolID string, conn *websocket.Conn) {
-	if h.clients[poolID] != nil {
-		if userID, exists := h.clients[poolID][conn]; exists {
-			delete(h.clients[poolID], conn)
-			h.logger.Info("Client unregistered", "pool_id", poolID, "user_id", userID)
-			
-			if len(h.clients[poolID]) == 0 {
-				delete(h.clients, poolID)
-			}
-		}
-	}
+// Close sends a close frame to every connected WebSocket client and stops
+// the pool hubs. It is safe to call more than once.
+func (h *ChatHandler) Close() {
+	h.hubs.Close()
 }
 
-func (h *ChatHandler) handleMessages() {
-	for {
-		message := <-h.messages
-		
-		// Broadcast to all clients in the pool
-		if clients, exists := h.clients[message.PoolID]; exists {
-			for conn := range clients {
-				err := conn.WriteJSON(message)
-				if err != nil {
-					h.logger.Error("Failed to send message to client", "error", err)
-					conn.Close()
-					delete(clients, conn)
-				}
-			}
-		}
-	}
-}
-
-func (h *ChatHandler) saveMessage(message models.ChatMessage) error {
-	_, err := h.db.Exec(`
-		INSERT INTO chat_messages (pool_id, user_id, message, message_type, created_at)
-		VALUES ($1, $2, $3, $4, $5)
-	`, message.PoolID, message.UserID, message.Message, message.MessageType, message.Timestamp)
-	return err
+// Broadcast pushes a typed event to every client connected to the pool or
+// following it
+func (h *ChatHandler) Broadcast(poolID int, eventType string, data interface{}) {
+	h.hubs.Publish(poolID, h.event(poolID, eventType, data))
 }
 
-func (h *ChatHandler) getChatHistory(poolID string, limit, offset int) ([]models.ChatMessage, error) {
-	rows, err := h.db.Query(`
-		SELECT 
-			cm.id, cm.pool_id, cm.user_id, up.display_name,
-			cm.message, cm.message_type, cm.created_at
-		FROM chat_messages cm
-		JOIN user_profiles up ON cm.user_id = up.id
-		WHERE cm.pool_id = $1
-		ORDER BY cm.created_at DESC
-		LIMIT $2 OFFSET $3
-	`, poolID, limit, offset)
-
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var messages []models.ChatMessage
-	for rows.Next() {
-		var msg models.ChatMessage
-		err := rows.Scan(
-			&msg.ID, &msg.PoolID, &msg.UserID, &msg.DisplayName,
-			&msg.Message, &msg.MessageType, &msg.Timestamp,
-		)
-		if err != nil {
-			return nil, err
-		}
-		messages = append(messages, msg)
-	}
-
-	// Reverse the slice to show oldest messages first
-	for i := len(messages)/2 - 1; i >= 0; i-- {
-		opp := len(messages) - 1 - i
-		messages[i], messages[opp] = messages[opp], messages[i]
+func (h *ChatHandler) event(poolID int, eventType string, data interface{}) models.Event {
+	return models.Event{
+		Type:      eventType,
+		PoolID:    poolID,
+		Data:      data,
+		Timestamp: time.Now().UTC(),
 	}
+}
 
-	return messages, rows.Err()
+// broadcast sends a chat message to the clients of its pool
+func (h *ChatHandler) broadcast(message models.ChatMessage) {
+	h.hubs.Broadcast(message.PoolID, message)
 }
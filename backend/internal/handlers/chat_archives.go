@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
+	"touchdown-tally/pkg/response"
+)
+
+// Archives lists the exports of chat messages pruned from the pool
+func (h *ChatHandler) Archives(c *gin.Context) {
+	poolID, ok := h.archiveRequest(c)
+	if !ok {
+		return
+	}
+
+	archives, err := h.retention.Archives(c.Request.Context(), poolID)
+	if err != nil {
+		h.logger.Error("Failed to list chat archives", "pool_id", poolID, "error", err)
+		response.InternalServerError(c, "archives_failed", "Failed to retrieve chat archives")
+		return
+	}
+
+	response.Success(c, archives)
+}
+
+// DownloadArchive sends a chat archive as gzip-compressed JSON Lines
+func (h *ChatHandler) DownloadArchive(c *gin.Context) {
+	poolID, ok := h.archiveRequest(c)
+	if !ok {
+		return
+	}
+
+	archiveID, ok := idParam(c, "archive_id")
+	if !ok {
+		return
+	}
+
+	archive, data, err := h.retention.Archive(c.Request.Context(), poolID, archiveID)
+	if errors.Is(err, store.ErrNotFound) {
+		response.NotFound(c, "archive_not_found", "Chat archive not found")
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to get chat archive", "pool_id", poolID, "archive_id", archiveID, "error", err)
+		response.InternalServerError(c, "archive_failed", "Failed to retrieve chat archive")
+		return
+	}
+
+	filename := fmt.Sprintf("pool-%d-chat-%s-%s.jsonl.gz", poolID,
+		archive.FirstMessageAt.UTC().Format("20060102"), archive.LastMessageAt.UTC().Format("20060102"))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Data(http.StatusOK, "application/gzip", data)
+}
+
+// archiveRequest reads the pool of an archive request and checks the user
+// is its commissioner. It writes the error response when not.
+func (h *ChatHandler) archiveRequest(c *gin.Context) (int, bool) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return 0, false
+	}
+
+	poolID, ok := idParam(c, "id")
+	if !ok {
+		return 0, false
+	}
+
+	role, ok := requireMembership(c, h.store, h.logger, poolID, userID)
+	if !ok {
+		return 0, false
+	}
+	if role != models.RoleCommissioner {
+		response.Forbidden(c, "commissioner_required", "Only the commissioner can access chat archives")
+		return 0, false
+	}
+	return poolID, true
+}
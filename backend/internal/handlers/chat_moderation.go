@@ -0,0 +1,240 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"touchdown-tally/internal/chat"
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
+	"touchdown-tally/pkg/response"
+)
+
+// DeleteMessage lets the commissioner or a moderator remove a message
+func (h *ChatHandler) DeleteMessage(c *gin.Context) {
+	poolID, userID, ok := h.moderationRequest(c)
+	if !ok {
+		return
+	}
+
+	messageID, ok := idParam(c, "message_id")
+	if !ok {
+		return
+	}
+
+	var req models.ChatModerationRequest
+	if !bindOptionalJSON(c, &req) {
+		return
+	}
+
+	action, err := h.moderation.DeleteMessage(c.Request.Context(), poolID, userID, messageID, req.Reason)
+	if err != nil {
+		h.moderationError(c, err, "message_not_found", "Message not found")
+		return
+	}
+
+	response.Success(c, action, "Message deleted")
+}
+
+// Mute keeps a member from posting, for a duration or until lifted
+func (h *ChatHandler) Mute(c *gin.Context) {
+	h.mute(c, models.ModerationMute)
+}
+
+// Timeout keeps a member out of the chat for a duration, disconnecting them
+func (h *ChatHandler) Timeout(c *gin.Context) {
+	h.mute(c, models.ModerationTimeout)
+}
+
+func (h *ChatHandler) mute(c *gin.Context, kind string) {
+	poolID, userID, ok := h.moderationRequest(c)
+	if !ok {
+		return
+	}
+
+	var req models.ChatMuteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid_request", err.Error())
+		return
+	}
+
+	action, err := h.moderation.Mute(c.Request.Context(), poolID, userID, kind, req)
+	if err != nil {
+		h.moderationError(c, err, "member_not_found", "That user is not a member of this pool")
+		return
+	}
+
+	message := "Member muted"
+	if kind == models.ModerationTimeout {
+		message = "Member timed out"
+	}
+	response.Created(c, action, message)
+}
+
+// Unmute lifts a member's mute or timeout
+func (h *ChatHandler) Unmute(c *gin.Context) {
+	poolID, userID, ok := h.moderationRequest(c)
+	if !ok {
+		return
+	}
+
+	targetID, ok := idParam(c, "user_id")
+	if !ok {
+		return
+	}
+
+	var req models.ChatModerationRequest
+	if !bindOptionalJSON(c, &req) {
+		return
+	}
+
+	action, err := h.moderation.Unmute(c.Request.Context(), poolID, userID, targetID, req.Reason)
+	if err != nil {
+		h.moderationError(c, err, "not_muted", "That member is not muted")
+		return
+	}
+
+	response.Success(c, action, "Mute lifted")
+}
+
+// ClearMessages removes a member's recent messages
+func (h *ChatHandler) ClearMessages(c *gin.Context) {
+	poolID, userID, ok := h.moderationRequest(c)
+	if !ok {
+		return
+	}
+
+	var req models.ChatClearRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid_request", err.Error())
+		return
+	}
+
+	action, err := h.moderation.Clear(c.Request.Context(), poolID, userID, req)
+	if err != nil {
+		h.moderationError(c, err, "member_not_found", "That user is not a member of this pool")
+		return
+	}
+
+	response.Success(c, action, "Messages cleared")
+}
+
+// ModerationLog returns the pool's moderation actions, newest first
+func (h *ChatHandler) ModerationLog(c *gin.Context) {
+	poolID, userID, ok := h.moderationRequest(c)
+	if !ok {
+		return
+	}
+
+	limit := 0
+	if raw := c.Query("limit"); raw != "" {
+		if l, err := strconv.Atoi(raw); err == nil {
+			limit = l
+		}
+	}
+
+	actions, err := h.moderation.Log(c.Request.Context(), poolID, userID, limit)
+	if err != nil {
+		h.moderationError(c, err, "pool_not_found", "Pool not found")
+		return
+	}
+
+	response.Success(c, actions)
+}
+
+// moderationRequest reads the pool and user of a moderation request and
+// checks the user may moderate it. It writes the error response when not.
+func (h *ChatHandler) moderationRequest(c *gin.Context) (poolID, userID int, ok bool) {
+	userID, ok = currentUserID(c)
+	if !ok {
+		return 0, 0, false
+	}
+
+	poolID, ok = idParam(c, "id")
+	if !ok {
+		return 0, 0, false
+	}
+
+	if _, ok := requireMembership(c, h.store, h.logger, poolID, userID); !ok {
+		return 0, 0, false
+	}
+
+	allowed, err := h.moderation.CanModerate(c.Request.Context(), poolID, userID)
+	if err != nil {
+		h.logger.Error("Failed to check moderator role", "pool_id", poolID, "user_id", userID, "error", err)
+		response.InternalServerError(c, "role_check_failed", "Failed to verify permissions")
+		return 0, 0, false
+	}
+	if !allowed {
+		response.Forbidden(c, "moderator_required", "Only the commissioner or a moderator can moderate chat")
+		return 0, 0, false
+	}
+	return poolID, userID, true
+}
+
+// moderationError writes the response for a failed moderation action.
+// notFoundCode and notFoundMessage describe a store.ErrNotFound.
+func (h *ChatHandler) moderationError(c *gin.Context, err error, notFoundCode, notFoundMessage string) {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		response.NotFound(c, notFoundCode, notFoundMessage)
+	case errors.Is(err, chat.ErrNotModerator):
+		response.Forbidden(c, "moderator_required", "Only the commissioner or a moderator can moderate chat")
+	case errors.Is(err, chat.ErrProtectedMember):
+		response.Forbidden(c, "protected_member", "Commissioners cannot be moderated, and moderators only by a commissioner")
+	case errors.Is(err, chat.ErrSelf):
+		response.BadRequest(c, "cannot_moderate_self", "You cannot moderate yourself")
+	case errors.Is(err, chat.ErrInvalidDuration):
+		response.BadRequest(c, "invalid_duration", "Duration must be like 10m, 2h or 3d and within the allowed maximum")
+	case errors.Is(err, chat.ErrNoMessages):
+		response.NotFound(c, "no_messages", "The member has no messages to clear in that window")
+	default:
+		h.logger.Error("Chat moderation failed", "path", c.FullPath(), "error", err)
+		response.InternalServerError(c, "moderation_failed", "Failed to carry out the moderation action")
+	}
+}
+
+// checkMute returns the user's mute in the pool, or nil when they may post.
+// It writes a 500 response when the lookup fails.
+func (h *ChatHandler) checkMute(c *gin.Context, poolID, userID int) (*models.ChatMute, bool) {
+	mute, err := h.moderation.Muted(c.Request.Context(), poolID, userID)
+	if err != nil {
+		h.logger.Error("Failed to check chat mute", "pool_id", poolID, "user_id", userID, "error", err)
+		response.InternalServerError(c, "mute_check_failed", "Failed to check chat permissions")
+		return nil, false
+	}
+	return mute, true
+}
+
+// mutedResponse writes the 403 for a member who is muted or timed out
+func (h *ChatHandler) mutedResponse(c *gin.Context, mute *models.ChatMute) {
+	code, message := "chat_muted", "You are muted in this pool's chat"
+	if mute.Kind == models.ModerationTimeout {
+		code, message = "chat_timed_out", "You are timed out of this pool's chat"
+	}
+	if mute.ExpiresAt != nil {
+		message += " until " + mute.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	response.Forbidden(c, code, message)
+}
+
+// reservedMessageType reports whether a message type may only be posted by
+// the server
+func reservedMessageType(messageType string) bool {
+	return messageType == models.ChatMessageSystem || messageType == models.ChatMessageModeration
+}
+
+// bindOptionalJSON binds a request body when there is one. It writes a 400
+// response when the body is malformed.
+func bindOptionalJSON(c *gin.Context, dest interface{}) bool {
+	if c.Request.ContentLength == 0 {
+		return true
+	}
+	if err := c.ShouldBindJSON(dest); err != nil {
+		response.BadRequest(c, "invalid_request", err.Error())
+		return false
+	}
+	return true
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"touchdown-tally/internal/chat"
+	"touchdown-tally/internal/store"
+
+	"github.com/gorilla/websocket"
+)
+
+// chatFixture routes the chat handlers over SQLite, allowing messages of up
+// to 20 characters and one message a minute
+func chatFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	f := newFixture(t)
+	f.store = store.New(openSQLite(t))
+	f.config.ChatMaxMessageLength = 20
+	f.config.ChatRateLimit = 1
+
+	h := NewChatHandler(f.store, f.config, f.logger)
+	t.Cleanup(h.Close)
+	f.router.POST("/chat/pool/:id", h.SendMessage)
+	f.router.GET("/chat/pool/:id/ws", h.WebSocketHandler)
+	return f
+}
+
+func TestSendMessageLimits(t *testing.T) {
+	f := chatFixture(t)
+	commissioner := f.user(t, "commish")
+	member := f.user(t, "member")
+	poolID := f.pool(t, commissioner, openSettings(), member)
+	path := "/chat/pool/" + strconv.Itoa(poolID)
+
+	long := map[string]string{"message": strings.Repeat("a", 21)}
+	expectError(t, f.do(t, http.MethodPost, path, member, long), http.StatusBadRequest, chat.EventMessageTooLong)
+
+	expectStatus(t, f.do(t, http.MethodPost, path, commissioner, map[string]string{"message": "kickoff!"}), http.StatusOK, nil)
+	rec := f.do(t, http.MethodPost, path, commissioner, map[string]string{"message": "again"})
+	expectError(t, rec, http.StatusTooManyRequests, chat.EventRateLimited)
+	if rec.Header().Get("Retry-After") == "" {
+		t.Fatal("rate limited response has no Retry-After")
+	}
+
+	// Commands are carried out even when the moderator is over the rate
+	// limit, and however long they are
+	command := map[string]string{"message": "/mute member for spamming the chat"}
+	expectStatus(t, f.do(t, http.MethodPost, path, commissioner, command), http.StatusOK, nil)
+
+	// A muted member hears that they are muted, not that their message is
+	// too long or too fast
+	expectError(t, f.do(t, http.MethodPost, path, member, long), http.StatusForbidden, "chat_muted")
+	expectError(t, f.do(t, http.MethodPost, path, member, long), http.StatusForbidden, "chat_muted")
+}
+
+func TestWebSocketMessageLimits(t *testing.T) {
+	f := chatFixture(t)
+	commissioner := f.user(t, "commish")
+	member := f.user(t, "member")
+	poolID := f.pool(t, commissioner, openSettings(), member)
+	path := "/chat/pool/" + strconv.Itoa(poolID)
+
+	server := httptest.NewServer(f.router)
+	t.Cleanup(server.Close)
+	dial := func(userID int) *websocket.Conn {
+		t.Helper()
+
+		header := http.Header{testUserHeader: {strconv.Itoa(userID)}}
+		conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+path+"/ws", header)
+		if err != nil {
+			t.Fatalf("dial: %v", err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		return conn
+	}
+	// expect reads frames until one of the type arrives
+	expect := func(conn *websocket.Conn, eventType string) {
+		t.Helper()
+
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		for {
+			var frame struct {
+				Type string `json:"type"`
+			}
+			_, raw, err := conn.ReadMessage()
+			if err != nil {
+				t.Fatalf("waiting for %s: %v", eventType, err)
+			}
+			if err := json.Unmarshal(raw, &frame); err == nil && frame.Type == eventType {
+				return
+			}
+		}
+	}
+
+	moderator := dial(commissioner)
+	if err := moderator.WriteJSON(map[string]string{"message": "kickoff!"}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := moderator.WriteJSON(map[string]string{"message": "again"}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	expect(moderator, chat.EventRateLimited)
+
+	if err := moderator.WriteJSON(map[string]string{"message": "/mute member"}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	expect(moderator, chat.EventUserMuted)
+
+	muted := dial(member)
+	if err := muted.WriteJSON(map[string]string{"message": strings.Repeat("a", 21)}); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	expect(muted, chat.EventChatMuted)
+}
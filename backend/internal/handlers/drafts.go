@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"touchdown-tally/internal/config"
+	"touchdown-tally/internal/draft"
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
+	"touchdown-tally/pkg/logger"
+	"touchdown-tally/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DraftHandler handles pool draft requests
+type DraftHandler struct {
+	store   *store.Store
+	service *draft.Service
+	config  *config.Config
+	logger  *logger.Logger
+}
+
+// NewDraftHandler creates a new DraftHandler
+func NewDraftHandler(st *store.Store, service *draft.Service, cfg *config.Config, logger *logger.Logger) *DraftHandler {
+	return &DraftHandler{
+		store:   st,
+		service: service,
+		config:  cfg,
+		logger:  logger,
+	}
+}
+
+// RunClock starts scheduled drafts and enforces pick clocks until ctx is cancelled
+func (h *DraftHandler) RunClock(ctx context.Context) {
+	h.service.Run(ctx)
+}
+
+// Get returns the pool's draft board
+func (h *DraftHandler) Get(c *gin.Context) {
+	poolID, _, ok := h.member(c)
+	if !ok {
+		return
+	}
+
+	board, err := h.service.Board(c.Request.Context(), poolID)
+	if err != nil {
+		h.respondError(c, poolID, err)
+		return
+	}
+
+	response.Success(c, board)
+}
+
+// Schedule creates or moves the pool's draft. Commissioner only.
+func (h *DraftHandler) Schedule(c *gin.Context) {
+	poolID, userID, ok := h.commissioner(c)
+	if !ok {
+		return
+	}
+
+	var req models.ScheduleDraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid_request", err.Error())
+		return
+	}
+
+	scheduled, err := h.service.Schedule(c.Request.Context(), poolID, userID, req.ScheduledAt, req.PickSeconds)
+	if err != nil {
+		h.respondError(c, poolID, err)
+		return
+	}
+
+	h.logger.Info("Draft scheduled", "pool_id", poolID, "scheduled_at", scheduled.ScheduledAt)
+	response.Success(c, scheduled, "Draft scheduled")
+}
+
+// Start begins the draft immediately. Commissioner only.
+func (h *DraftHandler) Start(c *gin.Context) {
+	poolID, _, ok := h.commissioner(c)
+	if !ok {
+		return
+	}
+
+	board, err := h.service.Start(c.Request.Context(), poolID)
+	if err != nil {
+		h.respondError(c, poolID, err)
+		return
+	}
+
+	response.Success(c, board, "Draft started")
+}
+
+// Pick selects a team for the member on the clock
+func (h *DraftHandler) Pick(c *gin.Context) {
+	poolID, userID, ok := h.member(c)
+	if !ok {
+		return
+	}
+
+	var req models.DraftPickRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid_request", err.Error())
+		return
+	}
+
+	pick, err := h.service.Pick(c.Request.Context(), poolID, userID, req.TeamID)
+	if err != nil {
+		h.respondError(c, poolID, err)
+		return
+	}
+
+	response.Created(c, pick, "Pick made")
+}
+
+// GetQueue returns the caller's autopick queue
+func (h *DraftHandler) GetQueue(c *gin.Context) {
+	poolID, userID, ok := h.member(c)
+	if !ok {
+		return
+	}
+
+	queue, err := h.service.Queue(c.Request.Context(), poolID, userID)
+	if err != nil {
+		h.respondError(c, poolID, err)
+		return
+	}
+
+	response.Success(c, queue)
+}
+
+// SetQueue replaces the caller's autopick queue
+func (h *DraftHandler) SetQueue(c *gin.Context) {
+	poolID, userID, ok := h.member(c)
+	if !ok {
+		return
+	}
+
+	var req models.DraftQueueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid_request", err.Error())
+		return
+	}
+
+	queue, err := h.service.SetQueue(c.Request.Context(), poolID, userID, req.TeamIDs)
+	if err != nil {
+		h.respondError(c, poolID, err)
+		return
+	}
+
+	response.Success(c, queue, "Draft queue saved")
+}
+
+// SetAbsent flags a member as absent so their picks are made automatically.
+// Members may flag themselves; the commissioner may flag anyone.
+func (h *DraftHandler) SetAbsent(c *gin.Context) {
+	poolID, userID, ok := h.member(c)
+	if !ok {
+		return
+	}
+
+	var req models.DraftAbsenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid_request", err.Error())
+		return
+	}
+
+	target := userID
+	if req.UserID != 0 && req.UserID != userID {
+		role, err := h.store.Pools.MemberRole(c.Request.Context(), poolID, userID)
+		if err != nil {
+			h.logger.Error("Failed to check pool role", "pool_id", poolID, "user_id", userID, "error", err)
+			response.InternalServerError(c, "membership_check_failed", "Failed to verify pool membership")
+			return
+		}
+		if role != models.RoleCommissioner {
+			response.Forbidden(c, "commissioner_required", "Only the commissioner can flag other members")
+			return
+		}
+
+		isMember, err := h.store.Pools.IsMember(c.Request.Context(), poolID, req.UserID)
+		if err != nil {
+			h.logger.Error("Failed to check pool membership", "pool_id", poolID, "user_id", req.UserID, "error", err)
+			response.InternalServerError(c, "membership_check_failed", "Failed to verify pool membership")
+			return
+		}
+		if !isMember {
+			response.BadRequest(c, "invalid_user_id", "User is not a member of this pool")
+			return
+		}
+		target = req.UserID
+	}
+
+	if err := h.service.SetAbsent(c.Request.Context(), poolID, target, userID, *req.Absent); err != nil {
+		h.respondError(c, poolID, err)
+		return
+	}
+
+	response.Success(c, gin.H{"user_id": target, "absent": *req.Absent}, "Draft absence updated")
+}
+
+// member resolves the pool and user for a request from any pool member
+func (h *DraftHandler) member(c *gin.Context) (poolID, userID int, ok bool) {
+	if userID, ok = currentUserID(c); !ok {
+		return 0, 0, false
+	}
+	if poolID, ok = idParam(c, "id"); !ok {
+		return 0, 0, false
+	}
+	if _, ok = requireMembership(c, h.store, h.logger, poolID, userID); !ok {
+		return 0, 0, false
+	}
+	return poolID, userID, true
+}
+
+// commissioner resolves the pool and user for a commissioner-only request
+func (h *DraftHandler) commissioner(c *gin.Context) (poolID, userID int, ok bool) {
+	if userID, ok = currentUserID(c); !ok {
+		return 0, 0, false
+	}
+	if poolID, ok = idParam(c, "id"); !ok {
+		return 0, 0, false
+	}
+
+	role, ok := requireMembership(c, h.store, h.logger, poolID, userID)
+	if !ok {
+		return 0, 0, false
+	}
+	if role != models.RoleCommissioner {
+		response.Forbidden(c, "commissioner_required", "Only the commissioner can manage the draft")
+		return 0, 0, false
+	}
+	return poolID, userID, true
+}
+
+func (h *DraftHandler) respondError(c *gin.Context, poolID int, err error) {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		response.NotFound(c, "draft_not_found", "This pool has no draft")
+	case errors.Is(err, draft.ErrNotYourTurn):
+		response.Forbidden(c, "not_your_turn", err.Error())
+	case errors.Is(err, draft.ErrTeamTaken):
+		response.Conflict(c, "team_already_picked", err.Error())
+	case errors.Is(err, draft.ErrDraftExists), errors.Is(err, draft.ErrDraftStarted):
+		response.Conflict(c, "draft_started", err.Error())
+	case errors.Is(err, draft.ErrPicksExist):
+		response.Conflict(c, "picks_exist", err.Error())
+	case errors.Is(err, draft.ErrDraftCompleted):
+		response.Conflict(c, "draft_completed", err.Error())
+	case errors.Is(err, draft.ErrDuplicateTeam):
+		response.BadRequest(c, "duplicate_team", err.Error())
+	case errors.Is(err, draft.ErrDraftNotRunning):
+		response.Conflict(c, "draft_not_running", err.Error())
+	case errors.Is(err, draft.ErrNotEnoughMembers), errors.Is(err, draft.ErrTooManyMembers):
+		response.BadRequest(c, "invalid_member_count", err.Error())
+	case errors.Is(err, draft.ErrUnknownTeam):
+		response.BadRequest(c, "invalid_team", err.Error())
+	case errors.Is(err, draft.ErrScheduledInThePast):
+		response.BadRequest(c, "invalid_scheduled_at", err.Error())
+	default:
+		h.logger.Error("Draft request failed", "pool_id", poolID, "error", err)
+		response.InternalServerError(c, "draft_failed", "Failed to process draft request")
+	}
+}
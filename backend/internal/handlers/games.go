@@ -1,11 +1,19 @@
 package handlers
 
 import (
-	"database/sql"
+	"context"
+	"errors"
+	"io"
+	"net/http"
 	"strconv"
 
+	"touchdown-tally/internal/calendar"
 	"touchdown-tally/internal/config"
+	"touchdown-tally/internal/corrections"
 	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/schedule"
+	"touchdown-tally/internal/scores"
+	"touchdown-tally/internal/store"
 	"touchdown-tally/pkg/logger"
 	"touchdown-tally/pkg/response"
 
@@ -14,142 +22,90 @@ import (
 
 // GameHandler handles game-related requests
 type GameHandler struct {
-	db     *sql.DB
-	config *config.Config
-	logger *logger.Logger
+	store       *store.Store
+	syncer      *scores.Syncer
+	importer    *schedule.Importer
+	corrections *corrections.Service
+	calendar    *calendar.Calendar
+	config      *config.Config
+	logger      *logger.Logger
 }
 
-// NewGameHandler creates a new GameHandler
-func NewGameHandler(db *sql.DB, cfg *config.Config, logger *logger.Logger) *GameHandler {
+// NewGameHandler creates a new GameHandler. syncer may be nil when no score
+// provider is configured.
+func NewGameHandler(st *store.Store, syncer *scores.Syncer, importer *schedule.Importer, corrections *corrections.Service, cal *calendar.Calendar, cfg *config.Config, logger *logger.Logger) *GameHandler {
 	return &GameHandler{
-		db:     db,
-		config: cfg,
-		logger: logger,
+		store:       st,
+		syncer:      syncer,
+		importer:    importer,
+		corrections: corrections,
+		calendar:    cal,
+		config:      cfg,
+		logger:      logger,
 	}
 }
 
+// RunScoreSync keeps nfl_games up to date from the score provider until ctx
+// is cancelled
+func (h *GameHandler) RunScoreSync(ctx context.Context) {
+	if h.syncer == nil {
+		h.logger.Warn("Score sync not started: no score provider configured")
+		return
+	}
+	h.syncer.Run(ctx)
+}
+
 // List returns games with optional filters
 func (h *GameHandler) List(c *gin.Context) {
-	seasonYear := c.Query("season_year")
-	week := c.Query("week")
-	status := c.Query("status")
-
-	query := `
-		SELECT g.game_id, g.external_id, g.season_year, g.week, g.game_type,
-		       g.home_team_id, g.away_team_id, g.game_date, g.home_score, g.away_score,
-		       g.status, g.quarter, g.time_remaining, g.last_updated, g.created_at,
-		       ht.team_name as home_team_name, ht.team_abbreviation as home_team_abbr,
-		       ht.city as home_team_city, ht.conference as home_team_conference,
-		       ht.division as home_team_division, ht.logo_url as home_team_logo,
-		       ht.primary_color as home_team_primary, ht.secondary_color as home_team_secondary,
-		       at.team_name as away_team_name, at.team_abbreviation as away_team_abbr,
-		       at.city as away_team_city, at.conference as away_team_conference,
-		       at.division as away_team_division, at.logo_url as away_team_logo,
-		       at.primary_color as away_team_primary, at.secondary_color as away_team_secondary
-		FROM nfl_games g
-		JOIN nfl_teams ht ON g.home_team_id = ht.team_id
-		JOIN nfl_teams at ON g.away_team_id = at.team_id
-		WHERE 1=1`
-
-	var args []interface{}
-	argCount := 0
+	h.listGames(c, c.Query("season_year"), c.Query("week"), c.Query("status"))
+}
+
+func (h *GameHandler) listGames(c *gin.Context, seasonYear, week, status string) {
+	filter := store.GameFilter{Status: status}
+
+	if status != "" && !models.ValidGameStatus(status) {
+		response.BadRequest(c, "invalid_status", "Unknown game status")
+		return
+	}
 
 	if seasonYear != "" {
-		argCount++
-		query += " AND g.season_year = $" + strconv.Itoa(argCount)
-		if year, err := strconv.Atoi(seasonYear); err == nil {
-			args = append(args, year)
+		year, err := strconv.Atoi(seasonYear)
+		if err != nil {
+			response.BadRequest(c, "invalid_season_year", "Season year must be a number")
+			return
 		}
+		filter.SeasonYear = &year
 	}
 
 	if week != "" {
-		argCount++
-		query += " AND g.week = $" + strconv.Itoa(argCount)
-		if weekNum, err := strconv.Atoi(week); err == nil {
-			args = append(args, weekNum)
+		weekNum, err := strconv.Atoi(week)
+		if err != nil {
+			response.BadRequest(c, "invalid_week", "Week must be a number")
+			return
 		}
+		filter.Week = &weekNum
 	}
 
-	if status != "" {
-		argCount++
-		query += " AND g.status = $" + strconv.Itoa(argCount)
-		args = append(args, status)
-	}
-
-	query += " ORDER BY g.game_date"
-
-	rows, err := h.db.Query(query, args...)
+	games, err := h.store.Games.List(c.Request.Context(), filter)
 	if err != nil {
 		h.logger.Error("Failed to query games", "error", err)
 		response.InternalServerError(c, "query_failed", "Failed to fetch games")
 		return
 	}
-	defer rows.Close()
-
-	var games []models.GameWithTeams
-	for rows.Next() {
-		var game models.GameWithTeams
-		err := rows.Scan(
-			&game.GameID, &game.ExternalID, &game.SeasonYear, &game.Week, &game.GameType,
-			&game.HomeTeamID, &game.AwayTeamID, &game.GameDate, &game.HomeScore, &game.AwayScore,
-			&game.Status, &game.Quarter, &game.TimeRemaining, &game.LastUpdated, &game.CreatedAt,
-			&game.HomeTeam.TeamName, &game.HomeTeam.TeamAbbreviation, &game.HomeTeam.City,
-			&game.HomeTeam.Conference, &game.HomeTeam.Division, &game.HomeTeam.LogoURL,
-			&game.HomeTeam.PrimaryColor, &game.HomeTeam.SecondaryColor,
-			&game.AwayTeam.TeamName, &game.AwayTeam.TeamAbbreviation, &game.AwayTeam.City,
-			&game.AwayTeam.Conference, &game.AwayTeam.Division, &game.AwayTeam.LogoURL,
-			&game.AwayTeam.PrimaryColor, &game.AwayTeam.SecondaryColor,
-		)
-		if err != nil {
-			h.logger.Error("Failed to scan game", "error", err)
-			continue
-		}
-
-		game.HomeTeam.TeamID = game.HomeTeamID
-		game.AwayTeam.TeamID = game.AwayTeamID
-
-		games = append(games, game)
-	}
 
 	response.Success(c, games)
 }
 
 // Get returns a specific game by ID
 func (h *GameHandler) Get(c *gin.Context) {
-	gameID := c.Param("id")
-
-	var game models.GameWithTeams
-	err := h.db.QueryRow(`
-		SELECT g.game_id, g.external_id, g.season_year, g.week, g.game_type,
-		       g.home_team_id, g.away_team_id, g.game_date, g.home_score, g.away_score,
-		       g.status, g.quarter, g.time_remaining, g.last_updated, g.created_at,
-		       ht.team_name as home_team_name, ht.team_abbreviation as home_team_abbr,
-		       ht.city as home_team_city, ht.conference as home_team_conference,
-		       ht.division as home_team_division, ht.logo_url as home_team_logo,
-		       ht.primary_color as home_team_primary, ht.secondary_color as home_team_secondary,
-		       at.team_name as away_team_name, at.team_abbreviation as away_team_abbr,
-		       at.city as away_team_city, at.conference as away_team_conference,
-		       at.division as away_team_division, at.logo_url as away_team_logo,
-		       at.primary_color as away_team_primary, at.secondary_color as away_team_secondary
-		FROM nfl_games g
-		JOIN nfl_teams ht ON g.home_team_id = ht.team_id
-		JOIN nfl_teams at ON g.away_team_id = at.team_id
-		WHERE g.game_id = $1`,
-		gameID,
-	).Scan(
-		&game.GameID, &game.ExternalID, &game.SeasonYear, &game.Week, &game.GameType,
-		&game.HomeTeamID, &game.AwayTeamID, &game.GameDate, &game.HomeScore, &game.AwayScore,
-		&game.Status, &game.Quarter, &game.TimeRemaining, &game.LastUpdated, &game.CreatedAt,
-		&game.HomeTeam.TeamName, &game.HomeTeam.TeamAbbreviation, &game.HomeTeam.City,
-		&game.HomeTeam.Conference, &game.HomeTeam.Division, &game.HomeTeam.LogoURL,
-		&game.HomeTeam.PrimaryColor, &game.HomeTeam.SecondaryColor,
-		&game.AwayTeam.TeamName, &game.AwayTeam.TeamAbbreviation, &game.AwayTeam.City,
-		&game.AwayTeam.Conference, &game.AwayTeam.Division, &game.AwayTeam.LogoURL,
-		&game.AwayTeam.PrimaryColor, &game.AwayTeam.SecondaryColor,
-	)
+	gameID, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
 
+	game, err := h.store.Games.Get(c.Request.Context(), gameID)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, store.ErrNotFound) {
 			response.NotFound(c, "game_not_found", "Game not found")
 			return
 		}
@@ -158,22 +114,185 @@ func (h *GameHandler) Get(c *gin.Context) {
 		return
 	}
 
-	game.HomeTeam.TeamID = game.HomeTeamID
-	game.AwayTeam.TeamID = game.AwayTeamID
-
 	response.Success(c, game)
 }
 
+// Current returns the NFL week in play with its games and the teams on bye.
+// The season defaults to the configured one.
+func (h *GameHandler) Current(c *gin.Context) {
+	season := h.config.NFLSeasonYear
+	if value := c.Query("season_year"); value != "" {
+		year, err := strconv.Atoi(value)
+		if err != nil {
+			response.BadRequest(c, "invalid_season_year", "Season year must be a number")
+			return
+		}
+		season = year
+	}
+
+	current, err := h.calendar.Current(c.Request.Context(), season)
+	if err != nil {
+		if errors.Is(err, calendar.ErrNoSchedule) {
+			response.NotFound(c, "no_schedule", "No games are scheduled for the season yet")
+			return
+		}
+		h.logger.Error("Failed to resolve current week", "season", season, "error", err)
+		response.InternalServerError(c, "current_week_failed", "Failed to determine the current week")
+		return
+	}
+
+	response.Success(c, current)
+}
+
 // GetByWeek returns games for a specific week
 func (h *GameHandler) GetByWeek(c *gin.Context) {
 	week := c.Param("week")
 	seasonYear := c.Query("season_year")
+	if seasonYear == "" {
+		seasonYear = c.Query("season")
+	}
 
 	if seasonYear == "" {
 		seasonYear = strconv.Itoa(h.config.NFLSeasonYear)
 	}
 
-	c.Set("week", week)
-	c.Set("season_year", seasonYear)
-	h.List(c)
+	h.listGames(c, seasonYear, week, c.Query("status"))
+}
+
+// maxScheduleSize caps an uploaded schedule file; a full season is well
+// under 100KB in either format
+const maxScheduleSize = 1 << 20
+
+// Import loads a season schedule from a CSV or iCalendar file, sent either
+// as the multipart field "file" or as the raw request body. The season
+// defaults to the configured one and the format to the file's extension or
+// content type. Rows that cannot be matched are reported, not rejected.
+func (h *GameHandler) Import(c *gin.Context) {
+	if !requireAdmin(c, h.store, h.config, h.logger) {
+		return
+	}
+
+	season := h.config.NFLSeasonYear
+	if value := c.Query("season_year"); value != "" {
+		year, err := strconv.Atoi(value)
+		if err != nil {
+			response.BadRequest(c, "invalid_season_year", "Season year must be a number")
+			return
+		}
+		season = year
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxScheduleSize)
+	var (
+		body   io.Reader = c.Request.Body
+		format           = schedule.DetectFormat("", c.ContentType())
+	)
+	if c.ContentType() == "multipart/form-data" {
+		header, err := c.FormFile("file")
+		if err != nil {
+			response.BadRequest(c, "file_required", "Upload the schedule as the multipart field \"file\"")
+			return
+		}
+		file, err := header.Open()
+		if err != nil {
+			response.BadRequest(c, "invalid_file", "Failed to read the uploaded file")
+			return
+		}
+		defer file.Close()
+		body = file
+		format = schedule.DetectFormat(header.Filename, header.Header.Get("Content-Type"))
+	}
+	if value := c.Query("format"); value != "" {
+		format = value
+	}
+
+	result, err := h.importer.Import(c.Request.Context(), season, format, body)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.Is(err, schedule.ErrUnknownFormat):
+			response.BadRequest(c, "unknown_format", "Schedule format must be csv or ics; set ?format= or use a .csv or .ics file")
+		case errors.Is(err, schedule.ErrInvalidFile):
+			response.BadRequest(c, "invalid_schedule", err.Error())
+		case errors.Is(err, schedule.ErrEmpty):
+			response.BadRequest(c, "empty_schedule", "Schedule file has no games")
+		case errors.As(err, &tooLarge):
+			response.Error(c, http.StatusRequestEntityTooLarge, "schedule_too_large", "Schedule file is too large")
+		default:
+			h.logger.Error("Failed to import schedule", "season", season, "error", err)
+			response.InternalServerError(c, "import_failed", "Failed to import schedule")
+		}
+		return
+	}
+
+	response.Success(c, result, "Schedule imported")
+}
+
+// Correct overrides a game's score or status when the score feed has it
+// wrong. The change is recorded with who made it and why, the game is no
+// longer updated by the feed, and standings are recomputed if the result
+// changed.
+func (h *GameHandler) Correct(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	gameID, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req models.GameCorrectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid_request", err.Error())
+		return
+	}
+
+	if !requireAdmin(c, h.store, h.config, h.logger) {
+		return
+	}
+
+	correction, err := h.corrections.Correct(c.Request.Context(), gameID, userID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			response.NotFound(c, "game_not_found", "Game not found")
+		case errors.Is(err, corrections.ErrInvalidStatus):
+			response.BadRequest(c, "invalid_status", "Unknown game status")
+		case errors.Is(err, corrections.ErrScoreNotPlayed):
+			response.BadRequest(c, "score_not_played", "A game that has not kicked off cannot have a score")
+		case errors.Is(err, corrections.ErrNoChange):
+			response.BadRequest(c, "no_change", "The correction does not change the game")
+		case errors.Is(err, store.ErrConflict):
+			response.Conflict(c, "game_changed", "The game changed while you were correcting it; reload and try again")
+		default:
+			h.logger.Error("Failed to correct game", "game_id", gameID, "error", err)
+			response.InternalServerError(c, "correction_failed", "Failed to correct game")
+		}
+		return
+	}
+
+	response.Created(c, correction, "Game corrected")
+}
+
+// Corrections returns the audit log of hand corrections to a game
+func (h *GameHandler) Corrections(c *gin.Context) {
+	gameID, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	history, err := h.corrections.History(c.Request.Context(), gameID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			response.NotFound(c, "game_not_found", "Game not found")
+			return
+		}
+		h.logger.Error("Failed to query game corrections", "game_id", gameID, "error", err)
+		response.InternalServerError(c, "query_failed", "Failed to fetch game corrections")
+		return
+	}
+
+	response.Success(c, history)
 }
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"touchdown-tally/internal/corrections"
+	"touchdown-tally/internal/gamestatus"
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/schedule"
+)
+
+// gameFixture routes the game admin handlers. admin@example.com is the only
+// admin account.
+func gameFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	f := newFixture(t)
+	f.config.AdminUsers = []string{" Admin@Example.com"}
+
+	importer := schedule.NewImporter(f.store, nil, f.logger)
+	corrector := corrections.NewService(f.store, gamestatus.NewBus(), f.logger)
+	h := NewGameHandler(f.store, nil, importer, corrector, nil, f.config, f.logger)
+	f.router.POST("/games/import", h.Import)
+	f.router.POST("/games/:id/corrections", h.Correct)
+	return f
+}
+
+func TestImportRequiresAdmin(t *testing.T) {
+	f := gameFixture(t)
+	commissioner := f.user(t, "commish")
+	f.pool(t, commissioner, openSettings())
+	admin := f.user(t, "admin")
+
+	// A profile named like an admin under another account is not one
+	impostor, err := f.store.Users.Register(context.Background(), "impostor@example.com", "hash", "admin", "admin")
+	if err != nil {
+		t.Fatalf("register impostor: %v", err)
+	}
+
+	for _, userID := range []int{commissioner, impostor.UserID} {
+		expectError(t, f.do(t, http.MethodPost, "/games/import?format=csv", userID, nil), http.StatusForbidden, "admin_required")
+	}
+
+	// Past the permission check the importer rejects the unknown format
+	expectError(t, f.do(t, http.MethodPost, "/games/import?format=xml", admin, nil), http.StatusBadRequest, "unknown_format")
+}
+
+func TestCorrectRequiresAdmin(t *testing.T) {
+	f := gameFixture(t)
+	commissioner := f.user(t, "commish")
+	f.pool(t, commissioner, openSettings())
+	admin := f.user(t, "admin")
+	f.team(1, "BUF")
+	f.team(2, "MIA")
+	gameID := f.db.AddGame(models.NFLGame{SeasonYear: 2025, Week: 1, HomeTeamID: 1, AwayTeamID: 2,
+		HomeScore: 20, AwayScore: 17, Status: models.GameStatusCompleted})
+
+	path := "/games/" + strconv.Itoa(gameID) + "/corrections"
+	req := models.GameCorrectionRequest{HomeScore: new(int), Reason: "Scored the wrong way round"}
+	*req.HomeScore = 17
+	expectError(t, f.do(t, http.MethodPost, path, commissioner, req), http.StatusForbidden, "admin_required")
+
+	var correction models.GameCorrection
+	expectStatus(t, f.do(t, http.MethodPost, path, admin, req), http.StatusCreated, &correction)
+	if correction.CorrectedBy != admin || correction.Status != models.GameStatusCorrected {
+		t.Fatalf("correction = %+v", correction)
+	}
+}
@@ -1,10 +1,29 @@
 package handlers
 
 import (
-	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
 
+	"touchdown-tally/internal/calendar"
 	"touchdown-tally/internal/config"
+	"touchdown-tally/internal/corrections"
+	"touchdown-tally/internal/database"
+	"touchdown-tally/internal/draft"
+	"touchdown-tally/internal/gamestatus"
+	"touchdown-tally/internal/pickem"
+	"touchdown-tally/internal/picklock"
+	"touchdown-tally/internal/schedule"
+	"touchdown-tally/internal/scores"
+	"touchdown-tally/internal/standings"
+	"touchdown-tally/internal/store"
+	"touchdown-tally/internal/survivor"
+	"touchdown-tally/internal/tiebreak"
 	"touchdown-tally/pkg/logger"
+	"touchdown-tally/pkg/response"
+
+	"github.com/gin-gonic/gin"
 )
 
 // Handlers aggregates all handler groups
@@ -16,17 +35,144 @@ type Handlers struct {
 	Teams     *TeamHandler
 	Standings *StandingHandler
 	Chat      *ChatHandler
+	Drafts    *DraftHandler
+
+	WeeklyPicks *WeeklyPickHandler
+	Survivor    *SurvivorHandler
+	Tiebreakers *TiebreakerHandler
 }
 
 // New creates a new Handlers instance with all handler groups
-func New(db *sql.DB, cfg *config.Config, logger *logger.Logger) *Handlers {
+func New(db *database.DB, cfg *config.Config, logger *logger.Logger) *Handlers {
+	st := store.New(db)
+	engine := standings.NewEngine(st)
+	cal := calendar.New(st)
+	chat := NewChatHandler(st, cfg, logger)
+	drafts := draft.NewService(st, chat, logger)
+	locks := picklock.NewChecker(cal)
+	weekly := pickem.NewService(st, locks, logger)
+	survivors := survivor.NewService(st, logger)
+	tiebreakers := tiebreak.NewService(st, locks, logger)
+
+	events := gamestatus.NewBus()
+	events.Subscribe(gamestatus.RecomputeStandings(st, engine, logger))
+	events.Subscribe(gamestatus.Announce(st, chat, logger))
+	events.SubscribeUpdates(gamestatus.PushGameUpdates(st, engine, chat, logger))
+
+	importer := schedule.NewImporter(st, events, logger)
+	corrector := corrections.NewService(st, events, logger)
+
+	var syncer *scores.Syncer
+	if provider, err := scores.New(cfg); err != nil {
+		logger.Warn("Score provider unavailable", "error", err)
+	} else {
+		interval := time.Duration(cfg.ScoreUpdateInterval) * time.Second
+		syncer = scores.NewSyncer(provider, st, events, logger, cfg.NFLSeasonYear, interval)
+	}
+
 	return &Handlers{
-		Auth:      NewAuthHandler(db, cfg, logger),
-		Pools:     NewPoolHandler(db, cfg, logger),
-		Picks:     NewPickHandler(db, cfg, logger),
-		Games:     NewGameHandler(db, cfg, logger),
-		Teams:     NewTeamHandler(db, cfg, logger),
-		Standings: NewStandingHandler(db, cfg, logger),
-		Chat:      NewChatHandler(db, cfg, logger),
+		Auth:      NewAuthHandler(st, cfg, logger),
+		Pools:     NewPoolHandler(st, locks, cfg, logger),
+		Picks:     NewPickHandler(st, locks, cfg, logger),
+		Games:     NewGameHandler(st, syncer, importer, corrector, cal, cfg, logger),
+		Teams:     NewTeamHandler(st, cfg, logger),
+		Standings: NewStandingHandler(st, engine, cal, cfg, logger),
+		Chat:      chat,
+		Drafts:    NewDraftHandler(st, drafts, cfg, logger),
+
+		WeeklyPicks: NewWeeklyPickHandler(st, weekly, cal, cfg, logger),
+		Survivor:    NewSurvivorHandler(st, survivors, cal, cfg, logger),
+		Tiebreakers: NewTiebreakerHandler(st, tiebreakers, cal, cfg, logger),
+	}
+}
+
+// currentUserID returns the authenticated user set by middleware.RequireAuth.
+// It writes a 401 response and returns false when the context has no user.
+func currentUserID(c *gin.Context) (int, bool) {
+	userID, ok := c.Get("user_id")
+	if !ok {
+		response.Unauthorized(c, "authentication_required", "User must be authenticated")
+		return 0, false
+	}
+
+	id, ok := userID.(int)
+	if !ok {
+		response.Unauthorized(c, "invalid_user_context", "Invalid user context")
+		return 0, false
+	}
+	return id, true
+}
+
+// idParam parses a numeric path parameter, writing a 400 response on failure
+func idParam(c *gin.Context, name string) (int, bool) {
+	id, err := strconv.Atoi(c.Param(name))
+	if err != nil || id <= 0 {
+		response.BadRequest(c, "invalid_"+name, "Invalid "+name)
+		return 0, false
+	}
+	return id, true
+}
+
+// weekParam reads a week number from raw, where "current" means the week
+// now in play for season. It writes a 400 response for an invalid week, a
+// 404 when the season has no games yet and a 500 on lookup failure.
+func weekParam(c *gin.Context, cal *calendar.Calendar, log *logger.Logger, raw string, season int) (int, bool) {
+	if raw != "current" {
+		week, err := strconv.Atoi(raw)
+		if err != nil || week < 1 {
+			response.BadRequest(c, "invalid_week", "Week must be a positive number or current")
+			return 0, false
+		}
+		return week, true
+	}
+
+	week, err := cal.CurrentWeek(c.Request.Context(), season)
+	if err != nil {
+		if errors.Is(err, calendar.ErrNoSchedule) {
+			response.NotFound(c, "no_schedule", "No games are scheduled for the season yet")
+			return 0, false
+		}
+		log.Error("Failed to resolve current week", "season", season, "error", err)
+		response.InternalServerError(c, "current_week_failed", "Failed to determine the current week")
+		return 0, false
 	}
+	return week, true
+}
+
+// requireMembership returns the user's role in the pool. It writes a 403
+// response when the user is not a member and a 500 on lookup failure.
+func requireMembership(c *gin.Context, st *store.Store, log *logger.Logger, poolID, userID int) (string, bool) {
+	role, err := st.Pools.MemberRole(c.Request.Context(), poolID, userID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			response.Forbidden(c, "not_pool_member", "You are not a member of this pool")
+			return "", false
+		}
+		log.Error("Failed to check pool membership", "pool_id", poolID, "user_id", userID, "error", err)
+		response.InternalServerError(c, "membership_check_failed", "Failed to verify pool membership")
+		return "", false
+	}
+	return role, true
+}
+
+// requireAdmin allows changes only the accounts in ADMIN_USERS may make, such
+// as to the shared NFL schedule and scores. It writes a 403 response
+// otherwise and a 500 on lookup failure.
+func requireAdmin(c *gin.Context, st *store.Store, cfg *config.Config, log *logger.Logger) bool {
+	if emailID := c.GetInt("email_id"); emailID != 0 && len(cfg.AdminUsers) > 0 {
+		account, err := st.Users.GetAccount(c.Request.Context(), emailID)
+		if err != nil && !errors.Is(err, store.ErrNotFound) {
+			log.Error("Failed to check admin account", "email_id", emailID, "error", err)
+			response.InternalServerError(c, "role_check_failed", "Failed to verify permissions")
+			return false
+		}
+		for _, email := range cfg.AdminUsers {
+			if account != nil && strings.EqualFold(strings.TrimSpace(email), account.EmailAddress) {
+				return true
+			}
+		}
+	}
+
+	response.Forbidden(c, "admin_required", "Only admins can change NFL games")
+	return false
 }
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"touchdown-tally/internal/config"
+	"touchdown-tally/internal/database"
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
+	"touchdown-tally/internal/store/memstore"
+	"touchdown-tally/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// testUserHeader carries the caller's user ID into the fixture router, in
+// place of the JWT that middleware.RequireAuth would verify. The router sets
+// the profile's email_id as the middleware would.
+const testUserHeader = "X-Test-User"
+
+// fixture runs handlers against an in-memory store
+type fixture struct {
+	db     *memstore.DB
+	store  *store.Store
+	config *config.Config
+	logger *logger.Logger
+	router *gin.Engine
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	db := memstore.New()
+	f := &fixture{
+		db:     db,
+		store:  db.Store(),
+		config: &config.Config{},
+		logger: &logger.Logger{Logger: log.New(io.Discard, "", 0)},
+		router: gin.New(),
+	}
+	f.router.Use(func(c *gin.Context) {
+		if raw := c.GetHeader(testUserHeader); raw != "" {
+			id, err := strconv.Atoi(raw)
+			if err != nil {
+				t.Fatalf("bad %s header %q", testUserHeader, raw)
+			}
+			c.Set("user_id", id)
+			if profile, err := f.store.Users.GetProfile(c.Request.Context(), id); err == nil {
+				c.Set("email_id", profile.EmailID)
+			}
+		}
+		c.Next()
+	})
+	return f
+}
+
+// openSQLite returns a migrated SQLite database for handlers whose
+// repositories the in-memory store does not model
+func openSQLite(t *testing.T) *database.DB {
+	t.Helper()
+
+	db, err := database.Connect("sqlite://" + filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("connect to sqlite: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := database.Migrate(db); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return db
+}
+
+// user registers a profile and returns its user ID
+func (f *fixture) user(t *testing.T, name string) int {
+	t.Helper()
+
+	profile, err := f.store.Users.Register(context.Background(), name+"@example.com", "hash", name, name)
+	if err != nil {
+		t.Fatalf("register %s: %v", name, err)
+	}
+	return profile.UserID
+}
+
+// pool creates an active season pool run by commissionerID, adding members
+func (f *fixture) pool(t *testing.T, commissionerID int, settings models.PoolSettings, members ...int) int {
+	t.Helper()
+
+	ctx := context.Background()
+	poolID, err := f.store.Pools.Create(ctx, store.NewPool{
+		Name:           "Test Pool",
+		CommissionerID: commissionerID,
+		SeasonYear:     2025,
+		MaxMembers:     10,
+		PoolType:       models.PoolTypeSeason,
+		Settings:       settings,
+	})
+	if err != nil {
+		t.Fatalf("create pool: %v", err)
+	}
+	for _, userID := range members {
+		if err := f.store.Pools.AddMember(ctx, poolID, userID, models.RoleMember); err != nil {
+			t.Fatalf("add member %d: %v", userID, err)
+		}
+	}
+	return poolID
+}
+
+// team loads an NFL team with the given ID
+func (f *fixture) team(teamID int, abbreviation string) {
+	f.db.AddTeam(models.NFLTeam{
+		TeamID:           teamID,
+		TeamName:         abbreviation,
+		TeamAbbreviation: abbreviation,
+		Conference:       "AFC",
+		Division:         "East",
+	})
+}
+
+// do sends a request as userID, or anonymously when userID is 0. A non-nil
+// body is sent as JSON.
+func (f *fixture) do(t *testing.T, method, path string, userID int, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("marshal body: %v", err)
+		}
+		reader = bytes.NewReader(raw)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if userID != 0 {
+		req.Header.Set(testUserHeader, strconv.Itoa(userID))
+	}
+
+	rec := httptest.NewRecorder()
+	f.router.ServeHTTP(rec, req)
+	return rec
+}
+
+// expectStatus fails the test unless rec has the status, and decodes a
+// success envelope's data into data when it is non-nil
+func expectStatus(t *testing.T, rec *httptest.ResponseRecorder, status int, data interface{}) {
+	t.Helper()
+
+	if rec.Code != status {
+		t.Fatalf("status = %d, want %d; body %s", rec.Code, status, rec.Body.String())
+	}
+	if data == nil {
+		return
+	}
+
+	envelope := struct {
+		Data json.RawMessage `json:"data"`
+	}{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if err := json.Unmarshal(envelope.Data, data); err != nil {
+		t.Fatalf("decode data: %v", err)
+	}
+}
+
+// expectError fails the test unless rec is an error response with status
+// and code
+func expectError(t *testing.T, rec *httptest.ResponseRecorder, status int, code string) {
+	t.Helper()
+
+	expectStatus(t, rec, status, nil)
+	var body models.ErrorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode error response: %v", err)
+	}
+	if body.Error != code {
+		t.Fatalf("error = %q, want %q", body.Error, code)
+	}
+}
+
+// openSettings returns pool settings whose picks never lock
+func openSettings() models.PoolSettings {
+	settings := models.DefaultPoolSettings()
+	settings.Locks = models.LockSettings{Policy: models.LockPolicyNone}
+	return settings
+}
+
+// lockedSettings returns pool settings whose picks locked an hour ago
+func lockedSettings() models.PoolSettings {
+	deadline := time.Now().UTC().Add(-time.Hour)
+	settings := models.DefaultPoolSettings()
+	settings.Locks = models.LockSettings{Policy: models.LockPolicyDeadline, Deadline: &deadline}
+	return settings
+}
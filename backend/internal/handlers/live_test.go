@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"touchdown-tally/internal/config"
+	"touchdown-tally/internal/gamestatus"
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/scores"
+	"touchdown-tally/internal/store"
+	"touchdown-tally/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// TestScoreSyncReachesFollower follows a score change from the provider
+// feed through the sync, the game status bus and the chat hubs to the
+// pool-less socket the web client opens at /ws
+func TestScoreSyncReachesFollower(t *testing.T) {
+	ctx := context.Background()
+	db := openSQLite(t)
+
+	feed := filepath.Join(t.TempDir(), "scores.json")
+	kickoff := time.Now().UTC().Add(-time.Hour)
+	writeFeed := func(game scores.Game) {
+		t.Helper()
+		raw, err := json.Marshal(map[string][]scores.Game{"games": {game}})
+		if err != nil {
+			t.Fatalf("marshal feed: %v", err)
+		}
+		if err := os.WriteFile(feed, raw, 0o644); err != nil {
+			t.Fatalf("write feed: %v", err)
+		}
+	}
+	game := scores.Game{
+		ExternalID: "live-1",
+		SeasonYear: 2025,
+		Week:       1,
+		HomeTeam:   "BUF",
+		AwayTeam:   "MIA",
+		Kickoff:    kickoff,
+		Status:     models.GameStatusScheduled,
+	}
+	writeFeed(game)
+
+	cfg := &config.Config{
+		ScoreProvider:       scores.ProviderFixture,
+		ScoreFixturePath:    feed,
+		NFLSeasonYear:       2025,
+		ScoreUpdateInterval: 30,
+	}
+	quiet := &logger.Logger{Logger: log.New(io.Discard, "", 0)}
+	h := New(db, cfg, quiet)
+	t.Cleanup(h.Chat.Close)
+
+	// The first sync loads the schedule
+	if _, err := h.Games.syncer.Sync(ctx); err != nil {
+		t.Fatalf("schedule sync: %v", err)
+	}
+
+	st := store.New(db)
+	profile, err := st.Users.Register(ctx, "fan@example.com", "hash", "fan", "fan")
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	poolID, err := st.Pools.Create(ctx, store.NewPool{
+		Name:           "Office League",
+		CommissionerID: profile.UserID,
+		SeasonYear:     2025,
+		MaxMembers:     10,
+		PoolType:       models.PoolTypeSeason,
+		Settings:       openSettings(),
+	})
+	if err != nil {
+		t.Fatalf("create pool: %v", err)
+	}
+	teams, err := st.Teams.List(ctx, store.TeamFilter{})
+	if err != nil {
+		t.Fatalf("list teams: %v", err)
+	}
+	for _, team := range teams {
+		if team.TeamAbbreviation == "BUF" {
+			if _, err := st.Picks.Create(ctx, poolID, profile.UserID, team.TeamID, 1, nil); err != nil {
+				t.Fatalf("create pick: %v", err)
+			}
+		}
+	}
+
+	router := gin.New()
+	router.GET("/ws", func(c *gin.Context) {
+		c.Set("user_id", profile.UserID)
+		h.Chat.WebSocketHandler(c)
+	})
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws?token=test", nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+
+	next := func() models.Event {
+		t.Helper()
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		var event models.Event
+		if err := conn.ReadJSON(&event); err != nil {
+			t.Fatalf("read event: %v", err)
+		}
+		return event
+	}
+
+	// The handshake completes before the socket joins the pool's hub, so
+	// publish until the socket hears something
+	ready := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ready:
+				return
+			case <-time.After(10 * time.Millisecond):
+				h.Chat.Broadcast(poolID, "ping", nil)
+			}
+		}
+	}()
+	if event := next(); event.Type != "ping" || event.PoolID != poolID {
+		close(ready)
+		t.Fatalf("first event = %+v", event)
+	}
+	close(ready)
+
+	game.Status = models.GameStatusInProgress
+	game.HomeScore = 7
+	game.Quarter = 1
+	game.TimeRemaining = "10:12"
+	writeFeed(game)
+	if _, err := h.Games.syncer.Sync(ctx); err != nil {
+		t.Fatalf("live sync: %v", err)
+	}
+
+	var sawGame, sawStandings bool
+	for !sawGame || !sawStandings {
+		event := next()
+		raw, err := json.Marshal(event.Data)
+		if err != nil {
+			t.Fatalf("marshal event data: %v", err)
+		}
+		switch event.Type {
+		case gamestatus.EventGameUpdate:
+			var update models.GameUpdate
+			if err := json.Unmarshal(raw, &update); err != nil {
+				t.Fatalf("decode game update: %v", err)
+			}
+			if update.HomeScore != 7 || update.Status != models.GameStatusInProgress || update.TimeRemaining != "10:12" {
+				t.Fatalf("game update = %+v", update)
+			}
+			sawGame = true
+		case gamestatus.EventStandingsUpdate:
+			var update models.StandingsUpdate
+			if err := json.Unmarshal(raw, &update); err != nil {
+				t.Fatalf("decode standings update: %v", err)
+			}
+			if update.PoolID != poolID || event.PoolID != poolID || len(update.Games) != 1 || len(update.Standings) != 1 {
+				t.Fatalf("standings update = %+v", update)
+			}
+			sawStandings = true
+		}
+	}
+}
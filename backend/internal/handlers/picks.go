@@ -1,10 +1,15 @@
 package handlers
 
 import (
-	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
 
 	"touchdown-tally/internal/config"
 	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/picklock"
+	"touchdown-tally/internal/store"
 	"touchdown-tally/pkg/logger"
 	"touchdown-tally/pkg/response"
 
@@ -13,15 +18,17 @@ import (
 
 // PickHandler handles pick-related requests
 type PickHandler struct {
-	db     *sql.DB
+	store  *store.Store
+	locks  *picklock.Checker
 	config *config.Config
 	logger *logger.Logger
 }
 
 // NewPickHandler creates a new PickHandler
-func NewPickHandler(db *sql.DB, cfg *config.Config, logger *logger.Logger) *PickHandler {
+func NewPickHandler(st *store.Store, locks *picklock.Checker, cfg *config.Config, logger *logger.Logger) *PickHandler {
 	return &PickHandler{
-		db:     db,
+		store:  st,
+		locks:  locks,
 		config: cfg,
 		logger: logger,
 	}
@@ -29,67 +36,109 @@ func NewPickHandler(db *sql.DB, cfg *config.Config, logger *logger.Logger) *Pick
 
 // GetByPool returns all picks for a specific pool
 func (h *PickHandler) GetByPool(c *gin.Context) {
-	poolID := c.Param("pool_id")
-	userID, _ := c.Get("user_id")
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	poolID, ok := idParam(c, "pool_id")
+	if !ok {
+		return
+	}
 
 	// Verify user is a member of the pool
-	var membershipExists bool
-	err := h.db.QueryRow(
-		"SELECT EXISTS(SELECT 1 FROM pool_memberships WHERE pool_id = $1 AND user_id = $2)",
-		poolID, userID,
-	).Scan(&membershipExists)
+	if _, ok := requireMembership(c, h.store, h.logger, poolID, userID); !ok {
+		return
+	}
 
+	picks, err := h.store.Picks.ListByPool(c.Request.Context(), poolID)
 	if err != nil {
-		h.logger.Error("Failed to check pool membership", "error", err)
-		response.InternalServerError(c, "membership_check_failed", "Failed to verify pool membership")
+		h.logger.Error("Failed to query picks", "error", err)
+		response.InternalServerError(c, "query_failed", "Failed to fetch picks")
 		return
 	}
 
-	if !membershipExists {
-		response.Forbidden(c, "not_pool_member", "You are not a member of this pool")
+	response.Success(c, picks)
+}
+
+// List returns the current user's picks, optionally filtered by pool_id
+func (h *PickHandler) List(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
 		return
 	}
 
-	rows, err := h.db.Query(`
-		SELECT p.pick_id, p.pool_id, p.user_id, p.team_id, p.pick_order,
-		       p.points_scored, p.is_eliminated, p.elimination_week, p.created_at, p.updated_at,
-		       t.team_name, t.team_abbreviation, t.city, t.conference, t.division,
-		       t.logo_url, t.primary_color, t.secondary_color,
-		       u.username, u.display_name
-		FROM season_picks p
-		JOIN nfl_teams t ON p.team_id = t.team_id
-		JOIN user_profiles u ON p.user_id = u.user_id
-		WHERE p.pool_id = $1
-		ORDER BY u.display_name, p.pick_order`,
-		poolID,
-	)
+	var poolID *int
+	if raw := c.Query("pool_id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil {
+			response.BadRequest(c, "invalid_pool_id", "Pool ID must be a number")
+			return
+		}
+		poolID = &id
+	}
 
+	picks, err := h.store.Picks.ListByUser(c.Request.Context(), userID, poolID)
 	if err != nil {
 		h.logger.Error("Failed to query picks", "error", err)
 		response.InternalServerError(c, "query_failed", "Failed to fetch picks")
 		return
 	}
-	defer rows.Close()
-
-	var picks []models.PickWithTeam
-	for rows.Next() {
-		var pick models.PickWithTeam
-		var username, displayName string
-		err := rows.Scan(
-			&pick.PickID, &pick.PoolID, &pick.UserID, &pick.TeamID, &pick.PickOrder,
-			&pick.PointsScored, &pick.IsEliminated, &pick.EliminationWeek, &pick.CreatedAt, &pick.UpdatedAt,
-			&pick.Team.TeamName, &pick.Team.TeamAbbreviation, &pick.Team.City,
-			&pick.Team.Conference, &pick.Team.Division, &pick.Team.LogoURL,
-			&pick.Team.PrimaryColor, &pick.Team.SecondaryColor,
-			&username, &displayName,
-		)
-		if err != nil {
-			h.logger.Error("Failed to scan pick", "error", err)
-			continue
+
+	response.Success(c, picks)
+}
+
+// ByUser returns a member's picks in the pools the caller shares with them
+func (h *PickHandler) ByUser(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	memberID, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	picks, err := h.store.Picks.ListShared(c.Request.Context(), userID, store.PickFilter{UserID: &memberID})
+	if err != nil {
+		h.logger.Error("Failed to query picks", "user_id", memberID, "error", err)
+		response.InternalServerError(c, "query_failed", "Failed to fetch picks")
+		return
+	}
+
+	response.Success(c, picks)
+}
+
+// ByGame returns the picks of either team in a game, across the caller's pools
+func (h *PickHandler) ByGame(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	gameID, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	game, err := h.store.Games.Get(c.Request.Context(), gameID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			response.NotFound(c, "game_not_found", "Game not found")
+			return
 		}
+		h.logger.Error("Failed to query game", "error", err)
+		response.InternalServerError(c, "query_failed", "Failed to fetch game")
+		return
+	}
 
-		pick.Team.TeamID = pick.TeamID
-		picks = append(picks, pick)
+	filter := store.PickFilter{TeamIDs: []int{game.HomeTeamID, game.AwayTeamID}}
+	picks, err := h.store.Picks.ListShared(c.Request.Context(), userID, filter)
+	if err != nil {
+		h.logger.Error("Failed to query picks", "game_id", gameID, "error", err)
+		response.InternalServerError(c, "query_failed", "Failed to fetch picks")
+		return
 	}
 
 	response.Success(c, picks)
@@ -103,33 +152,38 @@ func (h *PickHandler) Create(c *gin.Context) {
 		return
 	}
 
-	userID, _ := c.Get("user_id")
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
 
 	// Verify user is a member of the pool
-	var membershipExists bool
-	err := h.db.QueryRow(
-		"SELECT EXISTS(SELECT 1 FROM pool_memberships WHERE pool_id = $1 AND user_id = $2)",
-		req.PoolID, userID,
-	).Scan(&membershipExists)
+	role, ok := requireMembership(c, h.store, h.logger, req.PoolID, userID)
+	if !ok {
+		return
+	}
 
-	if err != nil {
-		h.logger.Error("Failed to check pool membership", "error", err)
-		response.InternalServerError(c, "membership_check_failed", "Failed to verify pool membership")
+	override, ok := h.lockOverride(c, req.PoolID, userID, role, req.Note)
+	if !ok {
+		return
+	}
+	if override == nil && h.drafted(c, req.PoolID) {
 		return
 	}
 
-	if !membershipExists {
-		response.Forbidden(c, "not_pool_member", "You are not a member of this pool")
+	ownerID, ok := h.pickOwner(c, req, userID, override)
+	if !ok {
 		return
 	}
+	if override != nil {
+		override.UserID = ownerID
+		override.Action = models.PickActionCreate
+		override.TeamID = &req.TeamID
+		override.PickOrder = &req.PickOrder
+	}
 
 	// Check if team is already picked in this pool
-	var teamTaken bool
-	err = h.db.QueryRow(
-		"SELECT EXISTS(SELECT 1 FROM season_picks WHERE pool_id = $1 AND team_id = $2)",
-		req.PoolID, req.TeamID,
-	).Scan(&teamTaken)
-
+	teamTaken, err := h.store.Picks.TeamTaken(c.Request.Context(), req.PoolID, req.TeamID, 0)
 	if err != nil {
 		h.logger.Error("Failed to check team availability", "error", err)
 		response.InternalServerError(c, "team_check_failed", "Failed to verify team availability")
@@ -142,73 +196,58 @@ func (h *PickHandler) Create(c *gin.Context) {
 	}
 
 	// Check if user already has a pick for this order
-	var existingPickID int
-	err = h.db.QueryRow(
-		"SELECT pick_id FROM season_picks WHERE pool_id = $1 AND user_id = $2 AND pick_order = $3",
-		req.PoolID, userID, req.PickOrder,
-	).Scan(&existingPickID)
-
-	if err != nil && err != sql.ErrNoRows {
+	orderTaken, err := h.store.Picks.OrderTaken(c.Request.Context(), req.PoolID, ownerID, req.PickOrder)
+	if err != nil {
 		h.logger.Error("Failed to check existing pick", "error", err)
 		response.InternalServerError(c, "pick_check_failed", "Failed to check existing picks")
 		return
 	}
 
-	if existingPickID > 0 {
-		response.Conflict(c, "pick_order_taken", "You already have a pick for this order")
+	if orderTaken {
+		message := "You already have a pick for this order"
+		if ownerID != userID {
+			message = "The member already has a pick for this order"
+		}
+		response.Conflict(c, "pick_order_taken", message)
 		return
 	}
 
 	// Create the pick
-	var pickID int
-	err = h.db.QueryRow(`
-		INSERT INTO season_picks (pool_id, user_id, team_id, pick_order)
-		VALUES ($1, $2, $3, $4)
-		RETURNING pick_id`,
-		req.PoolID, userID, req.TeamID, req.PickOrder,
-	).Scan(&pickID)
-
+	pickID, err := h.store.Picks.Create(c.Request.Context(), req.PoolID, ownerID, req.TeamID, req.PickOrder, override)
 	if err != nil {
+		if errors.Is(err, store.ErrConflict) {
+			response.Conflict(c, "team_already_picked", "This team has already been picked by another user")
+			return
+		}
 		h.logger.Error("Failed to create pick", "error", err)
 		response.InternalServerError(c, "pick_creation_failed", "Failed to create pick")
 		return
 	}
 
 	// Fetch the created pick with team details
-	var pick models.PickWithTeam
-	err = h.db.QueryRow(`
-		SELECT p.pick_id, p.pool_id, p.user_id, p.team_id, p.pick_order,
-		       p.points_scored, p.is_eliminated, p.elimination_week, p.created_at, p.updated_at,
-		       t.team_name, t.team_abbreviation, t.city, t.conference, t.division,
-		       t.logo_url, t.primary_color, t.secondary_color
-		FROM season_picks p
-		JOIN nfl_teams t ON p.team_id = t.team_id
-		WHERE p.pick_id = $1`,
-		pickID,
-	).Scan(
-		&pick.PickID, &pick.PoolID, &pick.UserID, &pick.TeamID, &pick.PickOrder,
-		&pick.PointsScored, &pick.IsEliminated, &pick.EliminationWeek, &pick.CreatedAt, &pick.UpdatedAt,
-		&pick.Team.TeamName, &pick.Team.TeamAbbreviation, &pick.Team.City,
-		&pick.Team.Conference, &pick.Team.Division, &pick.Team.LogoURL,
-		&pick.Team.PrimaryColor, &pick.Team.SecondaryColor,
-	)
-
+	pick, err := h.store.Picks.Get(c.Request.Context(), pickID)
 	if err != nil {
 		h.logger.Error("Failed to fetch created pick", "error", err)
 		response.InternalServerError(c, "pick_fetch_failed", "Pick created but failed to fetch details")
 		return
 	}
 
-	pick.Team.TeamID = pick.TeamID
-
-	h.logger.Info("Pick created successfully", "pick_id", pickID, "user_id", userID, "pool_id", req.PoolID)
+	h.logOverride(override)
+	h.logger.Info("Pick created successfully", "pick_id", pickID, "user_id", ownerID, "pool_id", req.PoolID)
 	response.Created(c, pick, "Pick created successfully")
 }
 
 // Update updates an existing pick
 func (h *PickHandler) Update(c *gin.Context) {
-	pickID := c.Param("id")
-	userID, _ := c.Get("user_id")
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	pickID, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
 
 	var req models.CreatePickRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -216,30 +255,28 @@ func (h *PickHandler) Update(c *gin.Context) {
 		return
 	}
 
-	// Verify user owns this pick
-	var currentPoolID int
-	err := h.db.QueryRow(
-		"SELECT pool_id FROM season_picks WHERE pick_id = $1 AND user_id = $2",
-		pickID, userID,
-	).Scan(&currentPoolID)
+	// Verify user owns this pick, or runs its pool
+	current, role, ok := h.editablePick(c, pickID, userID, "Pick not found or you don't have permission to update it")
+	if !ok {
+		return
+	}
 
-	if err != nil {
-		if err == sql.ErrNoRows {
-			response.NotFound(c, "pick_not_found", "Pick not found or you don't have permission to update it")
-			return
-		}
-		h.logger.Error("Failed to verify pick ownership", "error", err)
-		response.InternalServerError(c, "pick_verification_failed", "Failed to verify pick ownership")
+	override, ok := h.lockOverride(c, current.PoolID, userID, role, req.Note)
+	if !ok {
+		return
+	}
+	if override == nil && h.drafted(c, current.PoolID) {
 		return
 	}
+	if override != nil {
+		override.UserID = current.UserID
+		override.Action = models.PickActionUpdate
+		override.TeamID = &req.TeamID
+		override.PickOrder = &req.PickOrder
+	}
 
 	// Check if new team is available (excluding current pick)
-	var teamTaken bool
-	err = h.db.QueryRow(
-		"SELECT EXISTS(SELECT 1 FROM season_picks WHERE pool_id = $1 AND team_id = $2 AND pick_id != $3)",
-		currentPoolID, req.TeamID, pickID,
-	).Scan(&teamTaken)
-
+	teamTaken, err := h.store.Picks.TeamTaken(c.Request.Context(), current.PoolID, req.TeamID, pickID)
 	if err != nil {
 		h.logger.Error("Failed to check team availability", "error", err)
 		response.InternalServerError(c, "team_check_failed", "Failed to verify team availability")
@@ -251,82 +288,252 @@ func (h *PickHandler) Update(c *gin.Context) {
 		return
 	}
 
-	// Update the pick
-	_, err = h.db.Exec(`
-		UPDATE season_picks 
-		SET team_id = $1, pick_order = $2, updated_at = CURRENT_TIMESTAMP
-		WHERE pick_id = $3`,
-		req.TeamID, req.PickOrder, pickID,
-	)
-
-	if err != nil {
+	if err := h.store.Picks.Update(c.Request.Context(), pickID, req.TeamID, req.PickOrder, override); err != nil {
+		if errors.Is(err, store.ErrConflict) {
+			response.Conflict(c, "pick_order_taken", "You already have a pick for this order")
+			return
+		}
 		h.logger.Error("Failed to update pick", "error", err)
 		response.InternalServerError(c, "pick_update_failed", "Failed to update pick")
 		return
 	}
 
 	// Fetch updated pick
-	var pick models.PickWithTeam
-	err = h.db.QueryRow(`
-		SELECT p.pick_id, p.pool_id, p.user_id, p.team_id, p.pick_order,
-		       p.points_scored, p.is_eliminated, p.elimination_week, p.created_at, p.updated_at,
-		       t.team_name, t.team_abbreviation, t.city, t.conference, t.division,
-		       t.logo_url, t.primary_color, t.secondary_color
-		FROM season_picks p
-		JOIN nfl_teams t ON p.team_id = t.team_id
-		WHERE p.pick_id = $1`,
-		pickID,
-	).Scan(
-		&pick.PickID, &pick.PoolID, &pick.UserID, &pick.TeamID, &pick.PickOrder,
-		&pick.PointsScored, &pick.IsEliminated, &pick.EliminationWeek, &pick.CreatedAt, &pick.UpdatedAt,
-		&pick.Team.TeamName, &pick.Team.TeamAbbreviation, &pick.Team.City,
-		&pick.Team.Conference, &pick.Team.Division, &pick.Team.LogoURL,
-		&pick.Team.PrimaryColor, &pick.Team.SecondaryColor,
-	)
-
+	pick, err := h.store.Picks.Get(c.Request.Context(), pickID)
 	if err != nil {
 		h.logger.Error("Failed to fetch updated pick", "error", err)
 		response.InternalServerError(c, "pick_fetch_failed", "Pick updated but failed to fetch details")
 		return
 	}
 
-	pick.Team.TeamID = pick.TeamID
-
+	h.logOverride(override)
 	h.logger.Info("Pick updated successfully", "pick_id", pickID, "user_id", userID)
 	response.Success(c, pick, "Pick updated successfully")
 }
 
-// Delete deletes a pick
+// Delete deletes a pick. After picks lock the commissioner passes the audit
+// note as the note query parameter.
 func (h *PickHandler) Delete(c *gin.Context) {
-	pickID := c.Param("id")
-	userID, _ := c.Get("user_id")
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
 
-	// Verify user owns this pick
-	var exists bool
-	err := h.db.QueryRow(
-		"SELECT EXISTS(SELECT 1 FROM season_picks WHERE pick_id = $1 AND user_id = $2)",
-		pickID, userID,
-	).Scan(&exists)
+	pickID, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
 
-	if err != nil {
-		h.logger.Error("Failed to verify pick ownership", "error", err)
-		response.InternalServerError(c, "pick_verification_failed", "Failed to verify pick ownership")
+	// Verify user owns this pick, or runs its pool
+	current, role, ok := h.editablePick(c, pickID, userID, "Pick not found or you don't have permission to delete it")
+	if !ok {
 		return
 	}
 
-	if !exists {
-		response.NotFound(c, "pick_not_found", "Pick not found or you don't have permission to delete it")
+	override, ok := h.lockOverride(c, current.PoolID, userID, role, c.Query("note"))
+	if !ok {
+		return
+	}
+	if override == nil && h.drafted(c, current.PoolID) {
 		return
 	}
+	if override != nil {
+		override.UserID = current.UserID
+		override.Action = models.PickActionDelete
+		override.TeamID = &current.TeamID
+		override.PickOrder = &current.PickOrder
+	}
 
-	// Delete the pick
-	_, err = h.db.Exec("DELETE FROM season_picks WHERE pick_id = $1", pickID)
-	if err != nil {
+	if err := h.store.Picks.Delete(c.Request.Context(), pickID, override); err != nil {
 		h.logger.Error("Failed to delete pick", "error", err)
 		response.InternalServerError(c, "pick_deletion_failed", "Failed to delete pick")
 		return
 	}
 
+	h.logOverride(override)
 	h.logger.Info("Pick deleted successfully", "pick_id", pickID, "user_id", userID)
 	response.Success(c, nil, "Pick deleted successfully")
 }
+
+// editablePick loads a pick the user may change: their own, or any pick in
+// a pool they are commissioner of. It responds with notFoundMessage when
+// neither applies, and otherwise returns the user's role in the pick's pool.
+func (h *PickHandler) editablePick(c *gin.Context, pickID, userID int, notFoundMessage string) (*models.PickWithTeam, string, bool) {
+	pick, err := h.store.Picks.Get(c.Request.Context(), pickID)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		h.logger.Error("Failed to verify pick ownership", "error", err)
+		response.InternalServerError(c, "pick_verification_failed", "Failed to verify pick ownership")
+		return nil, "", false
+	}
+	if pick == nil {
+		response.NotFound(c, "pick_not_found", notFoundMessage)
+		return nil, "", false
+	}
+
+	role, err := h.store.Pools.MemberRole(c.Request.Context(), pick.PoolID, userID)
+	if err != nil && !errors.Is(err, store.ErrNotFound) {
+		h.logger.Error("Failed to check pool role", "pool_id", pick.PoolID, "user_id", userID, "error", err)
+		response.InternalServerError(c, "pick_verification_failed", "Failed to verify pick ownership")
+		return nil, "", false
+	}
+
+	if pick.UserID != userID && role != models.RoleCommissioner {
+		response.NotFound(c, "pick_not_found", notFoundMessage)
+		return nil, "", false
+	}
+	return pick, role, true
+}
+
+// pickOwner returns the member a new pick is for. That is the caller unless
+// the request names another member, which only a commissioner override may
+// do. It writes the response when ok is false.
+func (h *PickHandler) pickOwner(c *gin.Context, req models.CreatePickRequest, userID int, override *models.PickOverride) (int, bool) {
+	if req.UserID == 0 || req.UserID == userID {
+		return userID, true
+	}
+
+	if override == nil {
+		response.Forbidden(c, "override_required", "Picks can only be made for another member as a commissioner override after picks lock")
+		return 0, false
+	}
+
+	member, err := h.store.Pools.IsMember(c.Request.Context(), req.PoolID, req.UserID)
+	if err != nil {
+		h.logger.Error("Failed to check pool membership", "pool_id", req.PoolID, "user_id", req.UserID, "error", err)
+		response.InternalServerError(c, "membership_check_failed", "Failed to verify pool membership")
+		return 0, false
+	}
+	if !member {
+		response.BadRequest(c, "invalid_user_id", "The user is not a member of this pool")
+		return 0, false
+	}
+	return req.UserID, true
+}
+
+// lockOverride enforces the pool's pick lock. Before the deadline it returns
+// a nil override. Afterwards only the commissioner may change picks, and only
+// with an audit note; the returned override carries the note and must be
+// completed by the caller and stored with the change. It writes the
+// response when ok is false.
+func (h *PickHandler) lockOverride(c *gin.Context, poolID, userID int, role, note string) (*models.PickOverride, bool) {
+	pool, err := h.store.Pools.Get(c.Request.Context(), poolID)
+	if err != nil {
+		h.logger.Error("Failed to get pool", "pool_id", poolID, "error", err)
+		response.InternalServerError(c, "lock_check_failed", "Failed to check pick deadline")
+		return nil, false
+	}
+
+	status, err := h.locks.SeasonStatus(c.Request.Context(), pool)
+	if err != nil {
+		h.logger.Error("Failed to check pick lock", "pool_id", poolID, "error", err)
+		response.InternalServerError(c, "lock_check_failed", "Failed to check pick deadline")
+		return nil, false
+	}
+	if !status.Locked {
+		return nil, true
+	}
+
+	locked := "Picks for this pool locked at " + status.Deadline.UTC().Format(time.RFC3339)
+	if role != models.RoleCommissioner {
+		response.Forbidden(c, "picks_locked", locked)
+		return nil, false
+	}
+
+	note = strings.TrimSpace(note)
+	if note == "" {
+		response.Forbidden(c, "picks_locked", locked+"; commissioner changes need an audit note")
+		return nil, false
+	}
+
+	return &models.PickOverride{
+		PoolID:         poolID,
+		CommissionerID: userID,
+		Note:           note,
+	}, true
+}
+
+// logOverride notes a stored commissioner override in the server log
+func (h *PickHandler) logOverride(override *models.PickOverride) {
+	if override == nil {
+		return
+	}
+	h.logger.Info("Locked pick overridden by commissioner", "pool_id", override.PoolID, "pick_id", *override.PickID,
+		"user_id", override.UserID, "commissioner_id", override.CommissionerID, "action", override.Action)
+}
+
+// Lock returns when the pool's picks lock and whether they already have
+func (h *PickHandler) Lock(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	poolID, ok := idParam(c, "pool_id")
+	if !ok {
+		return
+	}
+
+	if _, ok := requireMembership(c, h.store, h.logger, poolID, userID); !ok {
+		return
+	}
+
+	pool, err := h.store.Pools.Get(c.Request.Context(), poolID)
+	if err != nil {
+		h.logger.Error("Failed to get pool", "pool_id", poolID, "error", err)
+		response.InternalServerError(c, "query_failed", "Failed to retrieve pool")
+		return
+	}
+
+	status, err := h.locks.SeasonStatus(c.Request.Context(), pool)
+	if err != nil {
+		h.logger.Error("Failed to check pick lock", "pool_id", poolID, "error", err)
+		response.InternalServerError(c, "lock_check_failed", "Failed to check pick deadline")
+		return
+	}
+
+	response.Success(c, status)
+}
+
+// Overrides returns the audit log of commissioner changes made after the
+// pool's picks locked
+func (h *PickHandler) Overrides(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	poolID, ok := idParam(c, "pool_id")
+	if !ok {
+		return
+	}
+
+	if _, ok := requireMembership(c, h.store, h.logger, poolID, userID); !ok {
+		return
+	}
+
+	overrides, err := h.store.Picks.ListOverrides(c.Request.Context(), poolID)
+	if err != nil {
+		h.logger.Error("Failed to query pick overrides", "pool_id", poolID, "error", err)
+		response.InternalServerError(c, "query_failed", "Failed to fetch pick overrides")
+		return
+	}
+
+	response.Success(c, overrides)
+}
+
+// drafted reports whether the pool assigns teams through a draft, in which
+// case picks can only change through a commissioner override once they
+// lock. It writes the response when true.
+func (h *PickHandler) drafted(c *gin.Context, poolID int) bool {
+	_, err := h.store.Drafts.GetByPool(c.Request.Context(), poolID)
+	if errors.Is(err, store.ErrNotFound) {
+		return false
+	}
+	if err != nil {
+		h.logger.Error("Failed to check pool draft", "pool_id", poolID, "error", err)
+		response.InternalServerError(c, "draft_check_failed", "Failed to check pool draft")
+		return true
+	}
+	response.Conflict(c, "draft_mode", "Teams in this pool are assigned by draft")
+	return true
+}
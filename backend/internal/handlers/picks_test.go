@@ -0,0 +1,283 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"touchdown-tally/internal/calendar"
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/picklock"
+)
+
+// pickFixture routes the pick handlers and loads two teams, 1 and 2
+func pickFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	f := newFixture(t)
+	f.team(1, "BUF")
+	f.team(2, "MIA")
+
+	h := NewPickHandler(f.store, picklock.NewChecker(calendar.New(f.store)), f.config, f.logger)
+	f.router.POST("/picks", h.Create)
+	f.router.PUT("/picks/:id", h.Update)
+	f.router.DELETE("/picks/:id", h.Delete)
+	f.router.GET("/picks/pool/:pool_id", h.GetByPool)
+	f.router.GET("/picks/pool/:pool_id/overrides", h.Overrides)
+	f.router.GET("/picks/user/:id", h.ByUser)
+	f.router.GET("/picks/game/:id", h.ByGame)
+	return f
+}
+
+func TestCreatePickRequiresMembership(t *testing.T) {
+	f := pickFixture(t)
+	commissioner := f.user(t, "commish")
+	outsider := f.user(t, "outsider")
+	poolID := f.pool(t, commissioner, openSettings())
+
+	rec := f.do(t, http.MethodPost, "/picks", outsider, models.CreatePickRequest{PoolID: poolID, TeamID: 1, PickOrder: 1})
+	expectError(t, rec, http.StatusForbidden, "not_pool_member")
+}
+
+func TestCreatePick(t *testing.T) {
+	f := pickFixture(t)
+	commissioner := f.user(t, "commish")
+	member := f.user(t, "member")
+	poolID := f.pool(t, commissioner, openSettings(), member)
+
+	var pick models.PickWithTeam
+	rec := f.do(t, http.MethodPost, "/picks", member, models.CreatePickRequest{PoolID: poolID, TeamID: 1, PickOrder: 1})
+	expectStatus(t, rec, http.StatusCreated, &pick)
+	if pick.UserID != member || pick.TeamID != 1 || pick.Team.TeamAbbreviation != "BUF" {
+		t.Fatalf("created pick = %+v", pick)
+	}
+
+	rec = f.do(t, http.MethodPost, "/picks", commissioner, models.CreatePickRequest{PoolID: poolID, TeamID: 1, PickOrder: 1})
+	expectError(t, rec, http.StatusConflict, "team_already_picked")
+
+	rec = f.do(t, http.MethodPost, "/picks", member, models.CreatePickRequest{PoolID: poolID, TeamID: 2, PickOrder: 1})
+	expectError(t, rec, http.StatusConflict, "pick_order_taken")
+
+	var picks []models.PickWithTeam
+	expectStatus(t, f.do(t, http.MethodGet, "/picks/pool/"+strconv.Itoa(poolID), commissioner, nil), http.StatusOK, &picks)
+	if len(picks) != 1 || picks[0].PickID != pick.PickID {
+		t.Fatalf("pool picks = %+v", picks)
+	}
+}
+
+func TestCreatePickInDraftPool(t *testing.T) {
+	f := pickFixture(t)
+	commissioner := f.user(t, "commish")
+	poolID := f.pool(t, commissioner, openSettings())
+
+	if _, err := f.store.Drafts.Create(context.Background(), models.Draft{PoolID: poolID, Rounds: 4}); err != nil {
+		t.Fatalf("create draft: %v", err)
+	}
+
+	rec := f.do(t, http.MethodPost, "/picks", commissioner, models.CreatePickRequest{PoolID: poolID, TeamID: 1, PickOrder: 1})
+	expectError(t, rec, http.StatusConflict, "draft_mode")
+}
+
+func TestOverrideInDraftPool(t *testing.T) {
+	f := pickFixture(t)
+	commissioner := f.user(t, "commish")
+	member := f.user(t, "member")
+	poolID := f.pool(t, commissioner, lockedSettings(), member)
+
+	if _, err := f.store.Drafts.Create(context.Background(), models.Draft{PoolID: poolID, Rounds: 4}); err != nil {
+		t.Fatalf("create draft: %v", err)
+	}
+
+	req := models.CreatePickRequest{PoolID: poolID, TeamID: 1, PickOrder: 1, UserID: member}
+	expectError(t, f.do(t, http.MethodPost, "/picks", member, req), http.StatusForbidden, "picks_locked")
+	expectError(t, f.do(t, http.MethodPost, "/picks", commissioner, req), http.StatusForbidden, "picks_locked")
+
+	// The draft does not stand in the way of an audited override
+	req.Note = "Draft room crashed before the last pick"
+	var pick models.PickWithTeam
+	expectStatus(t, f.do(t, http.MethodPost, "/picks", commissioner, req), http.StatusCreated, &pick)
+	if pick.UserID != member {
+		t.Fatalf("override pick = %+v", pick)
+	}
+
+	path := "/picks/" + strconv.Itoa(pick.PickID)
+	update := models.CreatePickRequest{PoolID: poolID, TeamID: 2, PickOrder: 1}
+	expectError(t, f.do(t, http.MethodPut, path, member, update), http.StatusForbidden, "picks_locked")
+	update.Note = "Traded with another member"
+	expectStatus(t, f.do(t, http.MethodPut, path, commissioner, update), http.StatusOK, nil)
+	expectStatus(t, f.do(t, http.MethodDelete, path+"?note=Left+the+league", commissioner, nil), http.StatusOK, nil)
+
+	var overrides []models.PickOverride
+	expectStatus(t, f.do(t, http.MethodGet, "/picks/pool/"+strconv.Itoa(poolID)+"/overrides", member, nil), http.StatusOK, &overrides)
+	if len(overrides) != 3 {
+		t.Fatalf("got %d overrides, want 3", len(overrides))
+	}
+}
+
+func TestCreatePickAfterLock(t *testing.T) {
+	f := pickFixture(t)
+	commissioner := f.user(t, "commish")
+	member := f.user(t, "member")
+	poolID := f.pool(t, commissioner, lockedSettings(), member)
+
+	req := models.CreatePickRequest{PoolID: poolID, TeamID: 1, PickOrder: 1}
+	expectError(t, f.do(t, http.MethodPost, "/picks", member, req), http.StatusForbidden, "picks_locked")
+	expectError(t, f.do(t, http.MethodPost, "/picks", commissioner, req), http.StatusForbidden, "picks_locked")
+
+	req.Note = "Entered late by phone"
+	expectStatus(t, f.do(t, http.MethodPost, "/picks", commissioner, req), http.StatusCreated, nil)
+
+	var overrides []models.PickOverride
+	expectStatus(t, f.do(t, http.MethodGet, "/picks/pool/"+strconv.Itoa(poolID)+"/overrides", member, nil), http.StatusOK, &overrides)
+	if len(overrides) != 1 {
+		t.Fatalf("got %d overrides, want 1", len(overrides))
+	}
+	override := overrides[0]
+	if override.Action != models.PickActionCreate || override.CommissionerID != commissioner || override.Note != req.Note {
+		t.Fatalf("override = %+v", override)
+	}
+}
+
+func TestSeasonStartLock(t *testing.T) {
+	f := pickFixture(t)
+	commissioner := f.user(t, "commish")
+	member := f.user(t, "member")
+	poolID := f.pool(t, commissioner, models.DefaultPoolSettings(), member)
+
+	// Without a schedule the pool has no deadline yet
+	req := models.CreatePickRequest{PoolID: poolID, TeamID: 1, PickOrder: 1}
+	expectStatus(t, f.do(t, http.MethodPost, "/picks", member, req), http.StatusCreated, nil)
+
+	f.db.AddGame(models.NFLGame{
+		SeasonYear: 2025,
+		Week:       1,
+		HomeTeamID: 1,
+		AwayTeamID: 2,
+		GameDate:   time.Now().UTC().Add(-time.Hour),
+		Status:     models.GameStatusInProgress,
+	})
+
+	req = models.CreatePickRequest{PoolID: poolID, TeamID: 2, PickOrder: 2}
+	expectError(t, f.do(t, http.MethodPost, "/picks", member, req), http.StatusForbidden, "picks_locked")
+}
+
+func TestEditPickOwnership(t *testing.T) {
+	f := pickFixture(t)
+	commissioner := f.user(t, "commish")
+	member := f.user(t, "member")
+	other := f.user(t, "other")
+	poolID := f.pool(t, commissioner, openSettings(), member, other)
+
+	var pick models.PickWithTeam
+	rec := f.do(t, http.MethodPost, "/picks", member, models.CreatePickRequest{PoolID: poolID, TeamID: 1, PickOrder: 1})
+	expectStatus(t, rec, http.StatusCreated, &pick)
+
+	path := "/picks/" + strconv.Itoa(pick.PickID)
+	update := models.CreatePickRequest{PoolID: poolID, TeamID: 2, PickOrder: 1}
+	expectError(t, f.do(t, http.MethodPut, path, other, update), http.StatusNotFound, "pick_not_found")
+
+	expectStatus(t, f.do(t, http.MethodPut, path, commissioner, update), http.StatusOK, &pick)
+	if pick.UserID != member || pick.TeamID != 2 {
+		t.Fatalf("updated pick = %+v", pick)
+	}
+
+	expectError(t, f.do(t, http.MethodDelete, path, other, nil), http.StatusNotFound, "pick_not_found")
+	expectStatus(t, f.do(t, http.MethodDelete, path, member, nil), http.StatusOK, nil)
+	expectError(t, f.do(t, http.MethodDelete, path, member, nil), http.StatusNotFound, "pick_not_found")
+}
+
+func TestCreatePickForMember(t *testing.T) {
+	f := pickFixture(t)
+	commissioner := f.user(t, "commish")
+	member := f.user(t, "member")
+	outsider := f.user(t, "outsider")
+
+	// Before the lock nobody may pick for someone else
+	openID := f.pool(t, commissioner, openSettings(), member)
+	req := models.CreatePickRequest{PoolID: openID, TeamID: 1, PickOrder: 1, UserID: member}
+	expectError(t, f.do(t, http.MethodPost, "/picks", commissioner, req), http.StatusForbidden, "override_required")
+
+	poolID := f.pool(t, commissioner, lockedSettings(), member)
+	req = models.CreatePickRequest{PoolID: poolID, TeamID: 1, PickOrder: 1, UserID: member, Note: "Missed the deadline"}
+	expectError(t, f.do(t, http.MethodPost, "/picks", member, req), http.StatusForbidden, "picks_locked")
+
+	req.UserID = outsider
+	expectError(t, f.do(t, http.MethodPost, "/picks", commissioner, req), http.StatusBadRequest, "invalid_user_id")
+
+	req.UserID = member
+	var pick models.PickWithTeam
+	expectStatus(t, f.do(t, http.MethodPost, "/picks", commissioner, req), http.StatusCreated, &pick)
+	if pick.UserID != member {
+		t.Fatalf("pick made for user %d, want %d", pick.UserID, member)
+	}
+
+	overrides, err := f.store.Picks.ListOverrides(context.Background(), poolID)
+	if err != nil {
+		t.Fatalf("list overrides: %v", err)
+	}
+	if len(overrides) != 1 || overrides[0].UserID != member || overrides[0].CommissionerID != commissioner {
+		t.Fatalf("overrides = %+v", overrides)
+	}
+
+	req.TeamID = 2
+	expectError(t, f.do(t, http.MethodPost, "/picks", commissioner, req), http.StatusConflict, "pick_order_taken")
+}
+
+func TestPicksByUserAndGame(t *testing.T) {
+	f := pickFixture(t)
+	f.team(3, "NYJ")
+	ctx := context.Background()
+	commissioner := f.user(t, "commish")
+	member := f.user(t, "member")
+	outsider := f.user(t, "outsider")
+	shared := f.pool(t, commissioner, openSettings(), member)
+	private := f.pool(t, outsider, openSettings(), member)
+
+	for _, pick := range []struct{ poolID, userID, teamID int }{
+		{shared, member, 1},
+		{shared, commissioner, 3},
+		{private, member, 2},
+		{private, outsider, 1},
+	} {
+		if _, err := f.store.Picks.Create(ctx, pick.poolID, pick.userID, pick.teamID, 1, nil); err != nil {
+			t.Fatalf("create pick: %v", err)
+		}
+	}
+	gameID := f.db.AddGame(models.NFLGame{SeasonYear: 2025, Week: 1, HomeTeamID: 1, AwayTeamID: 2, Status: models.GameStatusScheduled})
+
+	tests := []struct {
+		name   string
+		path   string
+		viewer int
+		want   []int // team IDs, in pool, member and pick order
+	}{
+		{"member's own picks", "/picks/user/" + strconv.Itoa(member), member, []int{1, 2}},
+		{"member seen from a shared pool", "/picks/user/" + strconv.Itoa(member), commissioner, []int{1}},
+		{"member seen from no shared pool", "/picks/user/" + strconv.Itoa(commissioner), outsider, nil},
+		{"game picks across the viewer's pools", "/picks/game/" + strconv.Itoa(gameID), member, []int{1, 2, 1}},
+		{"game picks in the viewer's pool only", "/picks/game/" + strconv.Itoa(gameID), commissioner, []int{1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var picks []models.PickWithTeam
+			expectStatus(t, f.do(t, http.MethodGet, tt.path, tt.viewer, nil), http.StatusOK, &picks)
+			var got []int
+			for _, pick := range picks {
+				got = append(got, pick.TeamID)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("teams = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("teams = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	expectError(t, f.do(t, http.MethodGet, "/picks/game/999", member, nil), http.StatusNotFound, "game_not_found")
+	expectError(t, f.do(t, http.MethodGet, "/picks/user/abc", member, nil), http.StatusBadRequest, "invalid_id")
+}
@@ -1,27 +1,31 @@
 package handlers
 
 import (
-	"database/sql"
 	"encoding/json"
+	"errors"
 	"net/http"
-	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"touchdown-tally/internal/config"
 	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/picklock"
+	"touchdown-tally/internal/store"
 	"touchdown-tally/pkg/logger"
 	"touchdown-tally/pkg/response"
 )
 
 type PoolHandler struct {
-	db     *sql.DB
+	store  *store.Store
+	locks  *picklock.Checker
 	config *config.Config
 	logger *logger.Logger
 }
 
-func NewPoolHandler(db *sql.DB, config *config.Config, logger *logger.Logger) *PoolHandler {
+func NewPoolHandler(st *store.Store, locks *picklock.Checker, config *config.Config, logger *logger.Logger) *PoolHandler {
 	return &PoolHandler{
-		db:     db,
+		store:  st,
+		locks:  locks,
 		config: config,
 		logger: logger,
 	}
@@ -29,30 +33,26 @@ func NewPoolHandler(db *sql.DB, config *config.Config, logger *logger.Logger) *P
 
 // CreatePool creates a new pool
 func (h *PoolHandler) CreatePool(c *gin.Context) {
-	userIDInterface, exists := c.Get("user_id")
-	if !exists {
-		response.Error(c, http.StatusUnauthorized, "User not authenticated")
-		return
-	}
-	
-	userID, ok := userIDInterface.(int)
+	userID, ok := currentUserID(c)
 	if !ok {
-		response.Error(c, http.StatusUnauthorized, "Invalid user context")
 		return
 	}
 
-	var req models.CreatePoolRequest
+	// Settings omitted from the request keep their defaults
+	req := models.CreatePoolRequest{Settings: models.DefaultPoolSettings()}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		response.ValidationError(c, err.Error())
 		return
 	}
 
+	if err := req.Settings.Validate(); err != nil {
+		response.ValidationError(c, "invalid_settings", err.Error())
+		return
+	}
+
 	// For now, allow any authenticated user to create pools
 	// In the future, we can add role-based permissions
 
-	// Create pool
-	var poolID int64
-	
 	// Marshal JSON fields
 	prizeStructureJSON, err := json.Marshal(req.PrizeStructure)
 	if err != nil {
@@ -60,47 +60,30 @@ func (h *PoolHandler) CreatePool(c *gin.Context) {
 		response.Error(c, http.StatusBadRequest, "Invalid prize structure")
 		return
 	}
-	
-	settingsJSON, err := json.Marshal(req.Settings)
-	if err != nil {
-		h.logger.Error("Failed to marshal settings", "user_id", userID, "error", err)
-		response.Error(c, http.StatusBadRequest, "Invalid settings")
-		return
-	}
-	
-	result, err := h.db.Exec(`
-		INSERT INTO pools (pool_name, commissioner_id, season_year, max_members, entry_fee, prize_structure, pool_type, settings)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
-	`, req.PoolName, userID, req.SeasonYear, req.MaxMembers, req.EntryFee, 
-		string(prizeStructureJSON), "survivor", string(settingsJSON))
 
-	if err != nil {
-		h.logger.Error("Failed to create pool", "user_id", userID, "error", err)
-		response.Error(c, http.StatusInternalServerError, "Failed to create pool")
-		return
+	if req.PoolType == "" {
+		req.PoolType = models.PoolTypeSeason
 	}
 
-	poolID, err = result.LastInsertId()
+	// Create the pool with its creator as commissioner
+	poolID, err := h.store.Pools.Create(c.Request.Context(), store.NewPool{
+		Name:           req.PoolName,
+		CommissionerID: userID,
+		SeasonYear:     req.SeasonYear,
+		MaxMembers:     req.MaxMembers,
+		EntryFee:       req.EntryFee,
+		PoolType:       req.PoolType,
+		PrizeStructure: string(prizeStructureJSON),
+		Settings:       req.Settings,
+	})
 	if err != nil {
-		h.logger.Error("Failed to get pool ID", "user_id", userID, "error", err)
+		h.logger.Error("Failed to create pool", "user_id", userID, "error", err)
 		response.Error(c, http.StatusInternalServerError, "Failed to create pool")
 		return
 	}
 
-	// Add creator as pool commissioner (role_id=1 for commissioner)
-	_, err = h.db.Exec(`
-		INSERT INTO pool_memberships (pool_id, user_id, role_id, joined_at)
-		VALUES (?, ?, 1, CURRENT_TIMESTAMP)
-	`, poolID, userID)
-
-	if err != nil {
-		h.logger.Error("Failed to add creator to pool", "pool_id", poolID, "user_id", userID, "error", err)
-		response.Error(c, http.StatusInternalServerError, "Failed to complete pool creation")
-		return
-	}
-
 	// Get the created pool
-	pool, err := h.getPoolByID(poolID)
+	pool, err := h.store.Pools.Get(c.Request.Context(), poolID)
 	if err != nil {
 		h.logger.Error("Failed to retrieve created pool", "pool_id", poolID, "error", err)
 		response.Error(c, http.StatusInternalServerError, "Pool created but failed to retrieve details")
@@ -112,20 +95,13 @@ func (h *PoolHandler) CreatePool(c *gin.Context) {
 
 // GetPools returns pools the user is a member of or can join
 func (h *PoolHandler) GetPools(c *gin.Context) {
-	userIDInterface, exists := c.Get("user_id")
-	if !exists {
-		response.Error(c, http.StatusUnauthorized, "User not authenticated")
-		return
-	}
-	
-	userID, ok := userIDInterface.(int)
+	userID, ok := currentUserID(c)
 	if !ok {
-		response.Error(c, http.StatusUnauthorized, "Invalid user context")
 		return
 	}
 
 	// Get pools user is a member of
-	memberPools, err := h.getUserPools(userID)
+	memberPools, err := h.store.Pools.ListForMember(c.Request.Context(), userID)
 	if err != nil {
 		h.logger.Error("Failed to get user pools", "user_id", userID, "error", err)
 		response.Error(c, http.StatusInternalServerError, "Failed to retrieve pools")
@@ -133,7 +109,7 @@ func (h *PoolHandler) GetPools(c *gin.Context) {
 	}
 
 	// Get available pools (not full, user not already a member)
-	availablePools, err := h.getAvailablePools(userID)
+	availablePools, err := h.store.Pools.ListAvailable(c.Request.Context(), userID)
 	if err != nil {
 		h.logger.Error("Failed to get available pools", "user_id", userID, "error", err)
 		response.Error(c, http.StatusInternalServerError, "Failed to retrieve available pools")
@@ -148,44 +124,23 @@ func (h *PoolHandler) GetPools(c *gin.Context) {
 
 // GetPool returns details for a specific pool
 func (h *PoolHandler) GetPool(c *gin.Context) {
-	poolID := c.Param("id")
-	userIDInterface, exists := c.Get("user_id")
-	if !exists {
-		response.Error(c, http.StatusUnauthorized, "User not authenticated")
-		return
-	}
-	
-	userID, ok := userIDInterface.(int)
+	userID, ok := currentUserID(c)
 	if !ok {
-		response.Error(c, http.StatusUnauthorized, "Invalid user context")
 		return
 	}
 
-	// Check if user has access to this pool
-	var memberRole string
-	err := h.db.QueryRow(`
-		SELECT r.role_name FROM pool_memberships pm 
-		JOIN roles r ON pm.role_id = r.role_id
-		WHERE pm.pool_id = ? AND pm.user_id = ?
-	`, poolID, userID).Scan(&memberRole)
-
-	if err == sql.ErrNoRows {
-		response.Error(c, http.StatusForbidden, "Access denied to this pool")
-		return
-	}
-	if err != nil {
-		h.logger.Error("Failed to check pool membership", "pool_id", poolID, "user_id", userID, "error", err)
-		response.Error(c, http.StatusInternalServerError, "Failed to validate access")
+	poolID, ok := idParam(c, "id")
+	if !ok {
 		return
 	}
 
-	poolIDInt64, err := strconv.ParseInt(poolID, 10, 64)
-	if err != nil {
-		response.Error(c, http.StatusBadRequest, "Invalid pool ID")
+	// Check if user has access to this pool
+	memberRole, ok := requireMembership(c, h.store, h.logger, poolID, userID)
+	if !ok {
 		return
 	}
 
-	pool, err := h.getPoolByID(poolIDInt64)
+	pool, err := h.store.Pools.Get(c.Request.Context(), poolID)
 	if err != nil {
 		h.logger.Error("Failed to get pool", "pool_id", poolID, "error", err)
 		response.Error(c, http.StatusInternalServerError, "Failed to retrieve pool")
@@ -193,7 +148,7 @@ func (h *PoolHandler) GetPool(c *gin.Context) {
 	}
 
 	// Get pool members
-	members, err := h.getPoolMembers(poolID)
+	members, err := h.store.Pools.ListMembers(c.Request.Context(), poolID)
 	if err != nil {
 		h.logger.Error("Failed to get pool members", "pool_id", poolID, "error", err)
 		response.Error(c, http.StatusInternalServerError, "Failed to retrieve pool members")
@@ -206,33 +161,47 @@ func (h *PoolHandler) GetPool(c *gin.Context) {
 	response.Success(c, pool)
 }
 
+// GetMembers returns the members of a pool the user belongs to
+func (h *PoolHandler) GetMembers(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	poolID, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if _, ok := requireMembership(c, h.store, h.logger, poolID, userID); !ok {
+		return
+	}
+
+	members, err := h.store.Pools.ListMembers(c.Request.Context(), poolID)
+	if err != nil {
+		h.logger.Error("Failed to get pool members", "pool_id", poolID, "error", err)
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve pool members")
+		return
+	}
+
+	response.Success(c, members)
+}
+
 // JoinPool allows a user to join a pool
 func (h *PoolHandler) JoinPool(c *gin.Context) {
-	poolID := c.Param("id")
-	userIDInterface, exists := c.Get("user_id")
-	if !exists {
-		response.Error(c, http.StatusUnauthorized, "User not authenticated")
+	userID, ok := currentUserID(c)
+	if !ok {
 		return
 	}
-	
-	userID, ok := userIDInterface.(int)
+
+	poolID, ok := idParam(c, "id")
 	if !ok {
-		response.Error(c, http.StatusUnauthorized, "Invalid user context")
 		return
 	}
 
 	// Check if pool exists and is not full
-	var currentMembers, maxMembers int
-	var status string
-	err := h.db.QueryRow(`
-		SELECT 
-			(SELECT COUNT(*) FROM pool_memberships WHERE pool_id = p.pool_id),
-			p.max_members,
-			p.status
-		FROM pools p WHERE p.pool_id = ?
-	`, poolID).Scan(&currentMembers, &maxMembers, &status)
-
-	if err == sql.ErrNoRows {
+	pool, err := h.store.Pools.Get(c.Request.Context(), poolID)
+	if errors.Is(err, store.ErrNotFound) {
 		response.Error(c, http.StatusNotFound, "Pool not found")
 		return
 	}
@@ -242,40 +211,21 @@ func (h *PoolHandler) JoinPool(c *gin.Context) {
 		return
 	}
 
-	if status != "active" {
+	if pool.IsActive != "active" {
 		response.Error(c, http.StatusBadRequest, "Pool is not active")
 		return
 	}
 
-	if currentMembers >= maxMembers {
+	if pool.CurrentMembers >= pool.MaxPlayers {
 		response.Error(c, http.StatusBadRequest, "Pool is full")
 		return
 	}
 
-	// Check if user is already a member
-	var existingMember bool
-	err = h.db.QueryRow(`
-		SELECT EXISTS(SELECT 1 FROM pool_memberships 
-		WHERE pool_id = ? AND user_id = ?)
-	`, poolID, userID).Scan(&existingMember)
-
-	if err != nil {
-		h.logger.Error("Failed to check existing membership", "pool_id", poolID, "user_id", userID, "error", err)
-		response.Error(c, http.StatusInternalServerError, "Failed to validate membership")
-		return
-	}
-
-	if existingMember {
+	err = h.store.Pools.AddMember(c.Request.Context(), poolID, userID, models.RoleMember)
+	if errors.Is(err, store.ErrConflict) {
 		response.Error(c, http.StatusBadRequest, "Already a member of this pool")
 		return
 	}
-
-	// Add user to pool (role_id 2 = member)
-	_, err = h.db.Exec(`
-		INSERT INTO pool_memberships (pool_id, user_id, role_id, joined_at)
-		VALUES (?, ?, 2, CURRENT_TIMESTAMP)
-	`, poolID, userID)
-
 	if err != nil {
 		h.logger.Error("Failed to join pool", "pool_id", poolID, "user_id", userID, "error", err)
 		response.Error(c, http.StatusInternalServerError, "Failed to join pool")
@@ -287,27 +237,19 @@ func (h *PoolHandler) JoinPool(c *gin.Context) {
 
 // LeavePool allows a user to leave a pool
 func (h *PoolHandler) LeavePool(c *gin.Context) {
-	poolID := c.Param("id")
-	userIDInterface, exists := c.Get("user_id")
-	if !exists {
-		response.Error(c, http.StatusUnauthorized, "User not authenticated")
+	userID, ok := currentUserID(c)
+	if !ok {
 		return
 	}
-	
-	userID, ok := userIDInterface.(int)
+
+	poolID, ok := idParam(c, "id")
 	if !ok {
-		response.Error(c, http.StatusUnauthorized, "Invalid user context")
 		return
 	}
 
 	// Check if user is a member and get their role
-	var roleID int
-	err := h.db.QueryRow(`
-		SELECT role_id FROM pool_memberships 
-		WHERE pool_id = ? AND user_id = ?
-	`, poolID, userID).Scan(&roleID)
-
-	if err == sql.ErrNoRows {
+	role, err := h.store.Pools.MemberRole(c.Request.Context(), poolID, userID)
+	if errors.Is(err, store.ErrNotFound) {
 		response.Error(c, http.StatusBadRequest, "Not a member of this pool")
 		return
 	}
@@ -317,14 +259,9 @@ func (h *PoolHandler) LeavePool(c *gin.Context) {
 		return
 	}
 
-	// Check if this is the only commissioner (role_id 1 = commissioner)
-	if roleID == 1 {
-		var commissionerCount int
-		err = h.db.QueryRow(`
-			SELECT COUNT(*) FROM pool_memberships 
-			WHERE pool_id = ? AND role_id = 1
-		`, poolID).Scan(&commissionerCount)
-
+	// The last commissioner cannot leave
+	if role == models.RoleCommissioner {
+		commissionerCount, err := h.store.Pools.CountRole(c.Request.Context(), poolID, models.RoleCommissioner)
 		if err != nil {
 			h.logger.Error("Failed to count commissioners", "pool_id", poolID, "error", err)
 			response.Error(c, http.StatusInternalServerError, "Failed to validate commissioner status")
@@ -337,13 +274,7 @@ func (h *PoolHandler) LeavePool(c *gin.Context) {
 		}
 	}
 
-	// Remove user from pool
-	_, err = h.db.Exec(`
-		DELETE FROM pool_memberships 
-		WHERE pool_id = ? AND user_id = ?
-	`, poolID, userID)
-
-	if err != nil {
+	if err := h.store.Pools.RemoveMember(c.Request.Context(), poolID, userID); err != nil {
 		h.logger.Error("Failed to leave pool", "pool_id", poolID, "user_id", userID, "error", err)
 		response.Error(c, http.StatusInternalServerError, "Failed to leave pool")
 		return
@@ -352,136 +283,135 @@ func (h *PoolHandler) LeavePool(c *gin.Context) {
 	response.Success(c, gin.H{"message": "Successfully left pool"})
 }
 
-// Helper functions
-
-func (h *PoolHandler) getPoolByID(poolID int64) (*models.Pool, error) {
-	pool := &models.Pool{}
-	
-	err := h.db.QueryRow(`
-		SELECT 
-			p.pool_id, p.pool_name, COALESCE(p.description, ''), p.max_members, p.season_year, p.pool_type,
-			p.entry_fee, p.status, p.created_at, p.updated_at,
-			up.display_name as creator_name,
-			(SELECT COUNT(*) FROM pool_memberships WHERE pool_id = p.pool_id) as current_members
-		FROM pools p
-		JOIN user_profiles up ON p.commissioner_id = up.user_id
-		WHERE p.pool_id = ?
-	`, poolID).Scan(
-		&pool.ID, &pool.Name, &pool.Description, &pool.MaxPlayers, &pool.Season,
-		&pool.PoolType, &pool.EntryFee, &pool.IsActive, &pool.CreatedAt, &pool.UpdatedAt,
-		&pool.CreatorName, &pool.CurrentMembers,
-	)
-
-	return pool, err
-}
+// SetMemberRole lets the commissioner make a member a chat moderator or
+// return them to an ordinary member
+func (h *PoolHandler) SetMemberRole(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
 
-func (h *PoolHandler) getUserPools(userID int) ([]models.Pool, error) {
-	rows, err := h.db.Query(`
-		SELECT 
-			p.pool_id, p.pool_name, COALESCE(p.description, ''), p.max_members, p.season_year, p.pool_type,
-			p.entry_fee, p.status, p.created_at, p.updated_at,
-			up.display_name as creator_name,
-			r.role_name as user_role,
-			(SELECT COUNT(*) FROM pool_memberships WHERE pool_id = p.pool_id) as current_members
-		FROM pools p
-		JOIN pool_memberships pm ON p.pool_id = pm.pool_id
-		JOIN user_profiles up ON p.commissioner_id = up.user_id
-		JOIN roles r ON pm.role_id = r.role_id
-		WHERE pm.user_id = ?
-		ORDER BY p.created_at DESC
-	`, userID)
+	poolID, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
 
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var pools []models.Pool
-	for rows.Next() {
-		var pool models.Pool
-		err := rows.Scan(
-			&pool.ID, &pool.Name, &pool.Description, &pool.MaxPlayers, &pool.Season,
-			&pool.PoolType, &pool.EntryFee, &pool.IsActive, &pool.CreatedAt, &pool.UpdatedAt,
-			&pool.CreatorName, &pool.UserRole, &pool.CurrentMembers,
-		)
-		if err != nil {
-			return nil, err
-		}
-		pools = append(pools, pool)
+	targetID, ok := idParam(c, "user_id")
+	if !ok {
+		return
 	}
 
-	return pools, rows.Err()
-}
+	var req struct {
+		Role string `json:"role" binding:"required,oneof=member moderator"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid_request", err.Error())
+		return
+	}
 
-func (h *PoolHandler) getAvailablePools(userID int) ([]models.Pool, error) {
-	rows, err := h.db.Query(`
-		SELECT 
-			p.pool_id, p.pool_name, COALESCE(p.description, ''), p.max_members, p.season_year, p.pool_type,
-			p.entry_fee, p.status, p.created_at, p.updated_at,
-			up.display_name as creator_name,
-			(SELECT COUNT(*) FROM pool_memberships WHERE pool_id = p.pool_id) as current_members
-		FROM pools p
-		JOIN user_profiles up ON p.commissioner_id = up.user_id
-		WHERE p.status = 'active'
-		AND p.pool_id NOT IN (
-			SELECT pool_id FROM pool_memberships 
-			WHERE user_id = ?
-		)
-		AND (SELECT COUNT(*) FROM pool_memberships WHERE pool_id = p.pool_id) < p.max_members
-		ORDER BY p.created_at DESC
-	`, userID)
+	role, ok := requireMembership(c, h.store, h.logger, poolID, userID)
+	if !ok {
+		return
+	}
+	if role != models.RoleCommissioner {
+		response.Forbidden(c, "commissioner_required", "Only the commissioner can change member roles")
+		return
+	}
 
+	targetRole, err := h.store.Pools.MemberRole(c.Request.Context(), poolID, targetID)
+	if errors.Is(err, store.ErrNotFound) {
+		response.NotFound(c, "member_not_found", "That user is not a member of this pool")
+		return
+	}
 	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var pools []models.Pool
-	for rows.Next() {
-		var pool models.Pool
-		err := rows.Scan(
-			&pool.ID, &pool.Name, &pool.Description, &pool.MaxPlayers, &pool.Season,
-			&pool.PoolType, &pool.EntryFee, &pool.IsActive, &pool.CreatedAt, &pool.UpdatedAt,
-			&pool.CreatorName, &pool.CurrentMembers,
-		)
-		if err != nil {
-			return nil, err
-		}
-		pools = append(pools, pool)
+		h.logger.Error("Failed to check membership", "pool_id", poolID, "user_id", targetID, "error", err)
+		response.InternalServerError(c, "membership_check_failed", "Failed to verify pool membership")
+		return
+	}
+	if targetRole == models.RoleCommissioner {
+		response.BadRequest(c, "commissioner_role", "The commissioner's role cannot be changed")
+		return
 	}
 
-	return pools, rows.Err()
+	if err := h.store.Pools.SetMemberRole(c.Request.Context(), poolID, targetID, req.Role); err != nil {
+		h.logger.Error("Failed to set member role", "pool_id", poolID, "user_id", targetID, "error", err)
+		response.InternalServerError(c, "role_update_failed", "Failed to change member role")
+		return
+	}
+
+	h.logger.Info("Member role changed", "pool_id", poolID, "user_id", targetID, "role", req.Role, "by", userID)
+	response.Success(c, gin.H{"user_id": targetID, "role": req.Role}, "Member role updated")
 }
 
-func (h *PoolHandler) getPoolMembers(poolID string) ([]models.PoolMember, error) {
-	rows, err := h.db.Query(`
-		SELECT 
-			pm.user_id, r.role_name, pm.joined_at,
-			up.display_name, up.username
-		FROM pool_memberships pm
-		JOIN user_profiles up ON pm.user_id = up.user_id
-		JOIN roles r ON pm.role_id = r.role_id
-		WHERE pm.pool_id = ?
-		ORDER BY pm.joined_at ASC
-	`, poolID)
+// UpdateSettings lets the commissioner change a pool's settings. Fields
+// omitted from the body keep their current values.
+func (h *PoolHandler) UpdateSettings(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	poolID, ok := idParam(c, "id")
+	if !ok {
+		return
+	}
+
+	role, ok := requireMembership(c, h.store, h.logger, poolID, userID)
+	if !ok {
+		return
+	}
+
+	if role != models.RoleCommissioner {
+		response.Forbidden(c, "commissioner_required", "Only the commissioner can change pool settings")
+		return
+	}
 
+	pool, err := h.store.Pools.Get(c.Request.Context(), poolID)
 	if err != nil {
-		return nil, err
+		h.logger.Error("Failed to get pool", "pool_id", poolID, "error", err)
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve pool")
+		return
+	}
+
+	settings := pool.Settings
+	// Binding writes through pointers; keep the stored deadline intact so
+	// the lock change below can be checked against it
+	if deadline := settings.Locks.Deadline; deadline != nil {
+		stored := *deadline
+		settings.Locks.Deadline = &stored
+	}
+	if err := c.ShouldBindJSON(&settings); err != nil {
+		response.ValidationError(c, err.Error())
+		return
+	}
+
+	if err := settings.Validate(); err != nil {
+		response.ValidationError(c, "invalid_settings", err.Error())
+		return
 	}
-	defer rows.Close()
 
-	var members []models.PoolMember
-	for rows.Next() {
-		var member models.PoolMember
-		err := rows.Scan(
-			&member.UserID, &member.Role, &member.JoinedAt,
-			&member.DisplayName, &member.Email,
-		)
+	// Once picks lock, moving the lock would reopen them without the
+	// audit trail commissioner overrides leave
+	if !settings.Locks.Equal(pool.Settings.Locks) {
+		status, err := h.locks.SeasonStatus(c.Request.Context(), pool)
 		if err != nil {
-			return nil, err
+			h.logger.Error("Failed to check pick lock", "pool_id", poolID, "error", err)
+			response.InternalServerError(c, "lock_check_failed", "Failed to check pick deadline")
+			return
+		}
+		if status.Locked {
+			response.Forbidden(c, "picks_locked", "Picks for this pool locked at "+status.Deadline.UTC().Format(time.RFC3339)+
+				"; lock settings can no longer change")
+			return
 		}
-		members = append(members, member)
 	}
 
-	return members, rows.Err()
+	if err := h.store.Pools.UpdateSettings(c.Request.Context(), poolID, settings); err != nil {
+		h.logger.Error("Failed to update pool settings", "pool_id", poolID, "error", err)
+		response.Error(c, http.StatusInternalServerError, "Failed to update pool settings")
+		return
+	}
+
+	h.logger.Info("Pool settings updated", "pool_id", poolID, "user_id", userID)
+	response.Success(c, settings, "Pool settings updated")
 }
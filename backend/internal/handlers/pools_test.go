@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"touchdown-tally/internal/calendar"
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/picklock"
+	"touchdown-tally/internal/store"
+)
+
+// poolFixture routes the pool handlers
+func poolFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	f := newFixture(t)
+	h := NewPoolHandler(f.store, picklock.NewChecker(calendar.New(f.store)), f.config, f.logger)
+	f.router.GET("/pools", h.GetPools)
+	f.router.POST("/pools", h.CreatePool)
+	f.router.GET("/pools/:id", h.GetPool)
+	f.router.POST("/pools/:id/join", h.JoinPool)
+	f.router.POST("/pools/:id/leave", h.LeavePool)
+	f.router.PUT("/pools/:id/settings", h.UpdateSettings)
+	return f
+}
+
+func TestCreatePool(t *testing.T) {
+	f := poolFixture(t)
+	creator := f.user(t, "creator")
+
+	var created models.Pool
+	rec := f.do(t, http.MethodPost, "/pools", creator, map[string]interface{}{
+		"pool_name":   "Office League",
+		"season_year": 2025,
+		"max_members": 12,
+	})
+	expectStatus(t, rec, http.StatusOK, &created)
+	if created.PoolType != models.PoolTypeSeason || created.CurrentMembers != 1 || created.CreatorName != "creator" {
+		t.Fatalf("created pool = %+v", created)
+	}
+	if created.Settings.Locks.Policy != models.LockPolicySeasonStart {
+		t.Fatalf("lock policy = %q, want the default", created.Settings.Locks.Policy)
+	}
+
+	var pool models.Pool
+	expectStatus(t, f.do(t, http.MethodGet, "/pools/"+strconv.Itoa(created.ID), creator, nil), http.StatusOK, &pool)
+	if pool.UserRole != models.RoleCommissioner || len(pool.Members) != 1 {
+		t.Fatalf("pool = %+v", pool)
+	}
+}
+
+func TestCreatePoolRejectsInvalidSettings(t *testing.T) {
+	f := poolFixture(t)
+	creator := f.user(t, "creator")
+
+	rec := f.do(t, http.MethodPost, "/pools", creator, map[string]interface{}{
+		"pool_name":   "Office League",
+		"season_year": 2025,
+		"max_members": 12,
+		"settings":    map[string]interface{}{"locks": map[string]string{"policy": "whenever"}},
+	})
+	expectError(t, rec, http.StatusUnprocessableEntity, "invalid_settings")
+}
+
+func TestGetPoolRequiresMembership(t *testing.T) {
+	f := poolFixture(t)
+	commissioner := f.user(t, "commish")
+	outsider := f.user(t, "outsider")
+	poolID := f.pool(t, commissioner, openSettings())
+
+	expectError(t, f.do(t, http.MethodGet, "/pools/"+strconv.Itoa(poolID), outsider, nil), http.StatusForbidden, "not_pool_member")
+}
+
+func TestJoinPool(t *testing.T) {
+	f := poolFixture(t)
+	commissioner := f.user(t, "commish")
+	member := f.user(t, "member")
+	poolID := f.pool(t, commissioner, openSettings())
+	path := "/pools/" + strconv.Itoa(poolID) + "/join"
+
+	var pools struct {
+		Member    []models.Pool `json:"member_pools"`
+		Available []models.Pool `json:"available_pools"`
+	}
+	expectStatus(t, f.do(t, http.MethodGet, "/pools", member, nil), http.StatusOK, &pools)
+	if len(pools.Member) != 0 || len(pools.Available) != 1 {
+		t.Fatalf("before joining: %d member pools, %d available", len(pools.Member), len(pools.Available))
+	}
+
+	expectStatus(t, f.do(t, http.MethodPost, path, member, nil), http.StatusOK, nil)
+	expectStatus(t, f.do(t, http.MethodPost, path, member, nil), http.StatusBadRequest, nil)
+
+	pools.Member, pools.Available = nil, nil
+	expectStatus(t, f.do(t, http.MethodGet, "/pools", member, nil), http.StatusOK, &pools)
+	if len(pools.Member) != 1 || pools.Member[0].UserRole != models.RoleMember || len(pools.Available) != 0 {
+		t.Fatalf("after joining: %+v", pools)
+	}
+}
+
+func TestJoinFullOrClosedPool(t *testing.T) {
+	f := poolFixture(t)
+	ctx := context.Background()
+	commissioner := f.user(t, "commish")
+	late := f.user(t, "late")
+
+	fullID, err := f.store.Pools.Create(ctx, store.NewPool{
+		Name:           "Heads Up",
+		CommissionerID: commissioner,
+		SeasonYear:     2025,
+		MaxMembers:     2,
+		PoolType:       models.PoolTypeSeason,
+		Settings:       openSettings(),
+	})
+	if err != nil {
+		t.Fatalf("create pool: %v", err)
+	}
+	if err := f.store.Pools.AddMember(ctx, fullID, f.user(t, "rival"), models.RoleMember); err != nil {
+		t.Fatalf("add member: %v", err)
+	}
+	expectStatus(t, f.do(t, http.MethodPost, "/pools/"+strconv.Itoa(fullID)+"/join", late, nil), http.StatusBadRequest, nil)
+
+	closedID := f.pool(t, commissioner, openSettings())
+	if err := f.store.Pools.SetStatus(ctx, closedID, models.PoolStatusCompleted); err != nil {
+		t.Fatalf("close pool: %v", err)
+	}
+	expectStatus(t, f.do(t, http.MethodPost, "/pools/"+strconv.Itoa(closedID)+"/join", late, nil), http.StatusBadRequest, nil)
+
+	expectStatus(t, f.do(t, http.MethodPost, "/pools/999/join", late, nil), http.StatusNotFound, nil)
+}
+
+func TestLeavePool(t *testing.T) {
+	f := poolFixture(t)
+	commissioner := f.user(t, "commish")
+	member := f.user(t, "member")
+	poolID := f.pool(t, commissioner, openSettings(), member)
+	path := "/pools/" + strconv.Itoa(poolID) + "/leave"
+
+	// The only commissioner must stay
+	expectStatus(t, f.do(t, http.MethodPost, path, commissioner, nil), http.StatusBadRequest, nil)
+
+	expectStatus(t, f.do(t, http.MethodPost, path, member, nil), http.StatusOK, nil)
+	expectStatus(t, f.do(t, http.MethodPost, path, member, nil), http.StatusBadRequest, nil)
+
+	if ok, err := f.store.Pools.IsMember(context.Background(), poolID, member); err != nil || ok {
+		t.Fatalf("IsMember after leaving = %v, %v", ok, err)
+	}
+}
+
+func TestUpdateSettingsAfterLock(t *testing.T) {
+	later := time.Now().UTC().Add(24 * time.Hour)
+
+	tests := []struct {
+		name       string
+		settings   models.PoolSettings
+		body       map[string]interface{}
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "open pool may change its lock",
+			settings:   openSettings(),
+			body:       map[string]interface{}{"locks": models.LockSettings{Policy: models.LockPolicyDeadline, Deadline: &later}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "locked pool may not drop its lock",
+			settings:   lockedSettings(),
+			body:       map[string]interface{}{"locks": map[string]string{"policy": models.LockPolicyNone}},
+			wantStatus: http.StatusForbidden,
+			wantCode:   "picks_locked",
+		},
+		{
+			name:       "locked pool may not move its deadline",
+			settings:   lockedSettings(),
+			body:       map[string]interface{}{"locks": models.LockSettings{Policy: models.LockPolicyDeadline, Deadline: &later}},
+			wantStatus: http.StatusForbidden,
+			wantCode:   "picks_locked",
+		},
+		{
+			name:       "locked pool may change other settings",
+			settings:   lockedSettings(),
+			body:       map[string]interface{}{"survivor": map[string]string{"missed_pick": models.MissedPickAutoAssign}},
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := poolFixture(t)
+			commissioner := f.user(t, "commish")
+			poolID := f.pool(t, commissioner, tt.settings)
+
+			rec := f.do(t, http.MethodPut, "/pools/"+strconv.Itoa(poolID)+"/settings", commissioner, tt.body)
+			if tt.wantCode != "" {
+				expectError(t, rec, tt.wantStatus, tt.wantCode)
+			} else {
+				expectStatus(t, rec, tt.wantStatus, nil)
+			}
+
+			pool, err := f.store.Pools.Get(context.Background(), poolID)
+			if err != nil {
+				t.Fatalf("get pool: %v", err)
+			}
+			if tt.wantCode != "" && !pool.Settings.Locks.Equal(tt.settings.Locks) {
+				t.Fatalf("locks changed to %+v", pool.Settings.Locks)
+			}
+		})
+	}
+}
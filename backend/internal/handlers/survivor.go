@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"touchdown-tally/internal/calendar"
+	"touchdown-tally/internal/config"
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
+	"touchdown-tally/internal/survivor"
+	"touchdown-tally/pkg/logger"
+	"touchdown-tally/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SurvivorHandler handles weekly picks and the board in survivor pools
+type SurvivorHandler struct {
+	store    *store.Store
+	service  *survivor.Service
+	calendar *calendar.Calendar
+	config   *config.Config
+	logger   *logger.Logger
+}
+
+// NewSurvivorHandler creates a new SurvivorHandler
+func NewSurvivorHandler(st *store.Store, service *survivor.Service, cal *calendar.Calendar, cfg *config.Config, logger *logger.Logger) *SurvivorHandler {
+	return &SurvivorHandler{
+		store:    st,
+		service:  service,
+		calendar: cal,
+		config:   cfg,
+		logger:   logger,
+	}
+}
+
+// RunProcessor grades survivor picks and resolves missed weeks until ctx is
+// cancelled
+func (h *SurvivorHandler) RunProcessor(ctx context.Context) {
+	h.service.Run(ctx)
+}
+
+// Board returns every member's picks and elimination status
+func (h *SurvivorHandler) Board(c *gin.Context) {
+	pool, userID, ok := h.resolve(c)
+	if !ok {
+		return
+	}
+
+	board, err := h.service.Board(c.Request.Context(), pool, userID)
+	if err != nil {
+		h.respondError(c, pool.ID, err)
+		return
+	}
+
+	response.Success(c, board)
+}
+
+// Pick saves the caller's team for a week, which may be "current"
+func (h *SurvivorHandler) Pick(c *gin.Context) {
+	pool, userID, ok := h.resolve(c)
+	if !ok {
+		return
+	}
+
+	week, ok := weekParam(c, h.calendar, h.logger, c.Param("week"), pool.Season)
+	if !ok {
+		return
+	}
+
+	var req models.SurvivorPickRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid_request", err.Error())
+		return
+	}
+
+	board, err := h.service.Pick(c.Request.Context(), pool, userID, week, req.TeamID)
+	if err != nil {
+		h.respondError(c, pool.ID, err)
+		return
+	}
+
+	response.Success(c, board, "Pick saved")
+}
+
+// resolve loads the pool for a request from any pool member
+func (h *SurvivorHandler) resolve(c *gin.Context) (*models.Pool, int, bool) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return nil, 0, false
+	}
+
+	poolID, ok := idParam(c, "id")
+	if !ok {
+		return nil, 0, false
+	}
+
+	if _, ok := requireMembership(c, h.store, h.logger, poolID, userID); !ok {
+		return nil, 0, false
+	}
+
+	pool, err := h.store.Pools.Get(c.Request.Context(), poolID)
+	if err != nil {
+		h.logger.Error("Failed to get pool", "pool_id", poolID, "error", err)
+		response.InternalServerError(c, "query_failed", "Failed to retrieve pool")
+		return nil, 0, false
+	}
+
+	return pool, userID, true
+}
+
+func (h *SurvivorHandler) respondError(c *gin.Context, poolID int, err error) {
+	switch {
+	case errors.Is(err, survivor.ErrNotSurvivorPool):
+		response.Conflict(c, "not_survivor_pool", err.Error())
+	case errors.Is(err, survivor.ErrPoolFinished):
+		response.Conflict(c, "pool_finished", err.Error())
+	case errors.Is(err, survivor.ErrWeekLocked):
+		response.Forbidden(c, "picks_locked", err.Error())
+	case errors.Is(err, survivor.ErrEliminated):
+		response.Forbidden(c, "eliminated", err.Error())
+	case errors.Is(err, survivor.ErrTeamNotPlaying):
+		response.BadRequest(c, "invalid_team", err.Error())
+	case errors.Is(err, survivor.ErrTeamUsed):
+		response.Conflict(c, "team_used", err.Error())
+	default:
+		h.logger.Error("Survivor request failed", "pool_id", poolID, "error", err)
+		response.InternalServerError(c, "survivor_failed", "Failed to process survivor request")
+	}
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"touchdown-tally/internal/models"
+)
+
+func TestTeams(t *testing.T) {
+	f := newFixture(t)
+	user := f.user(t, "fan")
+	f.db.AddTeam(models.NFLTeam{TeamID: 1, TeamName: "Bills", Conference: "AFC", Division: "East"})
+	f.db.AddTeam(models.NFLTeam{TeamID: 2, TeamName: "Packers", Conference: "NFC", Division: "North"})
+	f.db.AddTeam(models.NFLTeam{TeamID: 3, TeamName: "Bears", Conference: "NFC", Division: "North"})
+
+	h := NewTeamHandler(f.store, f.config, f.logger)
+	f.router.GET("/teams", h.List)
+	f.router.GET("/teams/:id", h.Get)
+
+	var teams []models.NFLTeam
+	expectStatus(t, f.do(t, http.MethodGet, "/teams?conference=NFC", user, nil), http.StatusOK, &teams)
+	if len(teams) != 2 || teams[0].TeamName != "Bears" || teams[1].TeamName != "Packers" {
+		t.Fatalf("NFC teams = %+v", teams)
+	}
+
+	var team models.NFLTeam
+	expectStatus(t, f.do(t, http.MethodGet, "/teams/1", user, nil), http.StatusOK, &team)
+	if team.TeamName != "Bills" {
+		t.Fatalf("team 1 = %+v", team)
+	}
+
+	expectError(t, f.do(t, http.MethodGet, "/teams/99", user, nil), http.StatusNotFound, "team_not_found")
+	expectError(t, f.do(t, http.MethodGet, "/teams/abc", user, nil), http.StatusBadRequest, "invalid_id")
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"errors"
+
+	"touchdown-tally/internal/calendar"
+	"touchdown-tally/internal/config"
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
+	"touchdown-tally/internal/tiebreak"
+	"touchdown-tally/pkg/logger"
+	"touchdown-tally/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TiebreakerHandler handles members' tiebreaker predictions
+type TiebreakerHandler struct {
+	store    *store.Store
+	service  *tiebreak.Service
+	calendar *calendar.Calendar
+	config   *config.Config
+	logger   *logger.Logger
+}
+
+// NewTiebreakerHandler creates a new TiebreakerHandler
+func NewTiebreakerHandler(st *store.Store, service *tiebreak.Service, cal *calendar.Calendar, cfg *config.Config, logger *logger.Logger) *TiebreakerHandler {
+	return &TiebreakerHandler{
+		store:    st,
+		service:  service,
+		calendar: cal,
+		config:   cfg,
+		logger:   logger,
+	}
+}
+
+// Get returns the tiebreaker game with the caller's prediction, and everyone's
+// once it has kicked off
+func (h *TiebreakerHandler) Get(c *gin.Context) {
+	pool, userID, week, ok := h.resolve(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.Get(c.Request.Context(), pool, userID, week)
+	if err != nil {
+		h.respondError(c, pool.ID, err)
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// Submit saves the caller's predicted total for the tiebreaker game
+func (h *TiebreakerHandler) Submit(c *gin.Context) {
+	pool, userID, week, ok := h.resolve(c)
+	if !ok {
+		return
+	}
+
+	var req models.TiebreakerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid_request", err.Error())
+		return
+	}
+
+	result, err := h.service.Submit(c.Request.Context(), pool, userID, week, *req.TotalPoints)
+	if err != nil {
+		h.respondError(c, pool.ID, err)
+		return
+	}
+
+	response.Success(c, result, "Tiebreaker saved")
+}
+
+// resolve loads the pool and the optional ?week=, which may be "current",
+// for a request from any pool member
+func (h *TiebreakerHandler) resolve(c *gin.Context) (*models.Pool, int, *int, bool) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return nil, 0, nil, false
+	}
+
+	poolID, ok := idParam(c, "id")
+	if !ok {
+		return nil, 0, nil, false
+	}
+
+	if _, ok := requireMembership(c, h.store, h.logger, poolID, userID); !ok {
+		return nil, 0, nil, false
+	}
+
+	pool, err := h.store.Pools.Get(c.Request.Context(), poolID)
+	if err != nil {
+		h.logger.Error("Failed to get pool", "pool_id", poolID, "error", err)
+		response.InternalServerError(c, "query_failed", "Failed to retrieve pool")
+		return nil, 0, nil, false
+	}
+
+	var week *int
+	if raw := c.Query("week"); raw != "" {
+		w, ok := weekParam(c, h.calendar, h.logger, raw, pool.Season)
+		if !ok {
+			return nil, 0, nil, false
+		}
+		week = &w
+	}
+
+	return pool, userID, week, true
+}
+
+func (h *TiebreakerHandler) respondError(c *gin.Context, poolID int, err error) {
+	switch {
+	case errors.Is(err, tiebreak.ErrWeekRequired):
+		response.BadRequest(c, "week_required", err.Error())
+	case errors.Is(err, tiebreak.ErrNoGame):
+		response.NotFound(c, "tiebreaker_game_not_found", err.Error())
+	case errors.Is(err, tiebreak.ErrLocked):
+		response.Forbidden(c, "tiebreaker_locked", err.Error())
+	default:
+		h.logger.Error("Tiebreaker request failed", "pool_id", poolID, "error", err)
+		response.InternalServerError(c, "tiebreaker_failed", "Failed to process tiebreaker")
+	}
+}
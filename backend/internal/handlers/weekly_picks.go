@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"touchdown-tally/internal/calendar"
+	"touchdown-tally/internal/config"
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/pickem"
+	"touchdown-tally/internal/store"
+	"touchdown-tally/pkg/logger"
+	"touchdown-tally/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WeeklyPickHandler handles per-game picks in weekly pools
+type WeeklyPickHandler struct {
+	store    *store.Store
+	service  *pickem.Service
+	calendar *calendar.Calendar
+	config   *config.Config
+	logger   *logger.Logger
+}
+
+// NewWeeklyPickHandler creates a new WeeklyPickHandler
+func NewWeeklyPickHandler(st *store.Store, service *pickem.Service, cal *calendar.Calendar, cfg *config.Config, logger *logger.Logger) *WeeklyPickHandler {
+	return &WeeklyPickHandler{
+		store:    st,
+		service:  service,
+		calendar: cal,
+		config:   cfg,
+		logger:   logger,
+	}
+}
+
+// RunGrader grades picks as games become final until ctx is cancelled
+func (h *WeeklyPickHandler) RunGrader(ctx context.Context) {
+	h.service.Run(ctx)
+}
+
+// Get returns the week's games with the caller's picks, and everyone's
+// picks on games that have kicked off
+func (h *WeeklyPickHandler) Get(c *gin.Context) {
+	pool, userID, week, ok := h.resolve(c)
+	if !ok {
+		return
+	}
+
+	picks, err := h.service.Week(c.Request.Context(), pool, userID, week)
+	if err != nil {
+		h.respondError(c, pool.ID, err)
+		return
+	}
+
+	response.Success(c, picks)
+}
+
+// Submit saves the caller's picks for games in the week
+func (h *WeeklyPickHandler) Submit(c *gin.Context) {
+	pool, userID, week, ok := h.resolve(c)
+	if !ok {
+		return
+	}
+
+	var req models.WeeklyPicksRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid_request", err.Error())
+		return
+	}
+
+	picks, err := h.service.Submit(c.Request.Context(), pool, userID, week, req.Picks)
+	if err != nil {
+		h.respondError(c, pool.ID, err)
+		return
+	}
+
+	response.Success(c, picks, "Picks saved")
+}
+
+// resolve loads the pool and week, which may be "current", for a request
+// from any pool member
+func (h *WeeklyPickHandler) resolve(c *gin.Context) (*models.Pool, int, int, bool) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return nil, 0, 0, false
+	}
+
+	poolID, ok := idParam(c, "id")
+	if !ok {
+		return nil, 0, 0, false
+	}
+
+	if _, ok := requireMembership(c, h.store, h.logger, poolID, userID); !ok {
+		return nil, 0, 0, false
+	}
+
+	pool, err := h.store.Pools.Get(c.Request.Context(), poolID)
+	if err != nil {
+		h.logger.Error("Failed to get pool", "pool_id", poolID, "error", err)
+		response.InternalServerError(c, "query_failed", "Failed to retrieve pool")
+		return nil, 0, 0, false
+	}
+
+	week, ok := weekParam(c, h.calendar, h.logger, c.Param("week"), pool.Season)
+	if !ok {
+		return nil, 0, 0, false
+	}
+
+	return pool, userID, week, true
+}
+
+func (h *WeeklyPickHandler) respondError(c *gin.Context, poolID int, err error) {
+	switch {
+	case errors.Is(err, pickem.ErrNotWeeklyPool):
+		response.Conflict(c, "not_weekly_pool", err.Error())
+	case errors.Is(err, pickem.ErrGameLocked):
+		response.Forbidden(c, "picks_locked", err.Error())
+	case errors.Is(err, pickem.ErrGameNotInWeek):
+		response.BadRequest(c, "invalid_game", err.Error())
+	case errors.Is(err, pickem.ErrTeamNotInGame):
+		response.BadRequest(c, "invalid_team", err.Error())
+	case errors.Is(err, pickem.ErrDuplicateGame):
+		response.BadRequest(c, "duplicate_game", err.Error())
+	case errors.Is(err, pickem.ErrConfidenceRequired), errors.Is(err, pickem.ErrConfidenceRange):
+		response.BadRequest(c, "invalid_confidence", err.Error())
+	case errors.Is(err, pickem.ErrConfidenceReused):
+		response.BadRequest(c, "confidence_reused", err.Error())
+	default:
+		h.logger.Error("Weekly pick request failed", "pool_id", poolID, "error", err)
+		response.InternalServerError(c, "weekly_picks_failed", "Failed to process weekly picks")
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/websocket"
 )
 
 // Logger returns a gin.HandlerFunc that logs requests
@@ -66,6 +67,15 @@ func CORS(allowedOrigins []string) gin.HandlerFunc {
 func RequireAuth(cfg *config.Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
+
+		// Browsers cannot set headers on a WebSocket handshake, so the
+		// client passes the token as a query parameter instead
+		if authHeader == "" && websocket.IsWebSocketUpgrade(c.Request) {
+			if token := c.Query("token"); token != "" {
+				authHeader = "Bearer " + token
+			}
+		}
+
 		if authHeader == "" {
 			response.Unauthorized(c, "authorization_required", "Authorization header is required")
 			c.Abort()
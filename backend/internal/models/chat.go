@@ -0,0 +1,107 @@
+package models
+
+import (
+	"time"
+)
+
+// Chat message types
+const (
+	ChatMessageUser       = "user"
+	ChatMessageSystem     = "system"
+	ChatMessageModeration = "moderation_action"
+)
+
+// Chat moderation actions
+const (
+	ModerationDeleteMessage = "delete_message"
+	ModerationMute          = "mute"
+	ModerationTimeout       = "timeout"
+	ModerationUnmute        = "unmute"
+	ModerationClearMessages = "clear_messages"
+)
+
+// ChatCursor is a position in a pool's chat history, which is ordered by
+// when messages were posted and then by message ID
+type ChatCursor struct {
+	CreatedAt time.Time
+	MessageID int
+}
+
+// ChatModerationAction is an entry in a pool's moderation log. MessageIDs
+// lists the messages a delete or clear removed; ExpiresAt is when a mute
+// or timeout ends, nil for a mute until lifted.
+type ChatModerationAction struct {
+	ActionID      int        `json:"action_id" db:"action_id"`
+	PoolID        int        `json:"pool_id" db:"pool_id"`
+	ModeratorID   int        `json:"moderator_id" db:"moderator_id"`
+	ModeratorName string     `json:"moderator_name,omitempty"`
+	Action        string     `json:"action" db:"action"`
+	TargetUserID  int        `json:"target_user_id,omitempty" db:"target_user_id"`
+	TargetName    string     `json:"target_name,omitempty"`
+	MessageIDs    []int      `json:"message_ids,omitempty" db:"message_ids"`
+	Reason        string     `json:"reason,omitempty" db:"reason"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+}
+
+// ChatMute keeps a member from posting in a pool's chat. A timeout also
+// keeps them out of the chat until it ends.
+type ChatMute struct {
+	PoolID    int        `json:"pool_id" db:"pool_id"`
+	UserID    int        `json:"user_id" db:"user_id"`
+	Kind      string     `json:"kind" db:"kind"`
+	MutedBy   int        `json:"muted_by" db:"muted_by"`
+	Reason    string     `json:"reason,omitempty" db:"reason"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// ChatMuteRequest mutes or times out a member. Duration is a Go duration
+// or a number of days such as "2d"; a mute without one lasts until lifted.
+type ChatMuteRequest struct {
+	UserID   int    `json:"user_id" binding:"required"`
+	Duration string `json:"duration"`
+	Reason   string `json:"reason" binding:"max=500"`
+}
+
+// ChatClearRequest deletes a member's messages posted within the window
+// given by Within, the last hour when it is empty
+type ChatClearRequest struct {
+	UserID int    `json:"user_id" binding:"required"`
+	Within string `json:"within"`
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// ChatModerationRequest carries the optional reason for a deletion or unmute
+type ChatModerationRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// ChatArchive describes an export of chat messages removed by a pool's
+// retention policy. The export itself is gzip-compressed JSON Lines, one
+// ArchivedChatMessage per line. ExpiresAt is when the archive itself will
+// be deleted, nil when archives are kept for good.
+type ChatArchive struct {
+	ArchiveID      int        `json:"archive_id" db:"archive_id"`
+	PoolID         int        `json:"pool_id" db:"pool_id"`
+	MessageCount   int        `json:"message_count" db:"message_count"`
+	FirstMessageAt time.Time  `json:"first_message_at" db:"first_message_at"`
+	LastMessageAt  time.Time  `json:"last_message_at" db:"last_message_at"`
+	SizeBytes      int        `json:"size_bytes" db:"size_bytes"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+}
+
+// ArchivedChatMessage is a chat message as written to an archive,
+// including messages moderators had deleted
+type ArchivedChatMessage struct {
+	ID          int        `json:"id"`
+	UserID      int        `json:"user_id"`
+	DisplayName string     `json:"display_name"`
+	Message     string     `json:"message"`
+	MessageType string     `json:"message_type"`
+	Timestamp   time.Time  `json:"timestamp"`
+	Deleted     bool       `json:"deleted,omitempty"`
+	DeletedBy   int        `json:"deleted_by,omitempty"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+}
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"time"
+)
+
+// Draft statuses stored in drafts.status
+const (
+	DraftStatusScheduled  = "scheduled"
+	DraftStatusInProgress = "in_progress"
+	DraftStatusCompleted  = "completed"
+)
+
+// Draft is a pool's snake draft for assigning season teams
+type Draft struct {
+	DraftID      int        `json:"draft_id" db:"draft_id"`
+	PoolID       int        `json:"pool_id" db:"pool_id"`
+	Status       string     `json:"status" db:"status"`
+	Rounds       int        `json:"rounds" db:"rounds"`
+	PickSeconds  int        `json:"pick_seconds" db:"pick_seconds"`
+	ScheduledAt  time.Time  `json:"scheduled_at" db:"scheduled_at"`
+	StartedAt    *time.Time `json:"started_at" db:"started_at"`
+	CompletedAt  *time.Time `json:"completed_at" db:"completed_at"`
+	CurrentPick  int        `json:"current_pick" db:"current_pick"`
+	PickDeadline *time.Time `json:"pick_deadline" db:"pick_deadline"`
+	CreatedBy    int        `json:"created_by" db:"created_by"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// DraftSlot is one member's position in the first round of a draft
+type DraftSlot struct {
+	Slot        int    `json:"slot"`
+	UserID      int    `json:"user_id"`
+	DisplayName string `json:"display_name"`
+	Absent      bool   `json:"absent"` // picked for automatically when on the clock
+}
+
+// DraftPick is a single selection made during a draft
+type DraftPick struct {
+	OverallPick      int       `json:"overall_pick"`
+	Round            int       `json:"round"`
+	UserID           int       `json:"user_id"`
+	TeamID           int       `json:"team_id"`
+	TeamAbbreviation string    `json:"team_abbreviation"`
+	TeamName         string    `json:"team_name"`
+	IsAuto           bool      `json:"is_auto"` // the server picked for the member
+	PickedAt         time.Time `json:"picked_at"`
+}
+
+// DraftQueueEntry is one team in a member's ranked autopick queue
+type DraftQueueEntry struct {
+	Rank             int    `json:"rank"`
+	TeamID           int    `json:"team_id"`
+	TeamAbbreviation string `json:"team_abbreviation"`
+	TeamName         string `json:"team_name"`
+}
+
+// DraftBoard is the full state of a draft as shown to pool members
+type DraftBoard struct {
+	Draft
+	Order      []DraftSlot `json:"order"`
+	Picks      []DraftPick `json:"picks"`
+	OnTheClock *int        `json:"on_the_clock"`
+}
+
+// ScheduleDraftRequest represents a commissioner scheduling a draft
+type ScheduleDraftRequest struct {
+	ScheduledAt time.Time `json:"scheduled_at" binding:"required"`
+	PickSeconds int       `json:"pick_seconds" binding:"omitempty,min=15,max=3600"`
+}
+
+// DraftPickRequest represents a member selecting a team on the clock
+type DraftPickRequest struct {
+	TeamID int `json:"team_id" binding:"required"`
+}
+
+// DraftQueueRequest replaces a member's autopick queue, best team first
+type DraftQueueRequest struct {
+	TeamIDs []int `json:"team_ids" binding:"required,dive,min=1"`
+}
+
+// DraftAbsenceRequest flags a member as absent from the draft. UserID
+// defaults to the caller; only the commissioner may flag someone else.
+type DraftAbsenceRequest struct {
+	UserID int   `json:"user_id"`
+	Absent *bool `json:"absent" binding:"required"`
+}
+
+// Event is a typed frame pushed to a pool's WebSocket clients
+type Event struct {
+	Type      string      `json:"type"`
+	PoolID    int         `json:"pool_id"`
+	Data      interface{} `json:"data"`
+	Timestamp time.Time   `json:"timestamp"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+// GameCorrection is an audit record of a commissioner or admin changing a
+// game's score or status by hand. Original values are the game's as they
+// stood before the change.
+type GameCorrection struct {
+	CorrectionID      int       `json:"correction_id" db:"correction_id"`
+	GameID            int       `json:"game_id" db:"game_id"`
+	CorrectedBy       int       `json:"corrected_by" db:"corrected_by"`
+	CorrectedByName   string    `json:"corrected_by_name,omitempty"`
+	Reason            string    `json:"reason" db:"reason"`
+	OriginalHomeScore int       `json:"original_home_score" db:"original_home_score"`
+	OriginalAwayScore int       `json:"original_away_score" db:"original_away_score"`
+	OriginalStatus    string    `json:"original_status" db:"original_status"`
+	HomeScore         int       `json:"home_score" db:"home_score"`
+	AwayScore         int       `json:"away_score" db:"away_score"`
+	Status            string    `json:"status" db:"status"`
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+}
+
+// GameCorrectionRequest overrides a game's score, status or both. Fields
+// left out keep their current values.
+type GameCorrectionRequest struct {
+	HomeScore *int   `json:"home_score" binding:"omitempty,min=0"`
+	AwayScore *int   `json:"away_score" binding:"omitempty,min=0"`
+	Status    string `json:"status"`
+	Reason    string `json:"reason" binding:"required,max=500"`
+}
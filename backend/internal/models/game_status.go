@@ -0,0 +1,143 @@
+package models
+
+import (
+	"time"
+)
+
+// gameTransitions lists the statuses a game may move to from each status.
+// Every write follows them except an admin's hand correction, which may
+// undo a wrong status.
+var gameTransitions = map[string][]string{
+	GameStatusScheduled:   {GameStatusInProgress, GameStatusPostponed},
+	GameStatusInProgress:  {GameStatusHalftime, GameStatusCompleted},
+	GameStatusHalftime:    {GameStatusInProgress, GameStatusCompleted},
+	GameStatusPostponed:   {GameStatusRescheduled},
+	GameStatusRescheduled: {GameStatusInProgress, GameStatusPostponed},
+	GameStatusCompleted:   {GameStatusCorrected},
+	GameStatusCorrected:   {},
+}
+
+// GameTransition is one change of a game's status
+type GameTransition struct {
+	GameID     int       `json:"game_id"`
+	SeasonYear int       `json:"season_year"`
+	Week       int       `json:"week"`
+	From       string    `json:"from"`
+	To         string    `json:"to"`
+	At         time.Time `json:"at"`
+}
+
+// ValidGameStatus reports whether status is a known game status
+func ValidGameStatus(status string) bool {
+	_, ok := gameTransitions[status]
+	return ok
+}
+
+// GameStatusPath returns the shortest run of allowed transitions from one
+// status to another, so a feed that skipped a step (a game seen scheduled and
+// then completed) still moves forward through each status in turn. It
+// returns false when to cannot be reached from from, and an empty path when
+// they are the same.
+func GameStatusPath(from, to string) ([]string, bool) {
+	if !ValidGameStatus(from) || !ValidGameStatus(to) {
+		return nil, false
+	}
+	if from == to {
+		return nil, true
+	}
+
+	previous := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		status := queue[0]
+		queue = queue[1:]
+
+		for _, next := range gameTransitions[status] {
+			if _, seen := previous[next]; seen {
+				continue
+			}
+			previous[next] = status
+			if next != to {
+				queue = append(queue, next)
+				continue
+			}
+
+			var path []string
+			for s := to; s != from; s = previous[s] {
+				path = append([]string{s}, path...)
+			}
+			return path, true
+		}
+	}
+	return nil, false
+}
+
+// IsLiveStatus reports whether a game with status is being played
+func IsLiveStatus(status string) bool {
+	return status == GameStatusInProgress || status == GameStatusHalftime
+}
+
+// IsFinalStatus reports whether a game with status is over
+func IsFinalStatus(status string) bool {
+	return status == GameStatusCompleted || status == GameStatusCorrected
+}
+
+// IsPendingStatus reports whether a game with status has yet to kick off
+func IsPendingStatus(status string) bool {
+	return status == GameStatusScheduled || status == GameStatusPostponed || status == GameStatusRescheduled
+}
+
+// IsLive reports whether the game is being played
+func (g NFLGame) IsLive() bool {
+	return IsLiveStatus(g.Status)
+}
+
+// IsFinal reports whether the game is over
+func (g NFLGame) IsFinal() bool {
+	return IsFinalStatus(g.Status)
+}
+
+// GameUpdate is the live state of a game pushed to clients whenever the
+// game changes
+type GameUpdate struct {
+	GameID        int       `json:"game_id"`
+	SeasonYear    int       `json:"season_year"`
+	Week          int       `json:"week"`
+	HomeTeamID    int       `json:"home_team_id"`
+	AwayTeamID    int       `json:"away_team_id"`
+	HomeTeam      string    `json:"home_team"`
+	AwayTeam      string    `json:"away_team"`
+	HomeScore     int       `json:"home_score"`
+	AwayScore     int       `json:"away_score"`
+	Status        string    `json:"status"`
+	Quarter       int       `json:"quarter"`
+	TimeRemaining string    `json:"time_remaining"`
+	LastUpdated   time.Time `json:"last_updated"`
+}
+
+// NewGameUpdate returns the live state of game
+func NewGameUpdate(game GameWithTeams) GameUpdate {
+	return GameUpdate{
+		GameID:        game.GameID,
+		SeasonYear:    game.SeasonYear,
+		Week:          game.Week,
+		HomeTeamID:    game.HomeTeamID,
+		AwayTeamID:    game.AwayTeamID,
+		HomeTeam:      game.HomeTeam.TeamAbbreviation,
+		AwayTeam:      game.AwayTeam.TeamAbbreviation,
+		HomeScore:     game.HomeScore,
+		AwayScore:     game.AwayScore,
+		Status:        game.Status,
+		Quarter:       game.Quarter,
+		TimeRemaining: game.TimeRemaining,
+		LastUpdated:   game.LastUpdated,
+	}
+}
+
+// StandingsUpdate is a pool's season standings pushed to its clients while
+// games involving its members' teams change. Games lists those games.
+type StandingsUpdate struct {
+	PoolID    int              `json:"pool_id"`
+	Standings []StandingsEntry `json:"standings"`
+	Games     []GameUpdate     `json:"games"`
+}
@@ -15,6 +15,7 @@ type Pool struct {
 	EntryFee       float64   `json:"entry_fee" db:"entry_fee"`
 	IsActive       string    `json:"is_active" db:"status"`
 	CreatedBy      int       `json:"created_by" db:"commissioner_id"`
+	Settings       PoolSettings `json:"settings" db:"settings"`
 	CreatedAt      time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
 	
@@ -42,6 +43,52 @@ type UserProfile struct {
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 }
 
+// Pool role names as stored in the roles table
+const (
+	RoleCommissioner = "commissioner"
+	RoleMember       = "member"
+	RoleModerator    = "moderator"
+)
+
+// Pool formats stored in pools.pool_type
+const (
+	// PoolTypeSeason pools have each member own up to four teams for the season
+	PoolTypeSeason = "season"
+	// PoolTypeSurvivor pools back one unused team a week until it loses
+	PoolTypeSurvivor = "survivor"
+	// PoolTypeWeekly pools pick the winner of every game each week
+	PoolTypeWeekly = "weekly"
+	// PoolTypeConfidence pools are weekly pools where members also rank the
+	// week's games from N down to 1 and score the rank of each correct pick
+	PoolTypeConfidence = "confidence"
+)
+
+// Pool statuses stored in pools.status
+const (
+	PoolStatusActive    = "active"
+	PoolStatusCompleted = "completed"
+)
+
+// Game statuses stored in nfl_games.status. See GameStatusPath for the
+// changes allowed between them.
+const (
+	GameStatusScheduled   = "scheduled"
+	GameStatusInProgress  = "in_progress"
+	GameStatusHalftime    = "halftime"
+	GameStatusCompleted   = "completed"
+	GameStatusPostponed   = "postponed"
+	GameStatusRescheduled = "rescheduled"
+	// GameStatusCorrected is a final game whose score was changed after it ended
+	GameStatusCorrected = "corrected"
+)
+
+// Game types stored in nfl_games.game_type
+const (
+	GameTypeRegular   = "regular"
+	GameTypePlayoff   = "playoff"
+	GameTypeSuperBowl = "superbowl"
+)
+
 // Role represents user roles within pools
 type Role struct {
 	RoleID      int    `json:"role_id" db:"role_id"`
@@ -87,10 +134,29 @@ type NFLGame struct {
 	Status        string    `json:"status" db:"status"`
 	Quarter       int       `json:"quarter" db:"quarter"`
 	TimeRemaining string    `json:"time_remaining" db:"time_remaining"`
+	// Overridden marks a game corrected by hand, which the score feed no
+	// longer updates
+	Overridden    bool      `json:"overridden" db:"overridden"`
 	LastUpdated   time.Time `json:"last_updated" db:"last_updated"`
 	CreatedAt     time.Time `json:"created_at" db:"created_at"`
 }
 
+// WinnerID returns the winning team of a final game, or 0 when the game is
+// not final or ended in a tie
+func (g NFLGame) WinnerID() int {
+	if !g.IsFinal() {
+		return 0
+	}
+	switch {
+	case g.HomeScore > g.AwayScore:
+		return g.HomeTeamID
+	case g.AwayScore > g.HomeScore:
+		return g.AwayTeamID
+	default:
+		return 0
+	}
+}
+
 // SeasonPick represents a user's team selection for the season
 type SeasonPick struct {
 	PickID          int       `json:"pick_id" db:"pick_id"`
@@ -100,18 +166,48 @@ type SeasonPick struct {
 	PickOrder       int       `json:"pick_order" db:"pick_order"`
 	PointsScored    int       `json:"points_scored" db:"points_scored"`
 	IsEliminated    bool      `json:"is_eliminated" db:"is_eliminated"`
-	EliminationWeek int       `json:"elimination_week" db:"elimination_week"`
+	EliminationWeek *int      `json:"elimination_week" db:"elimination_week"`
 	CreatedAt       time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
 }
 
+// Pick override actions stored in pick_overrides.action
+const (
+	PickActionCreate = "create"
+	PickActionUpdate = "update"
+	PickActionDelete = "delete"
+)
+
+// PickOverride is an audit record of a commissioner changing a season pick
+// after the pool's picks locked
+type PickOverride struct {
+	OverrideID       int       `json:"override_id" db:"override_id"`
+	PoolID           int       `json:"pool_id" db:"pool_id"`
+	PickID           *int      `json:"pick_id" db:"pick_id"`
+	UserID           int       `json:"user_id" db:"user_id"`
+	CommissionerID   int       `json:"commissioner_id" db:"commissioner_id"`
+	Action           string    `json:"action" db:"action"`
+	TeamID           *int      `json:"team_id" db:"team_id"`
+	PickOrder        *int      `json:"pick_order" db:"pick_order"`
+	Note             string    `json:"note" db:"note"`
+	CreatedAt        time.Time `json:"created_at" db:"created_at"`
+	CommissionerName string    `json:"commissioner_name,omitempty"`
+}
+
+// PickLockStatus reports whether a pool's season picks have locked
+type PickLockStatus struct {
+	Policy   string     `json:"policy"`
+	Deadline *time.Time `json:"deadline"`
+	Locked   bool       `json:"locked"`
+}
+
 // ChatMessage represents a chat message in a pool
 type ChatMessage struct {
-	ID          string    `json:"id,omitempty" db:"id"`
-	PoolID      string    `json:"pool_id" db:"pool_id"`
-	UserID      string    `json:"user_id" db:"user_id"`
+	ID          int       `json:"id,omitempty" db:"message_id"`
+	PoolID      int       `json:"pool_id" db:"pool_id"`
+	UserID      int       `json:"user_id" db:"user_id"`
 	DisplayName string    `json:"display_name" db:"display_name"`
-	Message     string    `json:"message" db:"message"`
+	Message     string    `json:"message" db:"content"`
 	MessageType string    `json:"message_type" db:"message_type"`
 	Timestamp   time.Time `json:"timestamp" db:"created_at"`
 }
@@ -145,15 +241,21 @@ type CreatePoolRequest struct {
 	SeasonYear     int                    `json:"season_year" binding:"required,min=2020,max=2030"`
 	MaxMembers     int                    `json:"max_members" binding:"min=2,max=100"`
 	EntryFee       float64               `json:"entry_fee" binding:"min=0"`
+	PoolType       string                 `json:"pool_type" binding:"omitempty,oneof=season survivor weekly confidence"`
 	PrizeStructure map[string]interface{} `json:"prize_structure"`
-	Settings       map[string]interface{} `json:"settings"`
+	Settings       PoolSettings           `json:"settings"`
 }
 
-// CreatePickRequest represents pick creation request data
+// CreatePickRequest represents pick creation request data. Note is required
+// when the commissioner changes picks after they lock. UserID names the
+// member a commissioner override creates the pick for; it defaults to the
+// caller and is ignored by updates.
 type CreatePickRequest struct {
-	PoolID    int `json:"pool_id" binding:"required"`
-	TeamID    int `json:"team_id" binding:"required"`
-	PickOrder int `json:"pick_order" binding:"required,min=1,max=4"`
+	PoolID    int    `json:"pool_id" binding:"required"`
+	TeamID    int    `json:"team_id" binding:"required"`
+	PickOrder int    `json:"pick_order" binding:"required,min=1,max=4"`
+	Note      string `json:"note" binding:"max=500"`
+	UserID    int    `json:"user_id,omitempty" binding:"omitempty,min=1"`
 }
 
 // ErrorResponse represents API error response
@@ -172,13 +274,56 @@ type SuccessResponse struct {
 
 // StandingsEntry represents a single entry in pool standings
 type StandingsEntry struct {
-	UserID      int    `json:"user_id"`
-	Username    string `json:"username"`
-	DisplayName string `json:"display_name"`
-	TotalPoints int    `json:"total_points"`
-	Rank        int    `json:"rank"`
-	TeamsPicked int    `json:"teams_picked"`
-	Eliminated  bool   `json:"eliminated"`
+	UserID      int          `json:"user_id"`
+	Username    string       `json:"username"`
+	DisplayName string       `json:"display_name"`
+	TotalPoints int          `json:"total_points"`
+	Rank        int          `json:"rank"`
+	Tied        bool         `json:"tied"`
+	TeamsPicked int          `json:"teams_picked"`
+	Wins        int          `json:"wins"`
+	Losses      int          `json:"losses"`
+	Ties        int          `json:"ties"`
+	Eliminated  bool         `json:"eliminated"`
+	Teams       []TeamRecord `json:"teams,omitempty"`
+
+	// Survivor pools record when a member went out
+	EliminationWeek *int `json:"elimination_week,omitempty"`
+
+	// Weekly pools count picks instead of owned teams' results
+	PicksMade    int          `json:"picks_made"`
+	PicksCorrect int          `json:"picks_correct"`
+	Weeks        []WeekTotals `json:"weeks,omitempty"`
+
+	// Tiebreaker is the member's predicted total for the tiebreaker game.
+	// TiebreakRule names the rule that ordered the member among those level
+	// with them on points: against the member ranked just above, or for the
+	// first of them, against the member just below.
+	Tiebreaker   *int   `json:"tiebreaker,omitempty"`
+	TiebreakRule string `json:"tiebreak_rule,omitempty"`
+}
+
+// WeekTotals is a member's result for one week of a weekly pool
+type WeekTotals struct {
+	Week         int `json:"week"`
+	Points       int `json:"points"`
+	PicksMade    int `json:"picks_made"`
+	PicksCorrect int `json:"picks_correct"`
+}
+
+// TeamRecord is one owned team's results as counted toward standings
+type TeamRecord struct {
+	PickID           int    `json:"pick_id"`
+	PickOrder        int    `json:"pick_order"`
+	TeamID           int    `json:"team_id"`
+	TeamAbbreviation string `json:"team_abbreviation"`
+	TeamName         string `json:"team_name"`
+	Wins             int    `json:"wins"`
+	Losses           int    `json:"losses"`
+	Ties             int    `json:"ties"`
+	PointsFor        int    `json:"points_for"`
+	PointsAgainst    int    `json:"points_against"`
+	Points           int    `json:"points"`
 }
 
 // ChatMessageWithUser represents a chat message with user information
@@ -216,59 +361,5 @@ type PoolMember struct {
 	Role        string    `json:"role"`
 	JoinedAt    time.Time `json:"joined_at"`
 	DisplayName string    `json:"display_name"`
-	Email       string    `json:"email"`
-}
-
-// Standing represents a user's standing in a pool
-type Standing struct {
-	Position        int      `json:"position"`
-	UserID          string   `json:"user_id"`
-	DisplayName     string   `json:"display_name"`
-	TotalPicks      int      `json:"total_picks"`
-	CorrectPicks    int      `json:"correct_picks"`
-	IncorrectPicks  int      `json:"incorrect_picks"`
-	WinPercentage   float64  `json:"win_percentage"`
-	Week            *int     `json:"week,omitempty"`
-}
-
-// UserStats represents detailed statistics for a user
-type UserStats struct {
-	UserID         string         `json:"user_id"`
-	DisplayName    string         `json:"display_name"`
-	PoolID         string         `json:"pool_id"`
-	Season         int            `json:"season"`
-	PoolType       string         `json:"pool_type"`
-	TotalPicks     int            `json:"total_picks"`
-	CorrectPicks   int            `json:"correct_picks"`
-	IncorrectPicks int            `json:"incorrect_picks"`
-	WinPercentage  float64        `json:"win_percentage"`
-	WeeklyStats    []WeeklyStats  `json:"weekly_stats,omitempty"`
-	RecentPicks    []RecentPick   `json:"recent_picks,omitempty"`
-}
-
-// WeeklyStats represents statistics for a specific week
-type WeeklyStats struct {
-	Week           int     `json:"week"`
-	TotalPicks     int     `json:"total_picks"`
-	CorrectPicks   int     `json:"correct_picks"`
-	IncorrectPicks int     `json:"incorrect_picks"`
-	WinPercentage  float64 `json:"win_percentage"`
-}
-
-// RecentPick represents a recent pick with game details
-type RecentPick struct {
-	GameID         string     `json:"game_id"`
-	Week           int        `json:"week"`
-	GameDate       time.Time  `json:"game_date"`
-	Status         string     `json:"status"`
-	HomeTeam       string     `json:"home_team"`
-	HomeTeamName   string     `json:"home_team_name"`
-	AwayTeam       string     `json:"away_team"`
-	AwayTeamName   string     `json:"away_team_name"`
-	HomeScore      *int       `json:"home_score"`
-	AwayScore      *int       `json:"away_score"`
-	PickedTeam     string     `json:"picked_team"`
-	PickedTeamName string     `json:"picked_team_name"`
-	Confidence     *int       `json:"confidence"`
-	IsCorrect      *bool      `json:"is_correct"`
+	Username    string    `json:"username"`
 }
@@ -0,0 +1,25 @@
+package models
+
+// ScheduleImport reports the outcome of importing a season schedule file
+type ScheduleImport struct {
+	SeasonYear int `json:"season_year"`
+	// Games counts rows that matched two teams and were written
+	Games int `json:"games"`
+	// Changed counts games added or updated; re-importing the same file
+	// changes nothing
+	Changed int `json:"changed"`
+	// Skipped lists games already under way or final, which an import
+	// leaves to the score feed
+	Skipped []int `json:"skipped"`
+	// Unmatched lists rows that could not be imported
+	Unmatched []ScheduleRowError `json:"unmatched"`
+}
+
+// ScheduleRowError is a schedule row that could not be imported. Line is
+// the row's line in the file; for iCalendar files it is the line of the
+// event's BEGIN:VEVENT.
+type ScheduleRowError struct {
+	Line   int    `json:"line"`
+	Row    string `json:"row"`
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// Season phases reported by SeasonWeek
+const (
+	SeasonPhasePreseason = "preseason"
+	SeasonPhaseRegular   = "regular_season"
+	SeasonPhasePlayoffs  = "playoffs"
+	SeasonPhaseComplete  = "complete"
+)
+
+// SeasonWeek is the NFL week in play: its games, the teams on bye and when
+// it hands over to the next week. StartsAt is nil for the first week.
+type SeasonWeek struct {
+	SeasonYear   int             `json:"season_year"`
+	Week         int             `json:"week"`
+	GameType     string          `json:"game_type"`
+	Phase        string          `json:"phase"`
+	StartsAt     *time.Time      `json:"starts_at"`
+	EndsAt       time.Time       `json:"ends_at"`
+	FirstKickoff time.Time       `json:"first_kickoff"`
+	Games        []GameWithTeams `json:"games"`
+	ByeTeams     []NFLTeam       `json:"bye_teams"`
+}
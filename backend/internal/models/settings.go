@@ -0,0 +1,281 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// PoolSettings is the typed form of the pools.settings JSON column. Decode
+// into DefaultPoolSettings() so that omitted fields keep their defaults.
+type PoolSettings struct {
+	Scoring ScoringRules  `json:"scoring"`
+	Draft   DraftSettings `json:"draft"`
+	Locks   LockSettings  `json:"locks"`
+
+	Survivor SurvivorSettings `json:"survivor"`
+	Tiebreak TiebreakSettings `json:"tiebreak"`
+	Chat     ChatSettings     `json:"chat"`
+}
+
+// ScoringRules controls how an owned team's games turn into standings points.
+// For each completed game a team earns its result points, plus PointScored
+// for every point it put on the board, plus DivisionalWinBonus when it beats
+// a division rival. Playoff and Super Bowl games multiply that total.
+type ScoringRules struct {
+	Win                 int `json:"win"`
+	Tie                 int `json:"tie"`
+	LossPenalty         int `json:"loss_penalty"`
+	PointScored         int `json:"point_scored"`
+	DivisionalWinBonus  int `json:"divisional_win_bonus"`
+	PlayoffMultiplier   int `json:"playoff_multiplier"`
+	SuperBowlMultiplier int `json:"super_bowl_multiplier"`
+}
+
+// DraftSettings controls automatic draft picks. DefaultRanking lists team
+// IDs best first and is used once a member's own queue has nothing left;
+// teams it does not mention follow in the usual team listing order.
+type DraftSettings struct {
+	DefaultRanking []int `json:"default_ranking"`
+}
+
+// Pick lock policies stored in LockSettings.Policy
+const (
+	// LockPolicySeasonStart locks picks at the season's first kickoff
+	LockPolicySeasonStart = "season_start"
+	// LockPolicyDeadline locks picks at LockSettings.Deadline
+	LockPolicyDeadline = "deadline"
+	// LockPolicyNone never locks picks
+	LockPolicyNone = "none"
+)
+
+// LockSettings controls when season picks freeze. After the deadline only
+// the commissioner can change picks, and each change needs an audit note.
+type LockSettings struct {
+	Policy   string     `json:"policy"`
+	Deadline *time.Time `json:"deadline,omitempty"` // used by LockPolicyDeadline
+}
+
+// What happens to a survivor member who has no pick when a week locks,
+// stored in SurvivorSettings.MissedPick
+const (
+	MissedPickEliminate  = "eliminate"
+	MissedPickAutoAssign = "auto_assign"
+)
+
+// SurvivorSettings controls how survivor pools knock members out. A loss
+// always eliminates; a tie only does when TieEliminates is set.
+type SurvivorSettings struct {
+	TieEliminates bool   `json:"tie_eliminates"`
+	MissedPick    string `json:"missed_pick"`
+}
+
+// Games members predict the total score of, stored in TiebreakSettings.Game
+const (
+	// TiebreakGameMondayNight uses the last game to kick off each week
+	TiebreakGameMondayNight = "monday_night"
+	// TiebreakGameSuperBowl uses the season's Super Bowl
+	TiebreakGameSuperBowl = "super_bowl"
+)
+
+// Rules for ordering members level on points, stored in TiebreakSettings.Rules
+const (
+	// TiebreakClosest favours the prediction closest to the tiebreaker
+	// game's total without going over it
+	TiebreakClosest = "closest_without_going_over"
+	// TiebreakHeadToHead favours the member who won more of the games where
+	// the tied members' picks met
+	TiebreakHeadToHead = "head_to_head"
+	// TiebreakEarliestPick favours the member who finished their picks first
+	TiebreakEarliestPick = "earliest_pick"
+)
+
+// TiebreakSettings chooses the tiebreaker game and the order tiebreak rules
+// are applied in. Members still level after every rule share a rank.
+type TiebreakSettings struct {
+	Game  string   `json:"game"`
+	Rules []string `json:"rules"`
+}
+
+// ChatSettings controls how much chat history a pool keeps. Messages
+// beyond the newest KeepMessages or older than KeepDays are archived and
+// removed; zero leaves that limit off. A pool with neither set keeps the
+// server's default number of messages.
+type ChatSettings struct {
+	KeepMessages int `json:"keep_messages"`
+	KeepDays     int `json:"keep_days"`
+}
+
+// Limits on individual scoring values, to catch typos like 1000 points per win
+const (
+	maxResultPoints = 100
+	maxPointScored  = 10
+	maxMultiplier   = 10
+)
+
+// Limits on chat retention
+const (
+	minKeepMessages = 50
+	maxKeepMessages = 100000
+	maxKeepDays     = 3650
+)
+
+// DefaultPoolSettings returns the settings used when a pool does not override them
+func DefaultPoolSettings() PoolSettings {
+	return PoolSettings{
+		Scoring: DefaultScoringRules(),
+		Locks:   LockSettings{Policy: LockPolicySeasonStart},
+
+		Survivor: SurvivorSettings{MissedPick: MissedPickEliminate},
+		Tiebreak: TiebreakSettings{
+			Game:  TiebreakGameMondayNight,
+			Rules: []string{TiebreakClosest, TiebreakHeadToHead, TiebreakEarliestPick},
+		},
+	}
+}
+
+// DefaultScoringRules awards two points per win and one per tie
+func DefaultScoringRules() ScoringRules {
+	return ScoringRules{
+		Win:                 2,
+		Tie:                 1,
+		PlayoffMultiplier:   1,
+		SuperBowlMultiplier: 1,
+	}
+}
+
+// Validate checks every section of the settings
+func (s PoolSettings) Validate() error {
+	if err := s.Scoring.Validate(); err != nil {
+		return fmt.Errorf("scoring: %w", err)
+	}
+	if err := s.Draft.Validate(); err != nil {
+		return fmt.Errorf("draft: %w", err)
+	}
+	if err := s.Locks.Validate(); err != nil {
+		return fmt.Errorf("locks: %w", err)
+	}
+	if err := s.Survivor.Validate(); err != nil {
+		return fmt.Errorf("survivor: %w", err)
+	}
+	if err := s.Tiebreak.Validate(); err != nil {
+		return fmt.Errorf("tiebreak: %w", err)
+	}
+	if err := s.Chat.Validate(); err != nil {
+		return fmt.Errorf("chat: %w", err)
+	}
+	return nil
+}
+
+// Validate checks the retention limits are off or within range
+func (c ChatSettings) Validate() error {
+	if c.KeepMessages != 0 && (c.KeepMessages < minKeepMessages || c.KeepMessages > maxKeepMessages) {
+		return fmt.Errorf("keep_messages must be 0 or between %d and %d", minKeepMessages, maxKeepMessages)
+	}
+	if c.KeepDays < 0 || c.KeepDays > maxKeepDays {
+		return fmt.Errorf("keep_days must be between 0 and %d", maxKeepDays)
+	}
+	return nil
+}
+
+// Validate checks the tiebreaker game and that each rule is known and used once
+func (t TiebreakSettings) Validate() error {
+	switch t.Game {
+	case TiebreakGameMondayNight, TiebreakGameSuperBowl:
+	default:
+		return fmt.Errorf("game must be %s or %s", TiebreakGameMondayNight, TiebreakGameSuperBowl)
+	}
+
+	seen := make(map[string]bool, len(t.Rules))
+	for _, rule := range t.Rules {
+		switch rule {
+		case TiebreakClosest, TiebreakHeadToHead, TiebreakEarliestPick:
+		default:
+			return fmt.Errorf("unknown rule %q", rule)
+		}
+		if seen[rule] {
+			return fmt.Errorf("rule %q is listed more than once", rule)
+		}
+		seen[rule] = true
+	}
+	return nil
+}
+
+// Validate checks the missed pick policy
+func (s SurvivorSettings) Validate() error {
+	switch s.MissedPick {
+	case MissedPickEliminate, MissedPickAutoAssign:
+		return nil
+	default:
+		return fmt.Errorf("missed_pick must be %s or %s", MissedPickEliminate, MissedPickAutoAssign)
+	}
+}
+
+// Equal reports whether both settings lock picks at the same moment
+func (l LockSettings) Equal(other LockSettings) bool {
+	if l.Policy != other.Policy {
+		return false
+	}
+	if l.Deadline == nil || other.Deadline == nil {
+		return l.Deadline == other.Deadline
+	}
+	return l.Deadline.Equal(*other.Deadline)
+}
+
+// Validate checks the policy name and that a fixed deadline has a time
+func (l LockSettings) Validate() error {
+	switch l.Policy {
+	case LockPolicySeasonStart, LockPolicyNone:
+		return nil
+	case LockPolicyDeadline:
+		if l.Deadline == nil {
+			return errors.New("deadline is required for the deadline policy")
+		}
+		return nil
+	default:
+		return fmt.Errorf("policy must be one of %s, %s or %s", LockPolicySeasonStart, LockPolicyDeadline, LockPolicyNone)
+	}
+}
+
+// Validate checks that the default ranking lists each team at most once
+func (d DraftSettings) Validate() error {
+	seen := make(map[int]bool, len(d.DefaultRanking))
+	for _, teamID := range d.DefaultRanking {
+		if teamID <= 0 {
+			return fmt.Errorf("default_ranking has invalid team id %d", teamID)
+		}
+		if seen[teamID] {
+			return fmt.Errorf("default_ranking lists team %d more than once", teamID)
+		}
+		seen[teamID] = true
+	}
+	return nil
+}
+
+// Validate checks that every rule is within its allowed range
+func (r ScoringRules) Validate() error {
+	checks := []struct {
+		name     string
+		value    int
+		min, max int
+	}{
+		{"win", r.Win, 0, maxResultPoints},
+		{"tie", r.Tie, 0, maxResultPoints},
+		{"loss_penalty", r.LossPenalty, 0, maxResultPoints},
+		{"point_scored", r.PointScored, 0, maxPointScored},
+		{"divisional_win_bonus", r.DivisionalWinBonus, 0, maxResultPoints},
+		{"playoff_multiplier", r.PlayoffMultiplier, 1, maxMultiplier},
+		{"super_bowl_multiplier", r.SuperBowlMultiplier, 1, maxMultiplier},
+	}
+
+	for _, check := range checks {
+		if check.value < check.min || check.value > check.max {
+			return fmt.Errorf("%s must be between %d and %d", check.name, check.min, check.max)
+		}
+	}
+
+	if r.Win < r.Tie {
+		return errors.New("win must be worth at least as much as tie")
+	}
+	return nil
+}
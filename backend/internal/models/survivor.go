@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+)
+
+// Survivor pick results stored in survivor_picks.result
+const (
+	SurvivorResultPending = "pending"
+	SurvivorResultWin     = "win"
+	SurvivorResultLoss    = "loss"
+	SurvivorResultTie     = "tie"
+)
+
+// Reasons a member was knocked out, stored in survivor_eliminations.reason
+const (
+	EliminatedByLoss       = "loss"
+	EliminatedByTie        = "tie"
+	EliminatedByMissedPick = "missed_pick"
+)
+
+// SurvivorPick is the one team a member backs in a week of a survivor pool
+type SurvivorPick struct {
+	SurvivorPickID   int       `json:"survivor_pick_id" db:"survivor_pick_id"`
+	PoolID           int       `json:"pool_id" db:"pool_id"`
+	UserID           int       `json:"user_id" db:"user_id"`
+	DisplayName      string    `json:"display_name"`
+	Week             int       `json:"week" db:"week"`
+	TeamID           int       `json:"team_id" db:"team_id"`
+	TeamAbbreviation string    `json:"team_abbreviation"`
+	TeamName         string    `json:"team_name"`
+	GameID           int       `json:"game_id" db:"game_id"`
+	Result           string    `json:"result" db:"result"`
+	IsAuto           bool      `json:"is_auto" db:"is_auto"` // assigned by the server after a missed pick
+	CreatedAt        time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// SurvivorElimination records the week a member was knocked out
+type SurvivorElimination struct {
+	PoolID    int       `json:"pool_id" db:"pool_id"`
+	UserID    int       `json:"user_id" db:"user_id"`
+	Week      int       `json:"week" db:"week"`
+	Reason    string    `json:"reason" db:"reason"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// SurvivorEntry is one member's run through a survivor pool. Picks for a
+// week are only shown to other members once that week has locked.
+type SurvivorEntry struct {
+	UserID            int            `json:"user_id"`
+	DisplayName       string         `json:"display_name"`
+	Alive             bool           `json:"alive"`
+	EliminationWeek   *int           `json:"elimination_week"`
+	EliminationReason string         `json:"elimination_reason,omitempty"`
+	Winner            bool           `json:"winner"`
+	Picks             []SurvivorPick `json:"picks"`
+}
+
+// SurvivorBoard is the state of a survivor pool. Once Finished, Winners
+// holds the last member standing, or everyone who went out in the final
+// week when the pool ends in a split.
+type SurvivorBoard struct {
+	PoolID   int             `json:"pool_id"`
+	Season   int             `json:"season"`
+	Finished bool            `json:"finished"`
+	Winners  []int           `json:"winners"`
+	Entries  []SurvivorEntry `json:"entries"`
+}
+
+// SurvivorPickRequest picks a team for one week
+type SurvivorPickRequest struct {
+	TeamID int `json:"team_id" binding:"required"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+)
+
+// Tiebreaker is a member's prediction of the total points scored in a pool's
+// tiebreaker game
+type Tiebreaker struct {
+	TiebreakerID int       `json:"tiebreaker_id" db:"tiebreaker_id"`
+	PoolID       int       `json:"pool_id" db:"pool_id"`
+	UserID       int       `json:"user_id" db:"user_id"`
+	DisplayName  string    `json:"display_name"`
+	GameID       int       `json:"game_id" db:"game_id"`
+	TotalPoints  int       `json:"total_points" db:"total_points"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// TiebreakerGame is a pool's tiebreaker game as shown to a member.
+// Predictions holds everyone's entries once the game has kicked off.
+type TiebreakerGame struct {
+	PoolID      int           `json:"pool_id"`
+	Game        GameWithTeams `json:"game"`
+	Locked      bool          `json:"locked"`
+	MyEntry     *Tiebreaker   `json:"my_entry"`
+	Predictions []Tiebreaker  `json:"predictions"`
+}
+
+// TiebreakerRequest predicts the total points of the tiebreaker game
+type TiebreakerRequest struct {
+	TotalPoints *int `json:"total_points" binding:"required,min=0,max=200"`
+}
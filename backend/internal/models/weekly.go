@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+)
+
+// WeeklyPick is a member's choice of winner for one game in a weekly pool
+type WeeklyPick struct {
+	WeeklyPickID int       `json:"weekly_pick_id" db:"weekly_pick_id"`
+	PoolID       int       `json:"pool_id" db:"pool_id"`
+	UserID       int       `json:"user_id" db:"user_id"`
+	DisplayName  string    `json:"display_name"`
+	GameID       int       `json:"game_id" db:"game_id"`
+	Week         int       `json:"week"`
+	TeamID       int       `json:"team_id" db:"picked_team_id"`
+	Confidence   *int      `json:"confidence" db:"confidence"` // confidence pools only
+	IsCorrect    *bool     `json:"is_correct" db:"is_correct"` // nil until the game is final
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Value is what the pick is worth when correct: its confidence in
+// confidence pools and one point otherwise
+func (p WeeklyPick) Value(poolType string) int {
+	if poolType != PoolTypeConfidence {
+		return 1
+	}
+	if p.Confidence == nil {
+		return 0
+	}
+	return *p.Confidence
+}
+
+// PicksWeekly reports whether the pool is scored from weekly game picks
+// rather than season-long team ownership
+func (p Pool) PicksWeekly() bool {
+	return p.PoolType == PoolTypeWeekly || p.PoolType == PoolTypeConfidence
+}
+
+// WeekGame is one game of a pick'em week as shown to a member. Picks holds
+// every member's pick once the game has locked and is empty before then.
+type WeekGame struct {
+	GameWithTeams
+	Locked bool         `json:"locked"`
+	MyPick *WeeklyPick  `json:"my_pick"`
+	Picks  []WeeklyPick `json:"picks"`
+}
+
+// WeekPicks is a member's view of a pool's pick'em week. In confidence
+// pools MaxConfidence is the number of games, the highest value a pick can
+// be given, and Points adds up the confidence of each correct pick.
+type WeekPicks struct {
+	PoolID        int        `json:"pool_id"`
+	Season        int        `json:"season"`
+	Week          int        `json:"week"`
+	Games         []WeekGame `json:"games"`
+	Correct       int        `json:"correct"` // the member's correct picks so far
+	Points        int        `json:"points"`
+	MaxConfidence int        `json:"max_confidence,omitempty"`
+}
+
+// WeeklyPicksRequest submits or changes picks for games in one week. Games
+// not listed keep their existing picks.
+type WeeklyPicksRequest struct {
+	Picks []WeeklyPickInput `json:"picks" binding:"required,min=1,dive"`
+}
+
+// WeeklyPickInput picks the winner of a single game. Confidence is required
+// in confidence pools and ignored elsewhere.
+type WeeklyPickInput struct {
+	GameID     int `json:"game_id" binding:"required"`
+	TeamID     int `json:"team_id" binding:"required"`
+	Confidence int `json:"confidence" binding:"min=0"`
+}
@@ -0,0 +1,222 @@
+// Package pickem runs weekly pools, where members pick the winner of every
+// game each week and score a point for each one they get right, and
+// confidence pools, where each correct pick scores the confidence the
+// member gave it.
+package pickem
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/picklock"
+	"touchdown-tally/internal/store"
+	"touchdown-tally/pkg/logger"
+)
+
+// gradeInterval is how often picks on newly final games are graded
+const gradeInterval = time.Minute
+
+var (
+	ErrNotWeeklyPool = errors.New("pool does not pick games weekly")
+	ErrGameNotInWeek = errors.New("game is not part of this week")
+	ErrTeamNotInGame = errors.New("team is not playing in this game")
+	ErrGameLocked    = errors.New("game has kicked off and picks are locked")
+	ErrDuplicateGame = errors.New("a game is picked more than once")
+
+	ErrConfidenceRequired = errors.New("every pick needs a confidence value")
+	ErrConfidenceRange    = errors.New("confidence must be between 1 and the number of games in the week")
+	ErrConfidenceReused   = errors.New("each confidence value can only be used once per week")
+)
+
+// Service records and grades weekly picks
+type Service struct {
+	store  *store.Store
+	locks  *picklock.Checker
+	logger *logger.Logger
+}
+
+// NewService creates a pick'em service
+func NewService(st *store.Store, locks *picklock.Checker, logger *logger.Logger) *Service {
+	return &Service{
+		store:  st,
+		locks:  locks,
+		logger: logger,
+	}
+}
+
+// Week returns the week's games with the member's own picks. Other members'
+// picks are only included for games that have locked.
+func (s *Service) Week(ctx context.Context, pool *models.Pool, userID, week int) (*models.WeekPicks, error) {
+	if !pool.PicksWeekly() {
+		return nil, ErrNotWeeklyPool
+	}
+
+	games, err := s.store.Games.List(ctx, store.GameFilter{SeasonYear: &pool.Season, Week: &week})
+	if err != nil {
+		return nil, err
+	}
+
+	picks, err := s.store.WeeklyPicks.List(ctx, pool.ID, pool.Season, &week)
+	if err != nil {
+		return nil, err
+	}
+	byGame := make(map[int][]models.WeeklyPick)
+	for _, pick := range picks {
+		byGame[pick.GameID] = append(byGame[pick.GameID], pick)
+	}
+
+	result := &models.WeekPicks{
+		PoolID: pool.ID,
+		Season: pool.Season,
+		Week:   week,
+		Games:  make([]models.WeekGame, 0, len(games)),
+	}
+	if pool.PoolType == models.PoolTypeConfidence {
+		result.MaxConfidence = len(games)
+	}
+	for _, game := range games {
+		weekGame := models.WeekGame{
+			GameWithTeams: game,
+			Locked:        s.locks.GameLocked(game.NFLGame),
+			Picks:         []models.WeeklyPick{},
+		}
+
+		for i, pick := range byGame[game.GameID] {
+			if pick.UserID == userID {
+				weekGame.MyPick = &byGame[game.GameID][i]
+				if pick.IsCorrect != nil && *pick.IsCorrect {
+					result.Correct++
+					result.Points += pick.Value(pool.PoolType)
+				}
+			}
+		}
+		if weekGame.Locked {
+			weekGame.Picks = append(weekGame.Picks, byGame[game.GameID]...)
+		}
+
+		result.Games = append(result.Games, weekGame)
+	}
+
+	return result, nil
+}
+
+// Submit saves the member's picks for games in the week. Every pick must be
+// for a game in the week that has not locked, naming one of its two teams.
+// In confidence pools the member's confidence values for the week, including
+// picks already saved, must each be used once.
+func (s *Service) Submit(ctx context.Context, pool *models.Pool, userID, week int, picks []models.WeeklyPickInput) (*models.WeekPicks, error) {
+	if !pool.PicksWeekly() {
+		return nil, ErrNotWeeklyPool
+	}
+
+	games, err := s.store.Games.List(ctx, store.GameFilter{SeasonYear: &pool.Season, Week: &week})
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int]models.NFLGame, len(games))
+	for _, game := range games {
+		byID[game.GameID] = game.NFLGame
+	}
+
+	seen := make(map[int]bool, len(picks))
+	for _, pick := range picks {
+		if seen[pick.GameID] {
+			return nil, ErrDuplicateGame
+		}
+		seen[pick.GameID] = true
+
+		game, ok := byID[pick.GameID]
+		if !ok {
+			return nil, ErrGameNotInWeek
+		}
+		if pick.TeamID != game.HomeTeamID && pick.TeamID != game.AwayTeamID {
+			return nil, ErrTeamNotInGame
+		}
+		if s.locks.GameLocked(game) {
+			return nil, ErrGameLocked
+		}
+	}
+
+	if pool.PoolType == models.PoolTypeConfidence {
+		if err := s.checkConfidence(ctx, pool, userID, week, len(games), picks); err != nil {
+			return nil, err
+		}
+	} else {
+		for i := range picks {
+			picks[i].Confidence = 0
+		}
+	}
+
+	if err := s.store.WeeklyPicks.Save(ctx, pool.ID, userID, picks); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Weekly picks saved", "pool_id", pool.ID, "user_id", userID, "week", week, "picks", len(picks))
+	return s.Week(ctx, pool, userID, week)
+}
+
+// checkConfidence ensures the member's confidence values for the week stay
+// within 1..games and distinct once picks are merged with those already
+// saved. Games left unpicked simply score nothing.
+func (s *Service) checkConfidence(ctx context.Context, pool *models.Pool, userID, week, games int, picks []models.WeeklyPickInput) error {
+	saved, err := s.store.WeeklyPicks.List(ctx, pool.ID, pool.Season, &week)
+	if err != nil {
+		return err
+	}
+
+	confidence := make(map[int]int)
+	for _, pick := range saved {
+		if pick.UserID == userID && pick.Confidence != nil {
+			confidence[pick.GameID] = *pick.Confidence
+		}
+	}
+	for _, pick := range picks {
+		if pick.Confidence == 0 {
+			return ErrConfidenceRequired
+		}
+		if pick.Confidence > games {
+			return ErrConfidenceRange
+		}
+		confidence[pick.GameID] = pick.Confidence
+	}
+
+	used := make(map[int]bool, len(confidence))
+	for _, value := range confidence {
+		if used[value] {
+			return ErrConfidenceReused
+		}
+		used[value] = true
+	}
+	return nil
+}
+
+// Grade marks picks on final games right or wrong
+func (s *Service) Grade(ctx context.Context) error {
+	changed, err := s.store.WeeklyPicks.Grade(ctx)
+	if err != nil {
+		return err
+	}
+	if changed > 0 {
+		s.logger.Info("Weekly picks graded", "picks", changed)
+	}
+	return nil
+}
+
+// Run grades picks as games become final until ctx is cancelled
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(gradeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Grade(ctx); err != nil {
+				s.logger.Error("Failed to grade weekly picks", "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,430 @@
+package pickem
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"touchdown-tally/internal/calendar"
+	"touchdown-tally/internal/database"
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/picklock"
+	"touchdown-tally/internal/store"
+	"touchdown-tally/pkg/logger"
+)
+
+// league is a pool over a migrated SQLite database. Week 1 has three games:
+// early, BUF at home to MIA, kicked off an hour ago; sun, KC at home to
+// DEN, tomorrow; and mon, DAL at home to NYG, the day after. Week 2 has
+// one game, next, PHI at home to WAS.
+type league struct {
+	service *Service
+	store   *store.Store
+	pool    *models.Pool
+	games   map[string]int
+	teams   map[string]int
+	users   []int
+}
+
+func newLeague(t *testing.T, poolType string) *league {
+	t.Helper()
+	ctx := context.Background()
+
+	db, err := database.Connect("sqlite://" + filepath.Join(t.TempDir(), "pickem.db"))
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := database.Migrate(db); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	st := store.New(db)
+	quiet := &logger.Logger{Logger: log.New(io.Discard, "", 0)}
+	l := &league{
+		service: NewService(st, picklock.NewChecker(calendar.New(st)), quiet),
+		store:   st,
+		games:   make(map[string]int),
+		teams:   make(map[string]int),
+	}
+
+	teams, err := st.Teams.List(ctx, store.TeamFilter{})
+	if err != nil {
+		t.Fatalf("list teams: %v", err)
+	}
+	for _, team := range teams {
+		l.teams[team.TeamAbbreviation] = team.TeamID
+	}
+
+	now := time.Now().UTC()
+	schedule := []struct {
+		name       string
+		week       int
+		home, away string
+		kickoff    time.Time
+		status     string
+	}{
+		{"early", 1, "BUF", "MIA", now.Add(-time.Hour), models.GameStatusInProgress},
+		{"sun", 1, "KC", "DEN", now.Add(24 * time.Hour), models.GameStatusScheduled},
+		{"mon", 1, "DAL", "NYG", now.Add(48 * time.Hour), models.GameStatusScheduled},
+		{"next", 2, "PHI", "WAS", now.Add(7 * 24 * time.Hour), models.GameStatusScheduled},
+	}
+	for _, g := range schedule {
+		result, err := st.Games.Upsert(ctx, []models.NFLGame{{
+			ExternalID: g.name,
+			SeasonYear: 2025,
+			Week:       g.week,
+			GameType:   models.GameTypeRegular,
+			HomeTeamID: l.teams[g.home],
+			AwayTeamID: l.teams[g.away],
+			GameDate:   g.kickoff,
+			Status:     g.status,
+		}})
+		if err != nil || len(result.Updated) != 1 {
+			t.Fatalf("upsert %s = %+v, %v", g.name, result, err)
+		}
+		l.games[g.name] = result.Updated[0]
+	}
+
+	for _, name := range []string{"commish", "member"} {
+		profile, err := st.Users.Register(ctx, name+"@example.com", "hash", name, name)
+		if err != nil {
+			t.Fatalf("register %s: %v", name, err)
+		}
+		l.users = append(l.users, profile.UserID)
+	}
+	poolID, err := st.Pools.Create(ctx, store.NewPool{
+		Name:           "Sunday Picks",
+		CommissionerID: l.users[0],
+		SeasonYear:     2025,
+		MaxMembers:     10,
+		PoolType:       poolType,
+		Settings:       models.DefaultPoolSettings(),
+	})
+	if err != nil {
+		t.Fatalf("create pool: %v", err)
+	}
+	if err := st.Pools.AddMember(ctx, poolID, l.users[1], models.RoleMember); err != nil {
+		t.Fatalf("add member: %v", err)
+	}
+	if l.pool, err = st.Pools.Get(ctx, poolID); err != nil {
+		t.Fatalf("get pool: %v", err)
+	}
+	return l
+}
+
+// pick backs team to win the named game with the given confidence
+func (l *league) pick(game, team string, confidence int) models.WeeklyPickInput {
+	return models.WeeklyPickInput{GameID: l.games[game], TeamID: l.teams[team], Confidence: confidence}
+}
+
+// myPick returns the user's pick on the named game in week 1
+func (l *league) myPick(t *testing.T, userID int, game string) *models.WeeklyPick {
+	t.Helper()
+
+	week, err := l.service.Week(context.Background(), l.pool, userID, 1)
+	if err != nil {
+		t.Fatalf("week: %v", err)
+	}
+	for _, g := range week.Games {
+		if g.GameID == l.games[game] {
+			return g.MyPick
+		}
+	}
+	t.Fatalf("game %s not in week 1", game)
+	return nil
+}
+
+// finishSunday ends the sun game with KC on home points and DEN on away
+// points, three hours after it kicked off, and grades the picks on it
+func (l *league) finishSunday(t *testing.T, home, away int) {
+	t.Helper()
+	ctx := context.Background()
+
+	final := models.NFLGame{
+		ExternalID: "sun",
+		SeasonYear: 2025,
+		Week:       1,
+		GameType:   models.GameTypeRegular,
+		HomeTeamID: l.teams["KC"],
+		AwayTeamID: l.teams["DEN"],
+		GameDate:   time.Now().UTC().Add(-3 * time.Hour),
+		HomeScore:  home,
+		AwayScore:  away,
+		Status:     models.GameStatusCompleted,
+	}
+	if _, err := l.store.Games.Upsert(ctx, []models.NFLGame{final}); err != nil {
+		t.Fatalf("finish game: %v", err)
+	}
+	if err := l.service.Grade(ctx); err != nil {
+		t.Fatalf("grade: %v", err)
+	}
+}
+
+func TestSubmitWeeklyPicks(t *testing.T) {
+	tests := []struct {
+		name     string
+		poolType string
+		picks    func(l *league) []models.WeeklyPickInput
+		wantErr  error
+	}{
+		{
+			name:     "open games",
+			poolType: models.PoolTypeWeekly,
+			picks: func(l *league) []models.WeeklyPickInput {
+				return []models.WeeklyPickInput{l.pick("sun", "KC", 0), l.pick("mon", "NYG", 0)}
+			},
+		},
+		{
+			name:     "confidence is ignored outside confidence pools",
+			poolType: models.PoolTypeWeekly,
+			picks: func(l *league) []models.WeeklyPickInput {
+				return []models.WeeklyPickInput{l.pick("sun", "KC", 3)}
+			},
+		},
+		{
+			name:     "season pools do not pick weekly",
+			poolType: models.PoolTypeSeason,
+			picks: func(l *league) []models.WeeklyPickInput {
+				return []models.WeeklyPickInput{l.pick("sun", "KC", 0)}
+			},
+			wantErr: ErrNotWeeklyPool,
+		},
+		{
+			name:     "game picked twice",
+			poolType: models.PoolTypeWeekly,
+			picks: func(l *league) []models.WeeklyPickInput {
+				return []models.WeeklyPickInput{l.pick("sun", "KC", 0), l.pick("sun", "DEN", 0)}
+			},
+			wantErr: ErrDuplicateGame,
+		},
+		{
+			name:     "game from another week",
+			poolType: models.PoolTypeWeekly,
+			picks: func(l *league) []models.WeeklyPickInput {
+				return []models.WeeklyPickInput{l.pick("next", "PHI", 0)}
+			},
+			wantErr: ErrGameNotInWeek,
+		},
+		{
+			name:     "team not in the game",
+			poolType: models.PoolTypeWeekly,
+			picks: func(l *league) []models.WeeklyPickInput {
+				return []models.WeeklyPickInput{l.pick("sun", "BUF", 0)}
+			},
+			wantErr: ErrTeamNotInGame,
+		},
+		{
+			name:     "game already kicked off",
+			poolType: models.PoolTypeWeekly,
+			picks: func(l *league) []models.WeeklyPickInput {
+				return []models.WeeklyPickInput{l.pick("sun", "KC", 0), l.pick("early", "BUF", 0)}
+			},
+			wantErr: ErrGameLocked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLeague(t, tt.poolType)
+			member := l.users[1]
+
+			_, err := l.service.Submit(context.Background(), l.pool, member, 1, tt.picks(l))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Submit error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if !l.pool.PicksWeekly() {
+					return
+				}
+				if pick := l.myPick(t, member, "sun"); pick != nil {
+					t.Errorf("rejected submission saved %+v", pick)
+				}
+				return
+			}
+
+			pick := l.myPick(t, member, "sun")
+			if pick == nil || pick.TeamID != l.teams["KC"] || pick.Confidence != nil {
+				t.Errorf("saved pick = %+v, want KC without confidence", pick)
+			}
+		})
+	}
+}
+
+func TestWeeklyGradingAndReveal(t *testing.T) {
+	ctx := context.Background()
+	l := newLeague(t, models.PoolTypeWeekly)
+	commissioner, member := l.users[0], l.users[1]
+
+	if _, err := l.service.Submit(ctx, l.pool, commissioner, 1, []models.WeeklyPickInput{l.pick("sun", "KC", 0)}); err != nil {
+		t.Fatalf("commissioner picks: %v", err)
+	}
+	if _, err := l.service.Submit(ctx, l.pool, member, 1, []models.WeeklyPickInput{l.pick("sun", "DEN", 0)}); err != nil {
+		t.Fatalf("member picks: %v", err)
+	}
+
+	sunday := func(userID int) (models.WeekGame, *models.WeekPicks) {
+		t.Helper()
+		week, err := l.service.Week(ctx, l.pool, userID, 1)
+		if err != nil {
+			t.Fatalf("week: %v", err)
+		}
+		for _, g := range week.Games {
+			if g.GameID == l.games["sun"] {
+				return g, week
+			}
+		}
+		t.Fatal("sun game missing from week 1")
+		return models.WeekGame{}, nil
+	}
+
+	// Until kickoff members only see their own pick
+	if game, _ := sunday(member); game.Locked || len(game.Picks) != 0 || game.MyPick == nil || game.MyPick.TeamID != l.teams["DEN"] {
+		t.Fatalf("before kickoff: %+v", game)
+	}
+
+	l.finishSunday(t, 27, 20)
+
+	tests := []struct {
+		name        string
+		userID      int
+		wantCorrect bool
+	}{
+		{name: "right pick", userID: commissioner, wantCorrect: true},
+		{name: "wrong pick", userID: member},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, week := sunday(tt.userID)
+			if !game.Locked || len(game.Picks) != 2 {
+				t.Fatalf("after the game: locked %t with %d picks shown", game.Locked, len(game.Picks))
+			}
+			if game.MyPick == nil || game.MyPick.IsCorrect == nil || *game.MyPick.IsCorrect != tt.wantCorrect {
+				t.Fatalf("pick = %+v, want correct %t", game.MyPick, tt.wantCorrect)
+			}
+			want := 0
+			if tt.wantCorrect {
+				want = 1
+			}
+			if week.Correct != want || week.Points != want {
+				t.Errorf("week totals = %d correct, %d points; want %d", week.Correct, week.Points, want)
+			}
+		})
+	}
+}
+
+func TestConfidence(t *testing.T) {
+	tests := []struct {
+		name string
+		// saved is submitted first and must be accepted
+		saved   func(l *league) []models.WeeklyPickInput
+		submit  func(l *league) []models.WeeklyPickInput
+		wantErr error
+		// want is the confidence on each game afterwards
+		want map[string]int
+	}{
+		{
+			name: "each value once",
+			submit: func(l *league) []models.WeeklyPickInput {
+				return []models.WeeklyPickInput{l.pick("sun", "KC", 3), l.pick("mon", "NYG", 1)}
+			},
+			want: map[string]int{"sun": 3, "mon": 1},
+		},
+		{
+			name: "missing confidence",
+			submit: func(l *league) []models.WeeklyPickInput {
+				return []models.WeeklyPickInput{l.pick("sun", "KC", 3), l.pick("mon", "NYG", 0)}
+			},
+			wantErr: ErrConfidenceRequired,
+		},
+		{
+			name: "above the number of games in the week",
+			submit: func(l *league) []models.WeeklyPickInput {
+				return []models.WeeklyPickInput{l.pick("sun", "KC", 4)}
+			},
+			wantErr: ErrConfidenceRange,
+		},
+		{
+			name: "reused within a submission",
+			submit: func(l *league) []models.WeeklyPickInput {
+				return []models.WeeklyPickInput{l.pick("sun", "KC", 2), l.pick("mon", "NYG", 2)}
+			},
+			wantErr: ErrConfidenceReused,
+		},
+		{
+			name: "reused against a saved pick",
+			saved: func(l *league) []models.WeeklyPickInput {
+				return []models.WeeklyPickInput{l.pick("sun", "KC", 3)}
+			},
+			submit: func(l *league) []models.WeeklyPickInput {
+				return []models.WeeklyPickInput{l.pick("mon", "NYG", 3)}
+			},
+			wantErr: ErrConfidenceReused,
+			want:    map[string]int{"sun": 3},
+		},
+		{
+			name: "saved value moved to another game",
+			saved: func(l *league) []models.WeeklyPickInput {
+				return []models.WeeklyPickInput{l.pick("sun", "KC", 3)}
+			},
+			submit: func(l *league) []models.WeeklyPickInput {
+				return []models.WeeklyPickInput{l.pick("sun", "KC", 1), l.pick("mon", "NYG", 3)}
+			},
+			want: map[string]int{"sun": 1, "mon": 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			l := newLeague(t, models.PoolTypeConfidence)
+			member := l.users[1]
+
+			if tt.saved != nil {
+				if _, err := l.service.Submit(ctx, l.pool, member, 1, tt.saved(l)); err != nil {
+					t.Fatalf("saved picks: %v", err)
+				}
+			}
+			_, err := l.service.Submit(ctx, l.pool, member, 1, tt.submit(l))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Submit error = %v, want %v", err, tt.wantErr)
+			}
+
+			for _, game := range []string{"sun", "mon"} {
+				pick := l.myPick(t, member, game)
+				want, ok := tt.want[game]
+				switch {
+				case !ok && pick != nil:
+					t.Errorf("%s picked with %v", game, pick.Confidence)
+				case ok && (pick == nil || pick.Confidence == nil || *pick.Confidence != want):
+					t.Errorf("%s pick = %+v, want confidence %d", game, pick, want)
+				}
+			}
+		})
+	}
+}
+
+func TestConfidencePoints(t *testing.T) {
+	ctx := context.Background()
+	l := newLeague(t, models.PoolTypeConfidence)
+	member := l.users[1]
+
+	picks := []models.WeeklyPickInput{l.pick("sun", "KC", 3), l.pick("mon", "NYG", 2)}
+	if _, err := l.service.Submit(ctx, l.pool, member, 1, picks); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	l.finishSunday(t, 27, 20)
+
+	// A correct pick is worth its confidence; the ungraded one nothing yet
+	week, err := l.service.Week(ctx, l.pool, member, 1)
+	if err != nil {
+		t.Fatalf("week: %v", err)
+	}
+	if week.MaxConfidence != 3 || week.Correct != 1 || week.Points != 3 {
+		t.Errorf("week = max %d, %d correct, %d points; want max 3, 1 correct, 3 points",
+			week.MaxConfidence, week.Correct, week.Points)
+	}
+}
@@ -0,0 +1,71 @@
+// Package picklock decides when a pool's picks stop accepting changes.
+package picklock
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"touchdown-tally/internal/calendar"
+	"touchdown-tally/internal/models"
+)
+
+// Checker resolves pool lock policies against the NFL schedule
+type Checker struct {
+	calendar *calendar.Calendar
+	now      func() time.Time
+}
+
+// NewChecker creates a lock checker that finds the season's start on cal
+func NewChecker(cal *calendar.Calendar) *Checker {
+	return &Checker{
+		calendar: cal,
+		now:      func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// SeasonDeadline returns when the pool's season picks lock, or nil when they
+// never do. A season_start pool whose season has no games loaded yet has no
+// deadline until they are.
+func (c *Checker) SeasonDeadline(ctx context.Context, pool *models.Pool) (*time.Time, error) {
+	locks := pool.Settings.Locks
+
+	switch locks.Policy {
+	case models.LockPolicyNone:
+		return nil, nil
+	case models.LockPolicyDeadline:
+		return locks.Deadline, nil
+	default:
+		kickoff, err := c.calendar.SeasonStart(ctx, pool.Season)
+		if errors.Is(err, calendar.ErrNoSchedule) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &kickoff, nil
+	}
+}
+
+// SeasonStatus reports the pool's lock policy, deadline and whether it has passed
+func (c *Checker) SeasonStatus(ctx context.Context, pool *models.Pool) (*models.PickLockStatus, error) {
+	deadline, err := c.SeasonDeadline(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PickLockStatus{
+		Policy:   pool.Settings.Locks.Policy,
+		Deadline: deadline,
+		Locked:   deadline != nil && !c.now().Before(*deadline),
+	}, nil
+}
+
+// GameLocked reports whether picks on the game have closed: at kickoff, or
+// as soon as the game is underway or over if that comes first
+func (c *Checker) GameLocked(game models.NFLGame) bool {
+	if models.IsPendingStatus(game.Status) {
+		return !c.now().Before(game.GameDate)
+	}
+	return true
+}
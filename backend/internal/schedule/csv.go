@@ -0,0 +1,182 @@
+package schedule
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"touchdown-tally/internal/models"
+)
+
+// csvColumns maps accepted header names to the field they hold
+var csvColumns = map[string]string{
+	"week":        "week",
+	"kickoff":     "kickoff",
+	"game_date":   "kickoff",
+	"datetime":    "kickoff",
+	"date":        "date",
+	"time":        "time",
+	"home":        "home",
+	"home_team":   "home",
+	"away":        "away",
+	"away_team":   "away",
+	"visitor":     "away",
+	"game_type":   "game_type",
+	"type":        "game_type",
+	"external_id": "external_id",
+	"id":          "external_id",
+}
+
+// kickoffLayouts are the date-time formats accepted in a kickoff column.
+// Layouts without a zone are read in Eastern time.
+var kickoffLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 3:04 PM",
+	"2006-01-02 3:04PM",
+	"01/02/2006 15:04",
+	"01/02/2006 3:04 PM",
+	"01/02/2006 3:04PM",
+}
+
+// dateLayouts are the formats accepted for a game listed by date only
+var dateLayouts = []string{
+	"2006-01-02",
+	"01/02/2006",
+}
+
+// parseCSV reads a schedule with a header row. It needs home and away
+// columns and either a kickoff column or a date column, with an optional
+// time column. week, game_type and external_id columns are optional.
+func parseCSV(r io.Reader) ([]row, []models.ScheduleRowError, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, ErrEmpty
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+
+	columns := make(map[string]int)
+	for idx, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if field, ok := csvColumns[name]; ok {
+			columns[field] = idx
+		}
+	}
+	for _, field := range []string{"home", "away"} {
+		if _, ok := columns[field]; !ok {
+			return nil, nil, fmt.Errorf("%w: csv header has no %s column", ErrInvalidFile, field)
+		}
+	}
+	_, hasKickoff := columns["kickoff"]
+	_, hasDate := columns["date"]
+	if !hasKickoff && !hasDate {
+		return nil, nil, fmt.Errorf("%w: csv header has no kickoff or date column", ErrInvalidFile)
+	}
+
+	var (
+		rows []row
+		bad  []models.ScheduleRowError
+	)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				bad = append(bad, models.ScheduleRowError{Line: parseErr.Line, Reason: parseErr.Err.Error()})
+				continue
+			}
+			return nil, nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		raw := strings.Join(record, ",")
+		if strings.Trim(raw, ", \t") == "" {
+			continue
+		}
+		field := func(name string) string {
+			if idx, ok := columns[name]; ok && idx < len(record) {
+				return strings.TrimSpace(record[idx])
+			}
+			return ""
+		}
+		fail := func(reason string, args ...interface{}) {
+			bad = append(bad, models.ScheduleRowError{Line: line, Row: raw, Reason: fmt.Sprintf(reason, args...)})
+		}
+
+		entry := row{
+			line:       line,
+			raw:        raw,
+			externalID: field("external_id"),
+			home:       field("home"),
+			away:       field("away"),
+		}
+		if entry.home == "" || entry.away == "" {
+			fail("home and away teams are required")
+			continue
+		}
+
+		if week := field("week"); week != "" {
+			entry.week, err = strconv.Atoi(week)
+			if err != nil || entry.week < 1 {
+				fail("invalid week %q", week)
+				continue
+			}
+		}
+
+		gameType, ok := parseGameType(field("game_type"))
+		if !ok {
+			fail("unknown game type %q", field("game_type"))
+			continue
+		}
+		entry.gameType = gameType
+
+		kickoff := field("kickoff")
+		if kickoff == "" {
+			kickoff = strings.TrimSpace(field("date") + " " + field("time"))
+		}
+		entry.kickoff, ok = parseKickoff(kickoff)
+		if !ok {
+			fail("invalid kickoff %q", kickoff)
+			continue
+		}
+
+		rows = append(rows, entry)
+	}
+
+	return rows, bad, nil
+}
+
+// parseKickoff reads a kickoff time in any of kickoffLayouts, or a date in
+// any of dateLayouts for a game whose time is not yet set
+func parseKickoff(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range kickoffLayouts {
+		if t, err := time.ParseInLocation(layout, value, eastern); err == nil {
+			return t, true
+		}
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, value, eastern); err == nil {
+			return t.Add(defaultKickoff), true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,234 @@
+package schedule
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"touchdown-tally/internal/models"
+)
+
+// icsLine is an unfolded iCalendar content line
+type icsLine struct {
+	number int
+	name   string
+	params map[string]string
+	value  string
+}
+
+// weekPattern finds "Week 3" in an event's summary or description
+var weekPattern = regexp.MustCompile(`(?i)\bweek\s+(\d{1,2})\b`)
+
+// matchupSeparators split an event summary into two teams. homeFirst marks
+// neutral-style listings ("KC vs BUF") that name the home team first.
+var matchupSeparators = []struct {
+	sep       string
+	homeFirst bool
+}{
+	{" @ ", false},
+	{" at ", false},
+	{" vs. ", true},
+	{" vs ", true},
+	{" v ", true},
+}
+
+// parseICS reads the VEVENTs of an iCalendar file. Each event's SUMMARY
+// names the matchup ("BAL @ KC", "Baltimore Ravens at Kansas City Chiefs")
+// and DTSTART its kickoff. A "Week N" in the summary or description sets
+// the week.
+func parseICS(r io.Reader) ([]row, []models.ScheduleRowError, error) {
+	lines, err := readICSLines(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+
+	var (
+		rows    []row
+		bad     []models.ScheduleRowError
+		event   map[string]icsLine
+		start   int
+		inEvent bool
+	)
+	for _, line := range lines {
+		switch {
+		case line.name == "BEGIN" && strings.EqualFold(line.value, "VEVENT"):
+			event = make(map[string]icsLine)
+			start = line.number
+			inEvent = true
+		case line.name == "END" && strings.EqualFold(line.value, "VEVENT") && inEvent:
+			inEvent = false
+			entry, reason := icsEvent(start, event)
+			if reason != "" {
+				bad = append(bad, models.ScheduleRowError{Line: start, Row: entry.raw, Reason: reason})
+				continue
+			}
+			rows = append(rows, entry)
+		case inEvent:
+			if _, ok := event[line.name]; !ok {
+				event[line.name] = line
+			}
+		}
+	}
+
+	return rows, bad, nil
+}
+
+// icsEvent reads one VEVENT, returning why it is not a game when it cannot
+// be read
+func icsEvent(start int, event map[string]icsLine) (row, string) {
+	summary := icsText(event["SUMMARY"].value)
+	entry := row{line: start, raw: summary}
+
+	dtstart, ok := event["DTSTART"]
+	if !ok {
+		return entry, "event has no DTSTART"
+	}
+	kickoff, ok := icsTime(dtstart)
+	if !ok {
+		return entry, fmt.Sprintf("invalid DTSTART %q", dtstart.value)
+	}
+	entry.kickoff = kickoff
+
+	if summary == "" {
+		return entry, "event has no SUMMARY"
+	}
+	for _, text := range []string{summary, icsText(event["DESCRIPTION"].value)} {
+		if m := weekPattern.FindStringSubmatch(text); m != nil {
+			entry.week, _ = strconv.Atoi(m[1])
+			break
+		}
+	}
+	if strings.Contains(strings.ToLower(summary), "super bowl") {
+		entry.gameType = models.GameTypeSuperBowl
+	}
+
+	var found bool
+	entry.home, entry.away, found = splitMatchup(summary)
+	if !found {
+		return entry, "summary does not name two teams"
+	}
+	return entry, ""
+}
+
+// splitMatchup finds the home and away teams in an event summary. Anything
+// before a colon ("Super Bowl LIX: KC vs PHI") or in parentheses is ignored.
+func splitMatchup(summary string) (home, away string, ok bool) {
+	if idx := strings.LastIndex(summary, ":"); idx >= 0 {
+		summary = summary[idx+1:]
+	}
+	for {
+		open := strings.Index(summary, "(")
+		end := strings.Index(summary, ")")
+		if open < 0 || end < open {
+			break
+		}
+		summary = summary[:open] + summary[end+1:]
+	}
+
+	lower := strings.ToLower(summary)
+	for _, s := range matchupSeparators {
+		idx := strings.Index(lower, s.sep)
+		if idx < 0 {
+			continue
+		}
+		first := strings.TrimSpace(summary[:idx])
+		second := strings.TrimSpace(summary[idx+len(s.sep):])
+		if first == "" || second == "" {
+			return "", "", false
+		}
+		if s.homeFirst {
+			return first, second, true
+		}
+		return second, first, true
+	}
+	return "", "", false
+}
+
+// icsTime reads a DTSTART in UTC ("20240906T002000Z"), in a TZID zone, as
+// floating local time (read as Eastern), or as a date alone
+func icsTime(line icsLine) (time.Time, bool) {
+	loc := eastern
+	if tzid := line.params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+
+	if strings.HasSuffix(line.value, "Z") {
+		t, err := time.Parse("20060102T150405Z", line.value)
+		return t, err == nil
+	}
+	if t, err := time.ParseInLocation("20060102T150405", line.value, loc); err == nil {
+		return t, true
+	}
+	if t, err := time.ParseInLocation("20060102", line.value, eastern); err == nil {
+		return t.Add(defaultKickoff), true
+	}
+	return time.Time{}, false
+}
+
+// readICSLines splits an iCalendar file into content lines, joining folded
+// continuation lines and recording the line each one starts on
+func readICSLines(r io.Reader) ([]icsLine, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var (
+		lines  []icsLine
+		buf    strings.Builder
+		first  int
+		number int
+	)
+	flush := func() {
+		if buf.Len() > 0 {
+			if line, ok := parseICSLine(first, buf.String()); ok {
+				lines = append(lines, line)
+			}
+			buf.Reset()
+		}
+	}
+	for scanner.Scan() {
+		number++
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t") {
+			buf.WriteString(text[1:])
+			continue
+		}
+		flush()
+		first = number
+		buf.WriteString(text)
+	}
+	flush()
+	return lines, scanner.Err()
+}
+
+// parseICSLine splits "NAME;PARAM=VALUE:value" into its parts
+func parseICSLine(number int, text string) (icsLine, bool) {
+	colon := strings.Index(text, ":")
+	if colon < 0 {
+		return icsLine{}, false
+	}
+
+	parts := strings.Split(text[:colon], ";")
+	line := icsLine{
+		number: number,
+		name:   strings.ToUpper(parts[0]),
+		params: make(map[string]string),
+		value:  strings.TrimSpace(text[colon+1:]),
+	}
+	for _, param := range parts[1:] {
+		if key, value, ok := strings.Cut(param, "="); ok {
+			line.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+		}
+	}
+	return line, true
+}
+
+// icsText unescapes an iCalendar TEXT value
+func icsText(value string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
@@ -0,0 +1,323 @@
+// Package schedule imports a season schedule into nfl_games from CSV or
+// iCalendar files, so a league can load its games without a score provider.
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	_ "time/tzdata" // kickoff times without a zone are read as US Eastern
+
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/scores"
+	"touchdown-tally/internal/store"
+	"touchdown-tally/pkg/logger"
+)
+
+// Schedule file formats
+const (
+	FormatCSV = "csv"
+	FormatICS = "ics"
+)
+
+var (
+	ErrUnknownFormat = errors.New("schedule format must be csv or ics")
+	ErrInvalidFile   = errors.New("invalid schedule file")
+	ErrEmpty         = errors.New("schedule file has no games")
+)
+
+// eastern is the zone NFL schedules are published in. Kickoffs written
+// without a zone are read in it, and weeks turn over on Tuesday there.
+var eastern = mustLoadLocation("America/New_York")
+
+// defaultKickoff is the Eastern time given to games listed by date only
+const defaultKickoff = 13 * time.Hour
+
+// row is one game read from a schedule file, before its teams are resolved
+type row struct {
+	line       int
+	raw        string
+	externalID string
+	week       int    // 0 when the file does not say
+	gameType   string // empty when the file does not say
+	home, away string
+	kickoff    time.Time
+}
+
+// DetectFormat returns the schedule format named by a file's extension or,
+// failing that, its content type. It returns "" when neither is known.
+func DetectFormat(filename, contentType string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return FormatCSV
+	case ".ics", ".ical", ".ifb", ".icalendar":
+		return FormatICS
+	}
+
+	switch {
+	case strings.HasPrefix(contentType, "text/csv"):
+		return FormatCSV
+	case strings.HasPrefix(contentType, "text/calendar"):
+		return FormatICS
+	}
+	return ""
+}
+
+// Importer writes schedule files to nfl_games
+type Importer struct {
+	store  *store.Store
+	events scores.Publisher
+	logger *logger.Logger
+}
+
+// NewImporter creates an Importer. events may be nil when nothing listens
+// for status changes, as in the import-schedule command.
+func NewImporter(st *store.Store, events scores.Publisher, logger *logger.Logger) *Importer {
+	return &Importer{
+		store:  st,
+		events: events,
+		logger: logger,
+	}
+}
+
+// Import reads a season's schedule in the given format and writes its games.
+// Rows without a week are placed by kickoff date, counting Tuesday-to-Monday
+// weeks from the season's first game; rows without a game type take it from
+// their week. Games are keyed so that importing the same schedule again, or
+// the same games from another file, updates them rather than adding copies.
+// Rows that cannot be read or matched to two teams are reported in the
+// result rather than failing the import.
+func (i *Importer) Import(ctx context.Context, season int, format string, r io.Reader) (*models.ScheduleImport, error) {
+	var (
+		rows []row
+		bad  []models.ScheduleRowError
+		err  error
+	)
+	switch format {
+	case FormatCSV:
+		rows, bad, err = parseCSV(r)
+	case FormatICS:
+		rows, bad, err = parseICS(r)
+	default:
+		return nil, ErrUnknownFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 && len(bad) == 0 {
+		return nil, ErrEmpty
+	}
+
+	teams, err := i.loadTeams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	known, err := i.knownGames(ctx, season)
+	if err != nil {
+		return nil, err
+	}
+
+	assignWeeks(rows)
+
+	result := &models.ScheduleImport{
+		SeasonYear: season,
+		Skipped:    []int{},
+		Unmatched:  bad,
+	}
+	reject := func(r row, reason string, args ...interface{}) {
+		result.Unmatched = append(result.Unmatched, models.ScheduleRowError{
+			Line:   r.line,
+			Row:    r.raw,
+			Reason: fmt.Sprintf(reason, args...),
+		})
+	}
+
+	games := make([]models.NFLGame, 0, len(rows))
+	seen := make(map[string]bool, len(rows))
+	for _, r := range rows {
+		home, ok := teams.resolve(r.home)
+		if !ok {
+			reject(r, "unknown home team %q", r.home)
+			continue
+		}
+		away, ok := teams.resolve(r.away)
+		if !ok {
+			reject(r, "unknown away team %q", r.away)
+			continue
+		}
+		if home.TeamID == away.TeamID {
+			reject(r, "home and away teams are both %s", home.TeamAbbreviation)
+			continue
+		}
+		if r.week < 1 || r.week > scores.RegularSeasonWeeks+scores.PlayoffRounds {
+			reject(r, "week %d is outside the season", r.week)
+			continue
+		}
+
+		gameType := r.gameType
+		if gameType == "" {
+			gameType = weekGameType(r.week)
+		}
+
+		key := matchupKey(gameType, away.TeamID, home.TeamID)
+		externalID := r.externalID
+		if externalID == "" {
+			externalID = known[key]
+		}
+		if externalID == "" {
+			externalID = fmt.Sprintf("schedule-%d-%s-%s-%s", season, gameType, away.TeamAbbreviation, home.TeamAbbreviation)
+		}
+		if seen[externalID] || seen[key] {
+			reject(r, "duplicate of an earlier game: %s at %s", away.TeamAbbreviation, home.TeamAbbreviation)
+			continue
+		}
+		seen[externalID] = true
+		seen[key] = true
+
+		games = append(games, models.NFLGame{
+			ExternalID: externalID,
+			SeasonYear: season,
+			Week:       r.week,
+			GameType:   gameType,
+			HomeTeamID: home.TeamID,
+			AwayTeamID: away.TeamID,
+			GameDate:   r.kickoff.UTC(),
+			Status:     models.GameStatusScheduled,
+		})
+	}
+
+	upserted, err := i.store.Games.Upsert(ctx, games)
+	if err != nil {
+		return nil, err
+	}
+
+	// Games already kicked off belong to the score feed; their schedule
+	// row no longer says anything new
+	for _, rejected := range upserted.Rejected {
+		result.Skipped = append(result.Skipped, rejected.GameID)
+	}
+	result.Games = len(games) - len(result.Skipped)
+	result.Changed = upserted.Changed
+	sort.Slice(result.Unmatched, func(a, b int) bool {
+		return result.Unmatched[a].Line < result.Unmatched[b].Line
+	})
+
+	if i.events != nil {
+		i.events.Publish(ctx, upserted.Transitions)
+		i.events.PublishUpdates(ctx, upserted.Updated)
+	}
+
+	i.logger.Info("Schedule imported", "season", season, "format", format, "games", result.Games,
+		"changed", result.Changed, "skipped", len(result.Skipped), "unmatched", len(result.Unmatched))
+	return result, nil
+}
+
+// knownGames maps the season's games that already have an external_id by
+// matchup, so a schedule file updates games a score feed loaded earlier
+func (i *Importer) knownGames(ctx context.Context, season int) (map[string]string, error) {
+	games, err := i.store.Games.List(ctx, store.GameFilter{SeasonYear: &season})
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]string, len(games))
+	for _, game := range games {
+		if game.ExternalID != "" {
+			known[matchupKey(game.GameType, game.AwayTeamID, game.HomeTeamID)] = game.ExternalID
+		}
+	}
+	return known, nil
+}
+
+// matchupKey identifies a game within a season. Teams meet at most once at
+// each home ground in the regular season, and at most once in the playoffs.
+func matchupKey(gameType string, awayID, homeID int) string {
+	if gameType == models.GameTypeSuperBowl {
+		gameType = models.GameTypePlayoff
+	}
+	return fmt.Sprintf("%s:%d@%d", gameType, awayID, homeID)
+}
+
+// assignWeeks places rows without a week by kickoff date. Weeks run
+// Tuesday to Monday in Eastern time from the week of the earliest game. The
+// open week before the Super Bowl is skipped, so anything after the last
+// regular-season week and the first two playoff rounds is the Super Bowl.
+func assignWeeks(rows []row) {
+	if len(rows) == 0 {
+		return
+	}
+
+	first := rows[0].kickoff
+	for _, r := range rows {
+		if r.kickoff.Before(first) {
+			first = r.kickoff
+		}
+	}
+	start := easternDate(first)
+	start = start.AddDate(0, 0, -((int(start.Weekday()) - int(time.Tuesday) + 7) % 7))
+
+	final := scores.RegularSeasonWeeks + scores.PlayoffRounds
+	for idx, r := range rows {
+		switch {
+		case r.gameType == models.GameTypeSuperBowl:
+			rows[idx].week = final
+		case r.week == 0:
+			days := int(easternDate(r.kickoff).Sub(start).Hours()/24 + 0.5)
+			week := days/7 + 1
+			if week > final {
+				week = final
+			}
+			rows[idx].week = week
+		}
+	}
+}
+
+// weekGameType returns the game type of a week: the regular season, then
+// playoff rounds ending with the Super Bowl
+func weekGameType(week int) string {
+	switch {
+	case week <= scores.RegularSeasonWeeks:
+		return models.GameTypeRegular
+	case week == scores.RegularSeasonWeeks+scores.PlayoffRounds:
+		return models.GameTypeSuperBowl
+	default:
+		return models.GameTypePlayoff
+	}
+}
+
+// parseGameType reads a game type column, accepting the short names common
+// in published schedules
+func parseGameType(value string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "":
+		return "", true
+	case models.GameTypeRegular, "reg", "regular season":
+		return models.GameTypeRegular, true
+	case models.GameTypePlayoff, "post", "postseason", "wildcard", "wild card", "divisional", "conference":
+		return models.GameTypePlayoff, true
+	case models.GameTypeSuperBowl, "super bowl", "sb":
+		return models.GameTypeSuperBowl, true
+	default:
+		return "", false
+	}
+}
+
+// easternDate returns midnight UTC on t's calendar date in Eastern time, so
+// date arithmetic is not thrown off by daylight saving changes
+func easternDate(t time.Time) time.Time {
+	y, m, d := t.In(eastern).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
@@ -0,0 +1,80 @@
+package schedule
+
+import (
+	"context"
+	"strings"
+
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
+)
+
+// teamAliases maps abbreviations used by other schedule publishers, and
+// by franchises before they moved, to nfl_teams abbreviations
+var teamAliases = map[string]string{
+	"ARZ": "ARI",
+	"BLT": "BAL",
+	"CLV": "CLE",
+	"GNB": "GB",
+	"HST": "HOU",
+	"JAC": "JAX",
+	"KAN": "KC",
+	"LA":  "LAR",
+	"LVR": "LV",
+	"NOR": "NO",
+	"NWE": "NE",
+	"OAK": "LV",
+	"SD":  "LAC",
+	"SFO": "SF",
+	"STL": "LAR",
+	"TAM": "TB",
+	"WSH": "WAS",
+}
+
+// teamIndex resolves the ways a schedule names a team: its abbreviation,
+// full name ("Kansas City Chiefs"), nickname ("Chiefs") or, where only one
+// team plays there, its city
+type teamIndex map[string]models.NFLTeam
+
+func (i *Importer) loadTeams(ctx context.Context) (teamIndex, error) {
+	teams, err := i.store.Teams.List(ctx, store.TeamFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(teamIndex, len(teams)*4)
+	cities := make(map[string]int)
+	for _, team := range teams {
+		cities[teamKey(team.City)]++
+	}
+	for _, team := range teams {
+		index[teamKey(team.TeamAbbreviation)] = team
+		index[teamKey(team.City+" "+team.TeamName)] = team
+		index[teamKey(team.TeamName)] = team
+		if city := teamKey(team.City); cities[city] == 1 {
+			index[city] = team
+		}
+	}
+	for alias, abbreviation := range teamAliases {
+		if team, ok := index[teamKey(abbreviation)]; ok {
+			index[teamKey(alias)] = team
+		}
+	}
+	return index, nil
+}
+
+func (t teamIndex) resolve(name string) (models.NFLTeam, bool) {
+	team, ok := t[teamKey(name)]
+	return team, ok
+}
+
+// teamKey normalizes a team name for lookup, ignoring case, punctuation and
+// spacing
+func teamKey(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,67 @@
+package scores
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"touchdown-tally/internal/models"
+)
+
+// Fixture serves games from a JSON file shaped like {"games": [Game...]}.
+// The file is read on every call, so editing it stands in for a live feed.
+type Fixture struct {
+	path string
+}
+
+// NewFixture creates a provider backed by the JSON file at path
+func NewFixture(path string) *Fixture {
+	return &Fixture{path: path}
+}
+
+type fixtureFile struct {
+	Games []Game `json:"games"`
+}
+
+func (f *Fixture) Schedule(ctx context.Context, season int) ([]Game, error) {
+	return f.games(func(g Game) bool { return g.SeasonYear == season })
+}
+
+func (f *Fixture) Live(ctx context.Context, season, week int) ([]Game, error) {
+	return f.games(func(g Game) bool {
+		return g.SeasonYear == season && g.Week == week && models.IsLiveStatus(g.Status)
+	})
+}
+
+func (f *Fixture) Final(ctx context.Context, season, week int) ([]Game, error) {
+	return f.games(func(g Game) bool {
+		return g.SeasonYear == season && g.Week == week && models.IsFinalStatus(g.Status)
+	})
+}
+
+func (f *Fixture) games(keep func(Game) bool) ([]Game, error) {
+	raw, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file fixtureFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("decode score fixture %s: %w", f.path, err)
+	}
+
+	var games []Game
+	for _, game := range file.Games {
+		if game.GameType == "" {
+			game.GameType = models.GameTypeRegular
+		}
+		if game.Status == "" {
+			game.Status = models.GameStatusScheduled
+		}
+		if keep(game) {
+			games = append(games, game)
+		}
+	}
+	return games, nil
+}
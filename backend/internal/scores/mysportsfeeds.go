@@ -0,0 +1,204 @@
+package scores
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"touchdown-tally/internal/models"
+)
+
+const (
+	mySportsFeedsBaseURL = "https://api.mysportsfeeds.com/v2.1/pull/nfl"
+	mySportsFeedsTimeout = 30 * time.Second
+)
+
+// teamAliases maps MySportsFeeds abbreviations that differ from nfl_teams
+var teamAliases = map[string]string{
+	"LA":  "LAR",
+	"OAK": "LV",
+	"SD":  "LAC",
+	"STL": "LAR",
+	"WSH": "WAS",
+}
+
+// MySportsFeeds reads games from the MySportsFeeds v2.1 API. The regular
+// season and playoffs are separate MySportsFeeds seasons: the 2024 season's
+// playoffs are "2025-playoff".
+type MySportsFeeds struct {
+	apiKey  string
+	baseURL string
+	client  *http.Client
+}
+
+// NewMySportsFeeds creates a client authenticating with apiKey
+func NewMySportsFeeds(apiKey string) *MySportsFeeds {
+	return &MySportsFeeds{
+		apiKey:  apiKey,
+		baseURL: mySportsFeedsBaseURL,
+		client:  &http.Client{Timeout: mySportsFeedsTimeout},
+	}
+}
+
+func (m *MySportsFeeds) Schedule(ctx context.Context, season int) ([]Game, error) {
+	games, err := m.fetch(ctx, season, 0, "")
+	if err != nil {
+		return nil, err
+	}
+
+	for round := 1; round <= PlayoffRounds; round++ {
+		playoffs, err := m.fetch(ctx, season, RegularSeasonWeeks+round, "")
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, playoffs...)
+	}
+	return games, nil
+}
+
+func (m *MySportsFeeds) Live(ctx context.Context, season, week int) ([]Game, error) {
+	return m.fetch(ctx, season, week, "in-progress")
+}
+
+func (m *MySportsFeeds) Final(ctx context.Context, season, week int) ([]Game, error) {
+	return m.fetch(ctx, season, week, "final")
+}
+
+// msfGames is the part of a games.json response we read
+type msfGames struct {
+	Games []struct {
+		Schedule struct {
+			ID             int       `json:"id"`
+			Week           int       `json:"week"`
+			StartTime      time.Time `json:"startTime"`
+			AwayTeam       msfTeam   `json:"awayTeam"`
+			HomeTeam       msfTeam   `json:"homeTeam"`
+			ScheduleStatus string    `json:"scheduleStatus"`
+			PlayedStatus   string    `json:"playedStatus"`
+		} `json:"schedule"`
+		Score struct {
+			CurrentQuarter                 *int `json:"currentQuarter"`
+			CurrentIntermission            *int `json:"currentIntermission"`
+			CurrentQuarterSecondsRemaining *int `json:"currentQuarterSecondsRemaining"`
+			AwayScoreTotal                 *int `json:"awayScoreTotal"`
+			HomeScoreTotal                 *int `json:"homeScoreTotal"`
+		} `json:"score"`
+	} `json:"games"`
+}
+
+type msfTeam struct {
+	Abbreviation string `json:"abbreviation"`
+}
+
+// fetch reads one week's games, or the whole regular season when week is 0.
+// Weeks past the regular season are read from the playoff season.
+func (m *MySportsFeeds) fetch(ctx context.Context, season, week int, status string) ([]Game, error) {
+	feedSeason := fmt.Sprintf("%d-regular", season)
+	feedWeek := week
+	if week > RegularSeasonWeeks {
+		feedSeason = fmt.Sprintf("%d-playoff", season+1)
+		feedWeek = week - RegularSeasonWeeks
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/games.json", m.baseURL, feedSeason)
+	if week > 0 {
+		endpoint = fmt.Sprintf("%s/%s/week/%d/games.json", m.baseURL, feedSeason, feedWeek)
+	}
+	if status != "" {
+		endpoint += "?" + url.Values{"status": {status}}.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(m.apiKey, "MYSPORTSFEEDS")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("mysportsfeeds: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// MySportsFeeds answers 204 when a feed has nothing in it yet
+	if resp.StatusCode == http.StatusNoContent {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("mysportsfeeds: %s returned %s", feedSeason, resp.Status)
+	}
+
+	var body msfGames
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("mysportsfeeds: decode games: %w", err)
+	}
+
+	games := make([]Game, 0, len(body.Games))
+	for _, g := range body.Games {
+		game := Game{
+			ExternalID: strconv.Itoa(g.Schedule.ID),
+			SeasonYear: season,
+			Week:       g.Schedule.Week,
+			GameType:   models.GameTypeRegular,
+			HomeTeam:   teamAbbreviation(g.Schedule.HomeTeam.Abbreviation),
+			AwayTeam:   teamAbbreviation(g.Schedule.AwayTeam.Abbreviation),
+			Kickoff:    g.Schedule.StartTime.UTC(),
+			Status:     gameStatus(g.Schedule.ScheduleStatus, g.Schedule.PlayedStatus),
+		}
+		// The break after the second quarter is intermission 2
+		if game.Status == models.GameStatusInProgress && g.Score.CurrentIntermission != nil && *g.Score.CurrentIntermission == 2 {
+			game.Status = models.GameStatusHalftime
+		}
+		if week > RegularSeasonWeeks {
+			game.Week = RegularSeasonWeeks + g.Schedule.Week
+			game.GameType = models.GameTypePlayoff
+			if g.Schedule.Week == PlayoffRounds {
+				game.GameType = models.GameTypeSuperBowl
+			}
+		}
+
+		if g.Score.HomeScoreTotal != nil {
+			game.HomeScore = *g.Score.HomeScoreTotal
+		}
+		if g.Score.AwayScoreTotal != nil {
+			game.AwayScore = *g.Score.AwayScoreTotal
+		}
+		if g.Score.CurrentQuarter != nil {
+			game.Quarter = *g.Score.CurrentQuarter
+		}
+		if s := g.Score.CurrentQuarterSecondsRemaining; s != nil {
+			game.TimeRemaining = fmt.Sprintf("%d:%02d", *s/60, *s%60)
+		}
+
+		games = append(games, game)
+	}
+	return games, nil
+}
+
+func teamAbbreviation(abbreviation string) string {
+	if alias, ok := teamAliases[abbreviation]; ok {
+		return alias
+	}
+	return abbreviation
+}
+
+// gameStatus maps MySportsFeeds schedule and played statuses to ours
+func gameStatus(scheduleStatus, playedStatus string) string {
+	if scheduleStatus == "POSTPONED" {
+		return models.GameStatusPostponed
+	}
+
+	switch playedStatus {
+	case "LIVE":
+		return models.GameStatusInProgress
+	case "COMPLETED", "COMPLETED_PENDING_REVIEW":
+		return models.GameStatusCompleted
+	default:
+		return models.GameStatusScheduled
+	}
+}
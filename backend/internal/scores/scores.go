@@ -0,0 +1,74 @@
+// Package scores fetches the NFL schedule and game scores from an outside
+// source so games can be loaded without hand-written SQL.
+package scores
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"touchdown-tally/internal/config"
+)
+
+// Season structure shared by every provider. Playoff rounds are numbered on
+// from the regular season, so the wild card round is week 19.
+const (
+	RegularSeasonWeeks = 18
+	PlayoffRounds      = 4 // the last round is the Super Bowl
+)
+
+// Provider names accepted in config.ScoreProvider
+const (
+	ProviderMySportsFeeds = "mysportsfeeds"
+	ProviderFixture       = "fixture"
+)
+
+// ErrNotConfigured is returned by New when the chosen provider is missing
+// the settings it needs
+var ErrNotConfigured = errors.New("score provider is not configured")
+
+// Game is one game as reported by a provider. Teams are identified by their
+// abbreviation in nfl_teams, and Status uses the models.GameStatus values.
+type Game struct {
+	ExternalID    string    `json:"external_id"`
+	SeasonYear    int       `json:"season_year"`
+	Week          int       `json:"week"`
+	GameType      string    `json:"game_type"`
+	HomeTeam      string    `json:"home_team"`
+	AwayTeam      string    `json:"away_team"`
+	Kickoff       time.Time `json:"kickoff"`
+	Status        string    `json:"status"`
+	HomeScore     int       `json:"home_score"`
+	AwayScore     int       `json:"away_score"`
+	Quarter       int       `json:"quarter"`
+	TimeRemaining string    `json:"time_remaining"`
+}
+
+// Provider is a source of NFL games and scores
+type Provider interface {
+	// Schedule returns every game of the season, regular season and playoffs
+	Schedule(ctx context.Context, season int) ([]Game, error)
+	// Live returns the week's games that are underway
+	Live(ctx context.Context, season, week int) ([]Game, error)
+	// Final returns the week's games that are over
+	Final(ctx context.Context, season, week int) ([]Game, error)
+}
+
+// New returns the provider chosen by cfg.ScoreProvider
+func New(cfg *config.Config) (Provider, error) {
+	switch cfg.ScoreProvider {
+	case ProviderMySportsFeeds:
+		if cfg.MySportsAPIKey == "" {
+			return nil, fmt.Errorf("%w: MYSPORTSFEEDS_API_KEY is not set", ErrNotConfigured)
+		}
+		return NewMySportsFeeds(cfg.MySportsAPIKey), nil
+	case ProviderFixture:
+		if cfg.ScoreFixturePath == "" {
+			return nil, fmt.Errorf("%w: SCORE_FIXTURE_PATH is not set", ErrNotConfigured)
+		}
+		return NewFixture(cfg.ScoreFixturePath), nil
+	default:
+		return nil, fmt.Errorf("unknown score provider %q", cfg.ScoreProvider)
+	}
+}
@@ -0,0 +1,241 @@
+package scores
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
+	"touchdown-tally/pkg/logger"
+)
+
+const (
+	// liveInterval is the polling interval while any game is underway or
+	// past its kickoff without having started
+	liveInterval = 30 * time.Second
+	// maxIdle caps how long the syncer sleeps between game windows
+	maxIdle = 6 * time.Hour
+	// scheduleRefresh is how often the full season schedule is reloaded to
+	// pick up flexed and rescheduled games
+	scheduleRefresh = 24 * time.Hour
+	// retryInterval is the wait after a failed sync
+	retryInterval = time.Minute
+)
+
+// Publisher is told about every change a sync makes to nfl_games: each
+// status change, then the IDs of every game added or updated
+type Publisher interface {
+	Publish(ctx context.Context, transitions []models.GameTransition)
+	PublishUpdates(ctx context.Context, gameIDs []int)
+}
+
+// Syncer copies the schedule and scores from a Provider into nfl_games
+type Syncer struct {
+	provider Provider
+	store    *store.Store
+	events   Publisher
+	logger   *logger.Logger
+	season   int
+	interval time.Duration
+	now      func() time.Time
+
+	teams        map[string]int
+	lastSchedule time.Time
+}
+
+// NewSyncer creates a syncer for season that polls at interval during game
+// windows, faster while games are live and less often between windows.
+// Intervals shorter than the live interval are raised to it.
+func NewSyncer(provider Provider, st *store.Store, events Publisher, logger *logger.Logger, season int, interval time.Duration) *Syncer {
+	if interval < liveInterval {
+		interval = liveInterval
+	}
+	return &Syncer{
+		provider: provider,
+		store:    st,
+		events:   events,
+		logger:   logger,
+		season:   season,
+		interval: interval,
+		now:      func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Run syncs until ctx is cancelled, waiting between syncs for as long as
+// Sync asks
+func (s *Syncer) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			wait, err := s.Sync(ctx)
+			if err != nil {
+				s.logger.Error("Failed to sync scores", "season", s.season, "error", err)
+				wait = retryInterval
+			}
+			timer.Reset(wait)
+		}
+	}
+}
+
+// Sync reloads the schedule when it is due, then fetches live and final
+// scores for every week with a game that has kicked off but is not yet
+// final. It returns how long to wait before the next sync.
+func (s *Syncer) Sync(ctx context.Context) (time.Duration, error) {
+	if s.now().Sub(s.lastSchedule) >= scheduleRefresh {
+		games, err := s.provider.Schedule(ctx, s.season)
+		if err != nil {
+			return 0, fmt.Errorf("schedule: %w", err)
+		}
+		if err := s.save(ctx, games); err != nil {
+			return 0, err
+		}
+		s.lastSchedule = s.now()
+	}
+
+	games, err := s.store.Games.List(ctx, store.GameFilter{SeasonYear: &s.season})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, week := range s.activeWeeks(games) {
+		live, err := s.provider.Live(ctx, s.season, week)
+		if err != nil {
+			return 0, fmt.Errorf("live scores for week %d: %w", week, err)
+		}
+		final, err := s.provider.Final(ctx, s.season, week)
+		if err != nil {
+			return 0, fmt.Errorf("final scores for week %d: %w", week, err)
+		}
+		if err := s.save(ctx, append(live, final...)); err != nil {
+			return 0, err
+		}
+	}
+
+	games, err = s.store.Games.List(ctx, store.GameFilter{SeasonYear: &s.season})
+	if err != nil {
+		return 0, err
+	}
+	return s.nextWait(games), nil
+}
+
+// activeWeeks returns the weeks, in order, with a game that has kicked off
+// but is not yet final
+func (s *Syncer) activeWeeks(games []models.GameWithTeams) []int {
+	now := s.now()
+	seen := make(map[int]bool)
+	var weeks []int
+	for _, game := range games {
+		if !started(game.NFLGame, now) || seen[game.Week] {
+			continue
+		}
+		seen[game.Week] = true
+		weeks = append(weeks, game.Week)
+	}
+	return weeks
+}
+
+// nextWait polls quickly while a game is live, at the configured interval
+// when the next kickoff is close, and otherwise sleeps until that kickoff
+func (s *Syncer) nextWait(games []models.GameWithTeams) time.Duration {
+	now := s.now()
+	var next time.Time
+	for _, game := range games {
+		if started(game.NFLGame, now) {
+			return liveInterval
+		}
+		waiting := game.Status == models.GameStatusScheduled || game.Status == models.GameStatusRescheduled
+		if waiting && (next.IsZero() || game.GameDate.Before(next)) {
+			next = game.GameDate
+		}
+	}
+
+	if next.IsZero() {
+		return maxIdle
+	}
+	wait := next.Sub(now)
+	switch {
+	case wait < s.interval:
+		return s.interval
+	case wait > maxIdle:
+		return maxIdle
+	default:
+		return wait
+	}
+}
+
+// started reports whether a game is underway, or should be going by its
+// kickoff time, and is not yet final
+func started(game models.NFLGame, now time.Time) bool {
+	switch game.Status {
+	case models.GameStatusScheduled, models.GameStatusRescheduled:
+		return !now.Before(game.GameDate)
+	default:
+		return game.IsLive()
+	}
+}
+
+// save resolves team abbreviations and upserts the games, skipping any whose
+// teams are unknown
+func (s *Syncer) save(ctx context.Context, feed []Game) error {
+	if len(feed) == 0 {
+		return nil
+	}
+
+	if s.teams == nil {
+		teams, err := s.store.Teams.List(ctx, store.TeamFilter{})
+		if err != nil {
+			return err
+		}
+		s.teams = make(map[string]int, len(teams))
+		for _, team := range teams {
+			s.teams[team.TeamAbbreviation] = team.TeamID
+		}
+	}
+
+	games := make([]models.NFLGame, 0, len(feed))
+	for _, g := range feed {
+		home, away := s.teams[g.HomeTeam], s.teams[g.AwayTeam]
+		if home == 0 || away == 0 || g.ExternalID == "" {
+			s.logger.Warn("Skipping game from score feed", "external_id", g.ExternalID,
+				"home_team", g.HomeTeam, "away_team", g.AwayTeam)
+			continue
+		}
+
+		games = append(games, models.NFLGame{
+			ExternalID:    g.ExternalID,
+			SeasonYear:    g.SeasonYear,
+			Week:          g.Week,
+			GameType:      g.GameType,
+			HomeTeamID:    home,
+			AwayTeamID:    away,
+			GameDate:      g.Kickoff,
+			HomeScore:     g.HomeScore,
+			AwayScore:     g.AwayScore,
+			Status:        g.Status,
+			Quarter:       g.Quarter,
+			TimeRemaining: g.TimeRemaining,
+		})
+	}
+
+	result, err := s.store.Games.Upsert(ctx, games)
+	if err != nil {
+		return err
+	}
+	for _, rejected := range result.Rejected {
+		s.logger.Warn("Ignoring invalid game status change from score feed", "game_id", rejected.GameID,
+			"season", rejected.SeasonYear, "week", rejected.Week, "from", rejected.From, "to", rejected.To)
+	}
+	if result.Changed > 0 {
+		s.logger.Info("Games synced", "season", s.season, "changed", result.Changed)
+	}
+
+	s.events.Publish(ctx, result.Transitions)
+	s.events.PublishUpdates(ctx, result.Updated)
+	return nil
+}
@@ -0,0 +1,289 @@
+// Package standings scores pools from NFL game results.
+package standings
+
+import (
+	"context"
+	"sort"
+
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
+)
+
+// Engine calculates standings for season-pick pools, where each member owns
+// up to four teams and scores whatever those teams earn on the field under
+// the pool's scoring rules, for weekly and confidence pools, where members
+// score their correct game picks, and for survivor pools, where members score
+// each week they survive
+type Engine struct {
+	store *store.Store
+}
+
+// NewEngine creates a standings engine backed by st
+func NewEngine(st *store.Store) *Engine {
+	return &Engine{store: st}
+}
+
+// Season calculates full-season standings from every completed game and
+// writes each pick's points_scored back to the database
+func (e *Engine) Season(ctx context.Context, poolID int) ([]models.StandingsEntry, error) {
+	pool, err := e.store.Pools.Get(ctx, poolID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := e.standings(ctx, pool, nil)
+	if err != nil {
+		return nil, err
+	}
+	if pool.PicksWeekly() || pool.PoolType == models.PoolTypeSurvivor {
+		return entries, nil
+	}
+
+	points := make(map[int]int)
+	for _, entry := range entries {
+		for _, team := range entry.Teams {
+			points[team.PickID] = team.Points
+		}
+	}
+	if err := e.store.Picks.SetPoints(ctx, points); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// Week calculates standings from the completed games of a single week.
+// Weekly results are not persisted.
+func (e *Engine) Week(ctx context.Context, poolID, week int) ([]models.StandingsEntry, error) {
+	pool, err := e.store.Pools.Get(ctx, poolID)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.standings(ctx, pool, &week)
+}
+
+// standings scores the pool by its type, then ranks it and breaks ties
+// between members level on points with the pool's tiebreak rules
+func (e *Engine) standings(ctx context.Context, pool *models.Pool, week *int) ([]models.StandingsEntry, error) {
+	var (
+		entries []models.StandingsEntry
+		tb      *tiebreaks
+		err     error
+	)
+	switch {
+	case pool.PicksWeekly():
+		entries, tb, err = e.weekly(ctx, pool, week)
+	case pool.PoolType == models.PoolTypeSurvivor:
+		entries, tb, err = e.survivor(ctx, pool, week)
+	default:
+		entries, tb, err = e.calculate(ctx, pool, week)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.loadPredictions(ctx, pool, week, tb); err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		if total, ok := tb.predictions[entries[i].UserID]; ok {
+			entries[i].Tiebreaker = &total
+		}
+	}
+
+	Rank(entries)
+	tb.breakTies(entries)
+	return entries, nil
+}
+
+func (e *Engine) calculate(ctx context.Context, pool *models.Pool, week *int) ([]models.StandingsEntry, *tiebreaks, error) {
+	members, err := e.store.Pools.ListMembers(ctx, pool.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	picks, err := e.store.Picks.ListByPool(ctx, pool.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	games, err := e.store.Games.List(ctx, store.GameFilter{
+		SeasonYear: &pool.Season,
+		Week:       week,
+		Final:      true,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	records := teamRecords(games, pool.Settings.Scoring)
+	entries, index := memberEntries(members)
+	tb := newTiebreaks(pool)
+
+	eliminated := make(map[int]int)
+	owners := make(map[int][]int)
+	for _, pick := range picks {
+		i, ok := index[pick.UserID]
+		if !ok {
+			// Picks left behind by members who have since left the pool
+			continue
+		}
+		owners[pick.TeamID] = append(owners[pick.TeamID], pick.UserID)
+		tb.picked(pick.UserID, pick.CreatedAt)
+
+		record := records[pick.TeamID]
+		record.PickID = pick.PickID
+		record.PickOrder = pick.PickOrder
+		record.TeamID = pick.TeamID
+		record.TeamAbbreviation = pick.Team.TeamAbbreviation
+		record.TeamName = pick.Team.TeamName
+
+		entry := &entries[i]
+		entry.Teams = append(entry.Teams, record)
+		entry.TeamsPicked++
+		entry.Wins += record.Wins
+		entry.Losses += record.Losses
+		entry.Ties += record.Ties
+		entry.TotalPoints += record.Points
+
+		if pick.IsEliminated {
+			eliminated[pick.UserID]++
+		}
+	}
+
+	for i := range entries {
+		entry := &entries[i]
+		entry.Eliminated = entry.TeamsPicked > 0 && eliminated[entry.UserID] == entry.TeamsPicked
+	}
+
+	// Members meet head to head whenever teams they own play each other
+	for _, game := range games {
+		winner, loser := game.HomeTeamID, game.AwayTeamID
+		switch game.WinnerID() {
+		case game.AwayTeamID:
+			winner, loser = loser, winner
+		case 0:
+			continue
+		}
+		for _, w := range owners[winner] {
+			for _, l := range owners[loser] {
+				tb.won(w, l)
+			}
+		}
+	}
+
+	return entries, tb, nil
+}
+
+// memberEntries creates an empty standings entry per member, with an index
+// from user ID to position
+func memberEntries(members []models.PoolMember) ([]models.StandingsEntry, map[int]int) {
+	entries := make([]models.StandingsEntry, 0, len(members))
+	index := make(map[int]int, len(members))
+	for _, member := range members {
+		index[member.UserID] = len(entries)
+		entries = append(entries, models.StandingsEntry{
+			UserID:      member.UserID,
+			Username:    member.Username,
+			DisplayName: member.DisplayName,
+		})
+	}
+	return entries, index
+}
+
+// teamRecords tallies wins, losses, ties and points for every team that
+// appears in games. Games must already be limited to completed ones.
+func teamRecords(games []models.GameWithTeams, rules models.ScoringRules) map[int]models.TeamRecord {
+	records := make(map[int]models.TeamRecord)
+
+	for _, game := range games {
+		home := records[game.HomeTeamID]
+		away := records[game.AwayTeamID]
+
+		home.PointsFor += game.HomeScore
+		home.PointsAgainst += game.AwayScore
+		away.PointsFor += game.AwayScore
+		away.PointsAgainst += game.HomeScore
+
+		switch {
+		case game.HomeScore > game.AwayScore:
+			home.Wins++
+			away.Losses++
+		case game.HomeScore < game.AwayScore:
+			home.Losses++
+			away.Wins++
+		default:
+			home.Ties++
+			away.Ties++
+		}
+
+		home.Points += gamePoints(rules, game, game.HomeScore, game.AwayScore)
+		away.Points += gamePoints(rules, game, game.AwayScore, game.HomeScore)
+
+		records[game.HomeTeamID] = home
+		records[game.AwayTeamID] = away
+	}
+
+	return records
+}
+
+// gamePoints scores one completed game for the team that scored teamScore
+// against opponentScore
+func gamePoints(rules models.ScoringRules, game models.GameWithTeams, teamScore, opponentScore int) int {
+	var points int
+	switch {
+	case teamScore > opponentScore:
+		points = rules.Win
+		if isDivisional(game) {
+			points += rules.DivisionalWinBonus
+		}
+	case teamScore < opponentScore:
+		points = -rules.LossPenalty
+	default:
+		points = rules.Tie
+	}
+
+	points += teamScore * rules.PointScored
+
+	switch game.GameType {
+	case models.GameTypePlayoff:
+		points *= rules.PlayoffMultiplier
+	case models.GameTypeSuperBowl:
+		points *= rules.SuperBowlMultiplier
+	}
+
+	return points
+}
+
+// isDivisional reports whether both teams play in the same division
+func isDivisional(game models.GameWithTeams) bool {
+	return game.HomeTeam.Conference == game.AwayTeam.Conference &&
+		game.HomeTeam.Division == game.AwayTeam.Division
+}
+
+// Rank orders entries by total points and assigns competition ranks: members
+// level on points share a rank and are marked as tied, and the next rank
+// skips accordingly (1, 2, 2, 4)
+func Rank(entries []models.StandingsEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].TotalPoints != entries[j].TotalPoints {
+			return entries[i].TotalPoints > entries[j].TotalPoints
+		}
+		if entries[i].DisplayName != entries[j].DisplayName {
+			return entries[i].DisplayName < entries[j].DisplayName
+		}
+		return entries[i].UserID < entries[j].UserID
+	})
+
+	for i := range entries {
+		entries[i].Tied = false
+		if i > 0 && entries[i].TotalPoints == entries[i-1].TotalPoints {
+			entries[i].Rank = entries[i-1].Rank
+			entries[i].Tied = true
+			entries[i-1].Tied = true
+			continue
+		}
+		entries[i].Rank = i + 1
+	}
+}
@@ -0,0 +1,171 @@
+package standings
+
+import (
+	"reflect"
+	"testing"
+
+	"touchdown-tally/internal/models"
+)
+
+// game returns a completed game between team 1 at home and team 2. East
+// sets both teams in the AFC East, making it a divisional game.
+func game(home, away int, gameType string, east bool) models.GameWithTeams {
+	g := models.GameWithTeams{
+		NFLGame: models.NFLGame{
+			HomeTeamID: 1,
+			AwayTeamID: 2,
+			HomeScore:  home,
+			AwayScore:  away,
+			GameType:   gameType,
+			Status:     models.GameStatusCompleted,
+		},
+		HomeTeam: models.NFLTeam{TeamID: 1, Conference: "AFC", Division: "East"},
+		AwayTeam: models.NFLTeam{TeamID: 2, Conference: "AFC", Division: "West"},
+	}
+	if east {
+		g.AwayTeam.Division = "East"
+	}
+	return g
+}
+
+func TestGamePoints(t *testing.T) {
+	rules := models.ScoringRules{
+		Win:                 3,
+		Tie:                 1,
+		LossPenalty:         2,
+		DivisionalWinBonus:  1,
+		PlayoffMultiplier:   2,
+		SuperBowlMultiplier: 4,
+	}
+	perPoint := rules
+	perPoint.PointScored = 1
+
+	tests := []struct {
+		name     string
+		rules    models.ScoringRules
+		game     models.GameWithTeams
+		wantHome int
+		wantAway int
+	}{
+		{name: "win and loss penalty", rules: rules, game: game(24, 10, models.GameTypeRegular, false), wantHome: 3, wantAway: -2},
+		{name: "tie", rules: rules, game: game(17, 17, models.GameTypeRegular, false), wantHome: 1, wantAway: 1},
+		{name: "divisional win bonus", rules: rules, game: game(10, 24, models.GameTypeRegular, true), wantHome: -2, wantAway: 4},
+		{name: "points scored", rules: perPoint, game: game(24, 10, models.GameTypeRegular, false), wantHome: 27, wantAway: 8},
+		{name: "playoff multiplier", rules: rules, game: game(24, 10, models.GameTypePlayoff, false), wantHome: 6, wantAway: -4},
+		{name: "super bowl multiplier", rules: rules, game: game(24, 10, models.GameTypeSuperBowl, false), wantHome: 12, wantAway: -8},
+		{name: "default rules", rules: models.DefaultScoringRules(), game: game(24, 10, models.GameTypeRegular, true), wantHome: 2, wantAway: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := tt.game
+			if got := gamePoints(tt.rules, g, g.HomeScore, g.AwayScore); got != tt.wantHome {
+				t.Errorf("home points = %d, want %d", got, tt.wantHome)
+			}
+			if got := gamePoints(tt.rules, g, g.AwayScore, g.HomeScore); got != tt.wantAway {
+				t.Errorf("away points = %d, want %d", got, tt.wantAway)
+			}
+		})
+	}
+}
+
+func TestTeamRecords(t *testing.T) {
+	games := []models.GameWithTeams{
+		game(24, 10, models.GameTypeRegular, false),
+		game(17, 17, models.GameTypeRegular, false),
+		game(3, 20, models.GameTypeRegular, false),
+	}
+
+	records := teamRecords(games, models.DefaultScoringRules())
+	want := map[int]models.TeamRecord{
+		1: {Wins: 1, Losses: 1, Ties: 1, PointsFor: 44, PointsAgainst: 47, Points: 3},
+		2: {Wins: 1, Losses: 1, Ties: 1, PointsFor: 47, PointsAgainst: 44, Points: 3},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("records = %+v, want %+v", records, want)
+	}
+}
+
+func TestRank(t *testing.T) {
+	type ranked struct {
+		name string
+		rank int
+		tied bool
+	}
+
+	tests := []struct {
+		name    string
+		entries []models.StandingsEntry
+		want    []ranked
+	}{
+		{
+			name: "competition ranking skips after a tie",
+			entries: []models.StandingsEntry{
+				{UserID: 1, DisplayName: "Dana", TotalPoints: 8},
+				{UserID: 2, DisplayName: "Ari", TotalPoints: 12},
+				{UserID: 3, DisplayName: "Cam", TotalPoints: 10},
+				{UserID: 4, DisplayName: "Bo", TotalPoints: 10},
+			},
+			want: []ranked{{"Ari", 1, false}, {"Bo", 2, true}, {"Cam", 2, true}, {"Dana", 4, false}},
+		},
+		{
+			name: "everyone level",
+			entries: []models.StandingsEntry{
+				{UserID: 1, DisplayName: "Cam", TotalPoints: 5},
+				{UserID: 2, DisplayName: "Ari", TotalPoints: 5},
+				{UserID: 3, DisplayName: "Bo", TotalPoints: 5},
+			},
+			want: []ranked{{"Ari", 1, true}, {"Bo", 1, true}, {"Cam", 1, true}},
+		},
+		{
+			name: "tie at the bottom",
+			entries: []models.StandingsEntry{
+				{UserID: 1, DisplayName: "Ari", TotalPoints: 9},
+				{UserID: 2, DisplayName: "Bo", TotalPoints: 7},
+				{UserID: 3, DisplayName: "Cam", TotalPoints: -1},
+				{UserID: 4, DisplayName: "Dana", TotalPoints: -1},
+			},
+			want: []ranked{{"Ari", 1, false}, {"Bo", 2, false}, {"Cam", 3, true}, {"Dana", 3, true}},
+		},
+		{
+			name: "stale tie flags are cleared",
+			entries: []models.StandingsEntry{
+				{UserID: 1, DisplayName: "Ari", TotalPoints: 9, Rank: 1, Tied: true},
+				{UserID: 2, DisplayName: "Bo", TotalPoints: 7, Rank: 1, Tied: true},
+			},
+			want: []ranked{{"Ari", 1, false}, {"Bo", 2, false}},
+		},
+		{
+			name: "same name falls back to user ID",
+			entries: []models.StandingsEntry{
+				{UserID: 7, DisplayName: "Sam", TotalPoints: 4},
+				{UserID: 3, DisplayName: "Sam", TotalPoints: 4},
+			},
+			want: []ranked{{"Sam", 1, true}, {"Sam", 1, true}},
+		},
+		{name: "no members", entries: []models.StandingsEntry{}, want: []ranked{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Rank(tt.entries)
+
+			got := make([]ranked, len(tt.entries))
+			for i, e := range tt.entries {
+				got[i] = ranked{e.DisplayName, e.Rank, e.Tied}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ranked %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	entries := []models.StandingsEntry{
+		{UserID: 7, DisplayName: "Sam", TotalPoints: 4},
+		{UserID: 3, DisplayName: "Sam", TotalPoints: 4},
+	}
+	Rank(entries)
+	if entries[0].UserID != 3 {
+		t.Errorf("members with the same name ordered %d first, want the lower user ID", entries[0].UserID)
+	}
+}
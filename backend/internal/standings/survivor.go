@@ -0,0 +1,76 @@
+package standings
+
+import (
+	"context"
+
+	"touchdown-tally/internal/models"
+)
+
+// survivor ranks a survivor pool by the number of weeks each member's team
+// won. Eliminated members keep the wins they had before going out.
+func (e *Engine) survivor(ctx context.Context, pool *models.Pool, week *int) ([]models.StandingsEntry, *tiebreaks, error) {
+	members, err := e.store.Pools.ListMembers(ctx, pool.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	picks, err := e.store.Survivor.Picks(ctx, pool.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	eliminations, err := e.store.Survivor.Eliminations(ctx, pool.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entries, index := memberEntries(members)
+	tb := newTiebreaks(pool)
+	byGame := make(map[int][]models.SurvivorPick)
+
+	for _, pick := range picks {
+		i, ok := index[pick.UserID]
+		if !ok || (week != nil && pick.Week != *week) {
+			continue
+		}
+
+		tb.picked(pick.UserID, pick.CreatedAt)
+		byGame[pick.GameID] = append(byGame[pick.GameID], pick)
+
+		entry := &entries[i]
+		entry.PicksMade++
+		switch pick.Result {
+		case models.SurvivorResultWin:
+			entry.Wins++
+			entry.PicksCorrect++
+			entry.TotalPoints++
+		case models.SurvivorResultLoss:
+			entry.Losses++
+		case models.SurvivorResultTie:
+			entry.Ties++
+		}
+	}
+
+	for _, elimination := range eliminations {
+		i, ok := index[elimination.UserID]
+		if !ok || (week != nil && elimination.Week > *week) {
+			continue
+		}
+		eliminationWeek := elimination.Week
+		entries[i].Eliminated = true
+		entries[i].EliminationWeek = &eliminationWeek
+	}
+
+	// Members meet head to head when they back opposite sides of a game
+	for _, picks := range byGame {
+		for _, a := range picks {
+			for _, b := range picks {
+				if a.Result == models.SurvivorResultWin && b.Result == models.SurvivorResultLoss {
+					tb.won(a.UserID, b.UserID)
+				}
+			}
+		}
+	}
+
+	return entries, tb, nil
+}
@@ -0,0 +1,186 @@
+package standings
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/tiebreak"
+)
+
+// tiebreaks collects what a pool's tiebreak rules need while its standings
+// are calculated
+type tiebreaks struct {
+	rules []string
+
+	// total is the tiebreaker game's final score, nil until it is final
+	total       *int
+	predictions map[int]int
+
+	// finished is when each member made the last of the picks counted in
+	// the standings
+	finished map[int]time.Time
+
+	// headToHead counts games won by the first member against the second
+	headToHead map[[2]int]int
+}
+
+func newTiebreaks(pool *models.Pool) *tiebreaks {
+	return &tiebreaks{
+		rules:       pool.Settings.Tiebreak.Rules,
+		predictions: make(map[int]int),
+		finished:    make(map[int]time.Time),
+		headToHead:  make(map[[2]int]int),
+	}
+}
+
+// picked records that the member made a pick at the given time
+func (tb *tiebreaks) picked(userID int, at time.Time) {
+	if at.After(tb.finished[userID]) {
+		tb.finished[userID] = at
+	}
+}
+
+// won records a game the winner's pick won against the loser's
+func (tb *tiebreaks) won(winner, loser int) {
+	if winner != loser {
+		tb.headToHead[[2]int{winner, loser}]++
+	}
+}
+
+// loadPredictions reads members' predictions for the pool's tiebreaker game
+// and, once it is final, its total score
+func (e *Engine) loadPredictions(ctx context.Context, pool *models.Pool, week *int, tb *tiebreaks) error {
+	game, err := tiebreak.Game(ctx, e.store, pool, week)
+	if errors.Is(err, tiebreak.ErrNoGame) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	predictions, err := e.store.Tiebreakers.List(ctx, pool.ID, game.GameID)
+	if err != nil {
+		return err
+	}
+	for _, prediction := range predictions {
+		tb.predictions[prediction.UserID] = prediction.TotalPoints
+	}
+
+	if game.IsFinal() {
+		total := game.HomeScore + game.AwayScore
+		tb.total = &total
+	}
+	return nil
+}
+
+// breakTies reorders each run of entries level on points by the pool's
+// tiebreak rules, applied in order until one separates them. Entries must
+// already be ranked. Entries no rule separates keep sharing a rank.
+func (tb *tiebreaks) breakTies(entries []models.StandingsEntry) {
+	if len(tb.rules) == 0 {
+		return
+	}
+
+	for start := 0; start < len(entries); {
+		end := start + 1
+		for end < len(entries) && entries[end].TotalPoints == entries[start].TotalPoints {
+			end++
+		}
+		if end-start > 1 {
+			tb.order(entries[start:end])
+		}
+		start = end
+	}
+}
+
+func (tb *tiebreaks) order(group []models.StandingsEntry) {
+	members := make([]int, len(group))
+	for i, entry := range group {
+		members[i] = entry.UserID
+	}
+
+	keys := make(map[int][]int64, len(group))
+	for _, userID := range members {
+		for _, rule := range tb.rules {
+			keys[userID] = append(keys[userID], tb.key(rule, userID, members))
+		}
+	}
+
+	// decider returns the first rule that separates a and b, or -1
+	decider := func(a, b int) int {
+		for r := range tb.rules {
+			if keys[a][r] != keys[b][r] {
+				return r
+			}
+		}
+		return -1
+	}
+
+	sort.SliceStable(group, func(i, j int) bool {
+		a, b := group[i].UserID, group[j].UserID
+		r := decider(a, b)
+		return r >= 0 && keys[a][r] < keys[b][r]
+	})
+
+	first := group[0].Rank
+	for i := range group {
+		group[i].Tied = false
+		group[i].TiebreakRule = ""
+	}
+	for i := 1; i < len(group); i++ {
+		r := decider(group[i-1].UserID, group[i].UserID)
+		if r < 0 {
+			group[i].Rank = group[i-1].Rank
+			group[i].Tied = true
+			group[i-1].Tied = true
+			continue
+		}
+
+		group[i].Rank = first + i
+		group[i].TiebreakRule = tb.rules[r]
+		if group[i-1].TiebreakRule == "" {
+			group[i-1].TiebreakRule = tb.rules[r]
+		}
+	}
+}
+
+// key scores a member under one rule among the members tied with them.
+// Lower keys rank higher.
+func (tb *tiebreaks) key(rule string, userID int, tied []int) int64 {
+	switch rule {
+	case models.TiebreakClosest:
+		if tb.total == nil {
+			return 0
+		}
+		prediction, ok := tb.predictions[userID]
+		if !ok {
+			return math.MaxInt64
+		}
+		// Every prediction at or under the total beats any that went over
+		over := int64(prediction - *tb.total)
+		if over > 0 {
+			return 1<<32 + over
+		}
+		return -over
+
+	case models.TiebreakHeadToHead:
+		var net int64
+		for _, other := range tied {
+			net += int64(tb.headToHead[[2]int{userID, other}])
+			net -= int64(tb.headToHead[[2]int{other, userID}])
+		}
+		return -net
+
+	case models.TiebreakEarliestPick:
+		finished, ok := tb.finished[userID]
+		if !ok {
+			return math.MaxInt64
+		}
+		return finished.UnixNano()
+	}
+	return 0
+}
@@ -0,0 +1,116 @@
+package standings
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"touchdown-tally/internal/models"
+)
+
+func TestBreakTies(t *testing.T) {
+	const (
+		dana = iota + 1
+		ari
+		bo
+		cam
+	)
+	type ranked struct {
+		name string
+		rank int
+		tied bool
+		rule string
+	}
+	total := 45
+	start := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	closest := models.TiebreakClosest
+	headToHead := models.TiebreakHeadToHead
+	earliest := models.TiebreakEarliestPick
+
+	tests := []struct {
+		name string
+		tb   tiebreaks
+		want []ranked
+	}{
+		{
+			name: "closest without going over",
+			tb: tiebreaks{
+				rules:       []string{closest},
+				total:       &total,
+				predictions: map[int]int{ari: 47, bo: 44, cam: 40},
+			},
+			want: []ranked{{"Bo", 2, false, closest}, {"Cam", 3, false, closest}, {"Ari", 4, false, closest}},
+		},
+		{
+			name: "missing prediction ranks behind one that went over",
+			tb: tiebreaks{
+				rules:       []string{closest},
+				total:       &total,
+				predictions: map[int]int{ari: 50, cam: 45},
+			},
+			want: []ranked{{"Cam", 2, false, closest}, {"Ari", 3, false, closest}, {"Bo", 4, false, closest}},
+		},
+		{
+			name: "unfinished tiebreaker game falls through to the next rule",
+			tb: tiebreaks{
+				rules:       []string{closest, earliest},
+				predictions: map[int]int{ari: 44, bo: 60, cam: 45},
+				finished:    map[int]time.Time{ari: start.Add(2 * time.Hour), bo: start, cam: start.Add(time.Hour)},
+			},
+			want: []ranked{{"Bo", 2, false, earliest}, {"Cam", 3, false, earliest}, {"Ari", 4, false, earliest}},
+		},
+		{
+			name: "level predictions fall through to the next rule",
+			tb: tiebreaks{
+				rules:       []string{closest, earliest},
+				total:       &total,
+				predictions: map[int]int{ari: 44, bo: 44, cam: 30},
+				finished:    map[int]time.Time{ari: start.Add(time.Hour), bo: start, cam: start},
+			},
+			want: []ranked{{"Bo", 2, false, earliest}, {"Ari", 3, false, earliest}, {"Cam", 4, false, closest}},
+		},
+		{
+			name: "head to head counts wins against every tied member",
+			tb: tiebreaks{
+				rules:      []string{headToHead},
+				headToHead: map[[2]int]int{{ari, bo}: 2, {bo, ari}: 1, {cam, bo}: 1},
+			},
+			want: []ranked{{"Ari", 2, true, ""}, {"Cam", 2, true, headToHead}, {"Bo", 4, false, headToHead}},
+		},
+		{
+			name: "members no rule separates share a rank",
+			tb:   tiebreaks{rules: []string{earliest}},
+			want: []ranked{{"Ari", 2, true, ""}, {"Bo", 2, true, ""}, {"Cam", 2, true, ""}},
+		},
+		{
+			name: "no rules",
+			tb: tiebreaks{
+				finished: map[int]time.Time{ari: start.Add(time.Hour), bo: start, cam: start},
+			},
+			want: []ranked{{"Ari", 2, true, ""}, {"Bo", 2, true, ""}, {"Cam", 2, true, ""}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Dana leads outright and is never touched by the tiebreak
+			entries := []models.StandingsEntry{
+				{UserID: ari, DisplayName: "Ari", TotalPoints: 10},
+				{UserID: bo, DisplayName: "Bo", TotalPoints: 10},
+				{UserID: cam, DisplayName: "Cam", TotalPoints: 10},
+				{UserID: dana, DisplayName: "Dana", TotalPoints: 12},
+			}
+			Rank(entries)
+			tt.tb.breakTies(entries)
+
+			got := make([]ranked, len(entries))
+			for i, e := range entries {
+				got[i] = ranked{e.DisplayName, e.Rank, e.Tied, e.TiebreakRule}
+			}
+			want := append([]ranked{{"Dana", 1, false, ""}}, tt.want...)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ranked %+v, want %+v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package standings
+
+import (
+	"context"
+	"sort"
+
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
+)
+
+// weekly scores a weekly or confidence pool from its game picks. Each pick
+// that named the winner of a completed game earns its value: one point, or
+// its confidence in confidence pools. Picks on ties score nothing.
+// Standings are worked out from the games themselves, so they do not wait
+// for the grader to catch up. Each entry also carries its per-week totals.
+func (e *Engine) weekly(ctx context.Context, pool *models.Pool, week *int) ([]models.StandingsEntry, *tiebreaks, error) {
+	members, err := e.store.Pools.ListMembers(ctx, pool.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	picks, err := e.store.WeeklyPicks.List(ctx, pool.ID, pool.Season, week)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	games, err := e.store.Games.List(ctx, store.GameFilter{
+		SeasonYear: &pool.Season,
+		Week:       week,
+		Final:      true,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	winners := make(map[int]int, len(games))
+	for _, game := range games {
+		winners[game.GameID] = game.WinnerID()
+	}
+
+	entries, index := memberEntries(members)
+	tb := newTiebreaks(pool)
+	byGame := make(map[int][]models.WeeklyPick)
+	weeks := make([]map[int]*models.WeekTotals, len(entries))
+	for i := range weeks {
+		weeks[i] = make(map[int]*models.WeekTotals)
+	}
+
+	for _, pick := range picks {
+		i, ok := index[pick.UserID]
+		if !ok {
+			continue
+		}
+
+		tb.picked(pick.UserID, pick.CreatedAt)
+		byGame[pick.GameID] = append(byGame[pick.GameID], pick)
+
+		entry := &entries[i]
+		totals := weeks[i][pick.Week]
+		if totals == nil {
+			totals = &models.WeekTotals{Week: pick.Week}
+			weeks[i][pick.Week] = totals
+		}
+		entry.PicksMade++
+		totals.PicksMade++
+
+		winner, final := winners[pick.GameID]
+		switch {
+		case !final:
+		case pick.TeamID == winner:
+			points := pick.Value(pool.PoolType)
+			entry.PicksCorrect++
+			entry.Wins++
+			entry.TotalPoints += points
+			totals.PicksCorrect++
+			totals.Points += points
+		default:
+			entry.Losses++
+		}
+	}
+
+	for i := range entries {
+		for _, totals := range weeks[i] {
+			entries[i].Weeks = append(entries[i].Weeks, *totals)
+		}
+		sort.Slice(entries[i].Weeks, func(a, b int) bool {
+			return entries[i].Weeks[a].Week < entries[i].Weeks[b].Week
+		})
+	}
+
+	// Members meet head to head on games they picked differently
+	for gameID, winner := range winners {
+		if winner == 0 {
+			continue
+		}
+		for _, a := range byGame[gameID] {
+			for _, b := range byGame[gameID] {
+				if a.TeamID == winner && b.TeamID != winner {
+					tb.won(a.UserID, b.UserID)
+				}
+			}
+		}
+	}
+
+	return entries, tb, nil
+}
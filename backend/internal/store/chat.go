@@ -0,0 +1,506 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"touchdown-tally/internal/database"
+	"touchdown-tally/internal/models"
+)
+
+// ChatStore persists pool chat messages
+type ChatStore interface {
+	// Save inserts the message and sets its ID
+	Save(ctx context.Context, message *models.ChatMessage) error
+	// History returns up to limit messages, oldest first, skipping deleted
+	// ones. With no cursor they are the newest messages; otherwise they are
+	// the messages nearest the cursor, after it when after is set and before
+	// it when not.
+	History(ctx context.Context, poolID int, cursor *models.ChatCursor, after bool, limit int) ([]models.ChatMessage, error)
+	// Cursor returns the history position of a pool message, deleted or
+	// not, or ErrNotFound
+	Cursor(ctx context.Context, poolID, messageID int) (*models.ChatCursor, error)
+	// Get returns a message that has not been deleted
+	Get(ctx context.Context, messageID int) (*models.ChatMessage, error)
+	// Recent returns the IDs of a user's messages in a pool posted since the
+	// given time, skipping deleted ones and moderation notices
+	Recent(ctx context.Context, poolID, userID int, since time.Time) ([]int, error)
+
+	// Moderate applies a moderation action and records it in the pool's
+	// moderation log, setting its ID. Deletions mark action.MessageIDs
+	// deleted, returning ErrNotFound if none were left to delete; mutes and
+	// timeouts replace any the target already has, and unmutes return
+	// ErrNotFound if there is none.
+	Moderate(ctx context.Context, action *models.ChatModerationAction) error
+	// ModerationLog returns a pool's moderation actions, newest first
+	ModerationLog(ctx context.Context, poolID, limit int) ([]models.ChatModerationAction, error)
+	// ActiveMute returns the user's mute in force at now, or ErrNotFound
+	ActiveMute(ctx context.Context, poolID, userID int, now time.Time) (*models.ChatMute, error)
+
+	// Expired returns up to limit of a pool's messages, oldest first, that
+	// fall outside its retention: behind the newest keep messages not
+	// deleted by a moderator, or posted before the given time. A keep of
+	// zero or a zero time leaves that limit off.
+	Expired(ctx context.Context, poolID, keep int, before time.Time, limit int) ([]models.ArchivedChatMessage, error)
+	// SaveArchive stores an export of a pool's messages, setting its ID,
+	// and deletes the exported messages in the same transaction
+	SaveArchive(ctx context.Context, archive *models.ChatArchive, data []byte, messageIDs []int) error
+	// Archives returns a pool's chat archives, newest first
+	Archives(ctx context.Context, poolID int) ([]models.ChatArchive, error)
+	// ArchiveData returns a pool's chat archive with its export, or ErrNotFound
+	ArchiveData(ctx context.Context, poolID, archiveID int) (*models.ChatArchive, []byte, error)
+	// DeleteArchives removes archives created before the given time
+	DeleteArchives(ctx context.Context, before time.Time) (int64, error)
+}
+
+type chatStore struct {
+	db *database.DB
+}
+
+func (s *chatStore) Save(ctx context.Context, message *models.ChatMessage) error {
+	id, err := s.db.InsertReturningID(ctx, `
+		INSERT INTO chat_messages (pool_id, user_id, content, message_type, created_at)
+		VALUES (?, ?, ?, ?, ?)`,
+		"message_id", message.PoolID, message.UserID, message.Message, message.MessageType,
+		message.Timestamp.UTC(),
+	)
+	if err != nil {
+		return err
+	}
+
+	message.ID = int(id)
+	return nil
+}
+
+func (s *chatStore) History(ctx context.Context, poolID int, cursor *models.ChatCursor, after bool, limit int) ([]models.ChatMessage, error) {
+	// Walk away from the cursor so the limit keeps the messages nearest it
+	where, order := "", "DESC"
+	args := []interface{}{poolID, false}
+	if cursor != nil {
+		at := cursor.CreatedAt.UTC()
+		if after {
+			where = "AND (cm.created_at > ? OR (cm.created_at = ? AND cm.message_id > ?))"
+			order = "ASC"
+		} else {
+			where = "AND (cm.created_at < ? OR (cm.created_at = ? AND cm.message_id < ?))"
+		}
+		args = append(args, at, at, cursor.MessageID)
+	}
+	args = append(args, limit)
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT cm.message_id, cm.pool_id, cm.user_id, up.display_name,
+		       cm.content, cm.message_type, cm.created_at
+		FROM chat_messages cm
+		JOIN user_profiles up ON cm.user_id = up.user_id
+		WHERE cm.pool_id = ? AND cm.is_deleted = ? `+where+`
+		ORDER BY cm.created_at `+order+`, cm.message_id `+order+`
+		LIMIT ?`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := []models.ChatMessage{}
+	for rows.Next() {
+		var msg models.ChatMessage
+		err := rows.Scan(
+			&msg.ID, &msg.PoolID, &msg.UserID, &msg.DisplayName,
+			&msg.Message, &msg.MessageType, &msg.Timestamp,
+		)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Reverse a backward walk so the oldest message comes first
+	if order == "DESC" {
+		for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+			messages[i], messages[j] = messages[j], messages[i]
+		}
+	}
+
+	return messages, nil
+}
+
+func (s *chatStore) Cursor(ctx context.Context, poolID, messageID int) (*models.ChatCursor, error) {
+	cursor := models.ChatCursor{MessageID: messageID}
+	err := s.db.QueryRowContext(ctx, `
+		SELECT created_at FROM chat_messages WHERE message_id = ? AND pool_id = ?`,
+		messageID, poolID,
+	).Scan(&cursor.CreatedAt)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &cursor, nil
+}
+
+func (s *chatStore) Get(ctx context.Context, messageID int) (*models.ChatMessage, error) {
+	var msg models.ChatMessage
+	err := s.db.QueryRowContext(ctx, `
+		SELECT cm.message_id, cm.pool_id, cm.user_id, up.display_name,
+		       cm.content, cm.message_type, cm.created_at
+		FROM chat_messages cm
+		JOIN user_profiles up ON cm.user_id = up.user_id
+		WHERE cm.message_id = ? AND cm.is_deleted = ?`,
+		messageID, false,
+	).Scan(
+		&msg.ID, &msg.PoolID, &msg.UserID, &msg.DisplayName,
+		&msg.Message, &msg.MessageType, &msg.Timestamp,
+	)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &msg, nil
+}
+
+func (s *chatStore) Recent(ctx context.Context, poolID, userID int, since time.Time) ([]int, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT message_id FROM chat_messages
+		WHERE pool_id = ? AND user_id = ? AND is_deleted = ? AND message_type <> ? AND created_at >= ?
+		ORDER BY created_at, message_id`,
+		poolID, userID, false, models.ChatMessageModeration, since.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+func (s *chatStore) Moderate(ctx context.Context, action *models.ChatModerationAction) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	switch action.Action {
+	case models.ModerationDeleteMessage, models.ModerationClearMessages:
+		deleted := make([]int, 0, len(action.MessageIDs))
+		for _, id := range action.MessageIDs {
+			result, err := tx.ExecContext(ctx, `
+				UPDATE chat_messages SET is_deleted = ?, deleted_by = ?, deleted_at = ?
+				WHERE message_id = ? AND pool_id = ? AND is_deleted = ?`,
+				true, action.ModeratorID, action.CreatedAt.UTC(), id, action.PoolID, false,
+			)
+			if err != nil {
+				return err
+			}
+			if n, err := result.RowsAffected(); err == nil && n > 0 {
+				deleted = append(deleted, id)
+			}
+		}
+		if len(deleted) == 0 {
+			return ErrNotFound
+		}
+		action.MessageIDs = deleted
+
+	case models.ModerationMute, models.ModerationTimeout:
+		var expiresAt interface{}
+		if action.ExpiresAt != nil {
+			expiresAt = action.ExpiresAt.UTC()
+		}
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO chat_mutes (pool_id, user_id, kind, muted_by, reason, expires_at, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (pool_id, user_id) DO UPDATE
+			SET kind = excluded.kind, muted_by = excluded.muted_by, reason = excluded.reason,
+			    expires_at = excluded.expires_at, created_at = excluded.created_at`,
+			action.PoolID, action.TargetUserID, action.Action, action.ModeratorID, action.Reason,
+			expiresAt, action.CreatedAt.UTC(),
+		)
+		if err != nil {
+			return err
+		}
+
+	case models.ModerationUnmute:
+		result, err := tx.ExecContext(ctx, `
+			DELETE FROM chat_mutes
+			WHERE pool_id = ? AND user_id = ? AND (expires_at IS NULL OR expires_at > ?)`,
+			action.PoolID, action.TargetUserID, action.CreatedAt.UTC(),
+		)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err == nil && n == 0 {
+			return ErrNotFound
+		}
+	}
+
+	var messageIDs interface{}
+	if len(action.MessageIDs) > 0 {
+		raw, err := json.Marshal(action.MessageIDs)
+		if err != nil {
+			return err
+		}
+		messageIDs = string(raw)
+	}
+	var targetUserID, expiresAt interface{}
+	if action.TargetUserID != 0 {
+		targetUserID = action.TargetUserID
+	}
+	if action.ExpiresAt != nil {
+		expiresAt = action.ExpiresAt.UTC()
+	}
+
+	id, err := tx.InsertReturningID(ctx, `
+		INSERT INTO chat_moderation_actions (pool_id, moderator_id, action, target_user_id, message_ids,
+		                                     reason, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`, "action_id",
+		action.PoolID, action.ModeratorID, action.Action, targetUserID, messageIDs,
+		action.Reason, expiresAt, action.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return err
+	}
+	action.ActionID = int(id)
+
+	return tx.Commit()
+}
+
+func (s *chatStore) ModerationLog(ctx context.Context, poolID, limit int) ([]models.ChatModerationAction, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT a.action_id, a.pool_id, COALESCE(a.moderator_id, 0), COALESCE(moderator.display_name, ''),
+		       a.action, COALESCE(a.target_user_id, 0), COALESCE(target.display_name, ''),
+		       COALESCE(a.message_ids, ''), COALESCE(a.reason, ''), a.expires_at, a.created_at
+		FROM chat_moderation_actions a
+		LEFT JOIN user_profiles moderator ON a.moderator_id = moderator.user_id
+		LEFT JOIN user_profiles target ON a.target_user_id = target.user_id
+		WHERE a.pool_id = ?
+		ORDER BY a.created_at DESC, a.action_id DESC
+		LIMIT ?`,
+		poolID, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	actions := []models.ChatModerationAction{}
+	for rows.Next() {
+		var (
+			action     models.ChatModerationAction
+			messageIDs string
+		)
+		err := rows.Scan(
+			&action.ActionID, &action.PoolID, &action.ModeratorID, &action.ModeratorName,
+			&action.Action, &action.TargetUserID, &action.TargetName,
+			&messageIDs, &action.Reason, &action.ExpiresAt, &action.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if messageIDs != "" {
+			if err := json.Unmarshal([]byte(messageIDs), &action.MessageIDs); err != nil {
+				return nil, err
+			}
+		}
+		actions = append(actions, action)
+	}
+
+	return actions, rows.Err()
+}
+
+func (s *chatStore) ActiveMute(ctx context.Context, poolID, userID int, now time.Time) (*models.ChatMute, error) {
+	var mute models.ChatMute
+	err := s.db.QueryRowContext(ctx, `
+		SELECT pool_id, user_id, kind, COALESCE(muted_by, 0), COALESCE(reason, ''), expires_at, created_at
+		FROM chat_mutes
+		WHERE pool_id = ? AND user_id = ? AND (expires_at IS NULL OR expires_at > ?)`,
+		poolID, userID, now.UTC(),
+	).Scan(
+		&mute.PoolID, &mute.UserID, &mute.Kind, &mute.MutedBy, &mute.Reason, &mute.ExpiresAt, &mute.CreatedAt,
+	)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &mute, nil
+}
+
+func (s *chatStore) Expired(ctx context.Context, poolID, keep int, before time.Time, limit int) ([]models.ArchivedChatMessage, error) {
+	var (
+		conditions []string
+		args       = []interface{}{poolID}
+	)
+
+	if keep > 0 {
+		// The newest message past the ones kept marks where pruning starts
+		var (
+			boundaryAt time.Time
+			boundaryID int
+		)
+		err := s.db.QueryRowContext(ctx, `
+			SELECT created_at, message_id FROM chat_messages
+			WHERE pool_id = ? AND is_deleted = ?
+			ORDER BY created_at DESC, message_id DESC
+			LIMIT 1 OFFSET ?`,
+			poolID, false, keep,
+		).Scan(&boundaryAt, &boundaryID)
+		switch {
+		case err == nil:
+			conditions = append(conditions, "cm.created_at < ? OR (cm.created_at = ? AND cm.message_id <= ?)")
+			args = append(args, boundaryAt.UTC(), boundaryAt.UTC(), boundaryID)
+		case !errors.Is(err, sql.ErrNoRows):
+			return nil, err
+		}
+	}
+	if !before.IsZero() {
+		conditions = append(conditions, "cm.created_at < ?")
+		args = append(args, before.UTC())
+	}
+
+	messages := []models.ArchivedChatMessage{}
+	if len(conditions) == 0 {
+		return messages, nil
+	}
+	args = append(args, limit)
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT cm.message_id, COALESCE(cm.user_id, 0), COALESCE(up.display_name, ''),
+		       cm.content, COALESCE(cm.message_type, ''), cm.created_at,
+		       cm.is_deleted, COALESCE(cm.deleted_by, 0), cm.deleted_at
+		FROM chat_messages cm
+		LEFT JOIN user_profiles up ON cm.user_id = up.user_id
+		WHERE cm.pool_id = ? AND ((`+strings.Join(conditions, ") OR (")+`))
+		ORDER BY cm.created_at, cm.message_id
+		LIMIT ?`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var msg models.ArchivedChatMessage
+		err := rows.Scan(
+			&msg.ID, &msg.UserID, &msg.DisplayName,
+			&msg.Message, &msg.MessageType, &msg.Timestamp,
+			&msg.Deleted, &msg.DeletedBy, &msg.DeletedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+
+	return messages, rows.Err()
+}
+
+func (s *chatStore) SaveArchive(ctx context.Context, archive *models.ChatArchive, data []byte, messageIDs []int) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	id, err := tx.InsertReturningID(ctx, `
+		INSERT INTO chat_archives (pool_id, message_count, first_message_at, last_message_at,
+		                           size_bytes, data, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`, "archive_id",
+		archive.PoolID, archive.MessageCount, archive.FirstMessageAt.UTC(), archive.LastMessageAt.UTC(),
+		len(data), data, archive.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, messageID := range messageIDs {
+		_, err := tx.ExecContext(ctx, `
+			DELETE FROM chat_messages WHERE message_id = ? AND pool_id = ?`,
+			messageID, archive.PoolID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	archive.ArchiveID = int(id)
+	archive.SizeBytes = len(data)
+	return nil
+}
+
+func (s *chatStore) Archives(ctx context.Context, poolID int) ([]models.ChatArchive, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT archive_id, pool_id, message_count, first_message_at, last_message_at, size_bytes, created_at
+		FROM chat_archives
+		WHERE pool_id = ?
+		ORDER BY created_at DESC, archive_id DESC`,
+		poolID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	archives := []models.ChatArchive{}
+	for rows.Next() {
+		var archive models.ChatArchive
+		err := rows.Scan(
+			&archive.ArchiveID, &archive.PoolID, &archive.MessageCount, &archive.FirstMessageAt,
+			&archive.LastMessageAt, &archive.SizeBytes, &archive.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		archives = append(archives, archive)
+	}
+
+	return archives, rows.Err()
+}
+
+func (s *chatStore) ArchiveData(ctx context.Context, poolID, archiveID int) (*models.ChatArchive, []byte, error) {
+	var (
+		archive models.ChatArchive
+		data    []byte
+	)
+	err := s.db.QueryRowContext(ctx, `
+		SELECT archive_id, pool_id, message_count, first_message_at, last_message_at, size_bytes,
+		       created_at, data
+		FROM chat_archives
+		WHERE archive_id = ? AND pool_id = ?`,
+		archiveID, poolID,
+	).Scan(
+		&archive.ArchiveID, &archive.PoolID, &archive.MessageCount, &archive.FirstMessageAt,
+		&archive.LastMessageAt, &archive.SizeBytes, &archive.CreatedAt, &data,
+	)
+	if err != nil {
+		return nil, nil, notFound(err)
+	}
+	return &archive, data, nil
+}
+
+func (s *chatStore) DeleteArchives(ctx context.Context, before time.Time) (int64, error) {
+	result, err := s.db.ExecContext(ctx, `
+		DELETE FROM chat_archives WHERE created_at < ?`,
+		before.UTC(),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
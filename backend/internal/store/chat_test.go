@@ -0,0 +1,209 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"touchdown-tally/internal/database"
+	"touchdown-tally/internal/models"
+)
+
+// chatPool creates a pool over a migrated SQLite database whose chat holds,
+// oldest first, a, then b, c and d posted in the same second, then e, which
+// a moderator deleted, then f. Another pool has a message, x, posted
+// alongside b. It returns the store, the pool and the messages' IDs.
+func chatPool(t *testing.T) (*Store, int, map[string]int) {
+	t.Helper()
+	ctx := context.Background()
+
+	db, err := database.Connect("sqlite://" + filepath.Join(t.TempDir(), "chat.db"))
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := database.Migrate(db); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	st := New(db)
+
+	profile, err := st.Users.Register(ctx, "commish@example.com", "hash", "commish", "Commish")
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	pools := make([]int, 2)
+	for i := range pools {
+		pools[i], err = st.Pools.Create(ctx, NewPool{
+			Name:           "Trash Talk",
+			CommissionerID: profile.UserID,
+			SeasonYear:     2025,
+			MaxMembers:     10,
+			PoolType:       models.PoolTypeSeason,
+			Settings:       models.DefaultPoolSettings(),
+		})
+		if err != nil {
+			t.Fatalf("create pool: %v", err)
+		}
+	}
+
+	start := time.Date(2025, 9, 7, 18, 0, 0, 0, time.UTC)
+	posts := []struct {
+		name string
+		pool int
+		at   time.Duration
+	}{
+		{"a", pools[0], 0},
+		{"b", pools[0], time.Minute},
+		{"x", pools[1], time.Minute},
+		{"c", pools[0], time.Minute},
+		{"d", pools[0], time.Minute},
+		{"e", pools[0], 2 * time.Minute},
+		{"f", pools[0], 3 * time.Minute},
+	}
+	ids := make(map[string]int, len(posts))
+	for _, post := range posts {
+		message := models.ChatMessage{
+			PoolID:      post.pool,
+			UserID:      profile.UserID,
+			Message:     post.name,
+			MessageType: models.ChatMessageUser,
+			Timestamp:   start.Add(post.at),
+		}
+		if err := st.Chat.Save(ctx, &message); err != nil {
+			t.Fatalf("save %s: %v", post.name, err)
+		}
+		ids[post.name] = message.ID
+	}
+
+	err = st.Chat.Moderate(ctx, &models.ChatModerationAction{
+		PoolID:      pools[0],
+		ModeratorID: profile.UserID,
+		Action:      models.ModerationDeleteMessage,
+		MessageIDs:  []int{ids["e"]},
+		CreatedAt:   start.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("delete e: %v", err)
+	}
+	return st, pools[0], ids
+}
+
+func TestChatHistory(t *testing.T) {
+	tests := []struct {
+		name string
+		// anchor is the message the cursor is on, none for the newest page
+		anchor string
+		after  bool
+		limit  int
+		want   []string
+	}{
+		{name: "newest page", limit: 3, want: []string{"c", "d", "f"}},
+		{name: "whole history", limit: 10, want: []string{"a", "b", "c", "d", "f"}},
+		{name: "before a message sharing its second", anchor: "c", limit: 10, want: []string{"a", "b"}},
+		{name: "before keeps the nearest messages", anchor: "f", limit: 2, want: []string{"c", "d"}},
+		{name: "after skips deleted messages", anchor: "b", after: true, limit: 10, want: []string{"c", "d", "f"}},
+		{name: "after keeps the nearest messages", anchor: "b", after: true, limit: 2, want: []string{"c", "d"}},
+		{name: "after a deleted message", anchor: "e", after: true, limit: 10, want: []string{"f"}},
+		{name: "before a deleted message", anchor: "e", limit: 1, want: []string{"d"}},
+		{name: "nothing after the newest", anchor: "f", after: true, limit: 10, want: []string{}},
+		{name: "nothing before the oldest", anchor: "a", limit: 10, want: []string{}},
+	}
+
+	st, poolID, ids := chatPool(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			var cursor *models.ChatCursor
+			if tt.anchor != "" {
+				var err error
+				if cursor, err = st.Chat.Cursor(ctx, poolID, ids[tt.anchor]); err != nil {
+					t.Fatalf("cursor on %s: %v", tt.anchor, err)
+				}
+			}
+
+			messages, err := st.Chat.History(ctx, poolID, cursor, tt.after, tt.limit)
+			if err != nil {
+				t.Fatalf("history: %v", err)
+			}
+			got := []string{}
+			for _, message := range messages {
+				got = append(got, message.Message)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("history = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestChatHistoryPaging walks the whole history a page at a time in each
+// direction and checks no message is skipped or repeated
+func TestChatHistoryPaging(t *testing.T) {
+	ctx := context.Background()
+	st, poolID, _ := chatPool(t)
+	want := []string{"a", "b", "c", "d", "f"}
+
+	for _, after := range []bool{false, true} {
+		var got []string
+		var cursor *models.ChatCursor
+		if after {
+			// Start from just before the oldest message
+			cursor = &models.ChatCursor{}
+		}
+		for pages := 0; pages < len(want); pages++ {
+			page, err := st.Chat.History(ctx, poolID, cursor, after, 2)
+			if err != nil {
+				t.Fatalf("history: %v", err)
+			}
+			if len(page) == 0 {
+				break
+			}
+			var texts []string
+			for _, message := range page {
+				texts = append(texts, message.Message)
+			}
+			edge := page[0]
+			if after {
+				got = append(got, texts...)
+				edge = page[len(page)-1]
+			} else {
+				got = append(texts, got...)
+			}
+			cursor = &models.ChatCursor{CreatedAt: edge.Timestamp, MessageID: edge.ID}
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("paging with after %t read %v, want %v", after, got, want)
+		}
+	}
+}
+
+func TestChatCursor(t *testing.T) {
+	ctx := context.Background()
+	st, poolID, ids := chatPool(t)
+
+	tests := []struct {
+		name    string
+		message int
+		wantErr error
+	}{
+		{name: "message in the pool", message: ids["c"]},
+		{name: "deleted message", message: ids["e"]},
+		{name: "message in another pool", message: ids["x"], wantErr: ErrNotFound},
+		{name: "no such message", message: 999, wantErr: ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := st.Chat.Cursor(ctx, poolID, tt.message)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Cursor error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && cursor.MessageID != tt.message {
+				t.Errorf("cursor = %+v, want message %d", cursor, tt.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,365 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"touchdown-tally/internal/database"
+	"touchdown-tally/internal/models"
+)
+
+// DraftStore manages pool drafts, their pick order and selections
+type DraftStore interface {
+	// Create returns ErrConflict if the pool already has a draft
+	Create(ctx context.Context, draft models.Draft) (int, error)
+	GetByPool(ctx context.Context, poolID int) (*models.Draft, error)
+	// Reschedule returns ErrConflict once the draft has started
+	Reschedule(ctx context.Context, draftID int, scheduledAt time.Time, pickSeconds int) error
+	// ListDueToStart returns scheduled drafts whose start time has passed
+	ListDueToStart(ctx context.Context, now time.Time) ([]models.Draft, error)
+	// ListExpired returns running drafts whose pick clock has run out
+	ListExpired(ctx context.Context, now time.Time) ([]models.Draft, error)
+
+	// Start stores the first-round order (user IDs by slot) and puts the
+	// first pick on the clock. It returns ErrConflict if already started.
+	Start(ctx context.Context, draftID int, order []int, rounds int, startedAt, deadline time.Time) error
+	Order(ctx context.Context, draftID int) ([]models.DraftSlot, error)
+	Picks(ctx context.Context, draftID int) ([]models.DraftPick, error)
+	// RecordPick stores the selection and advances the clock to deadline. A
+	// nil deadline marks the last pick: the draft is completed and every
+	// selection is copied into season_picks in the same transaction.
+	// ErrConflict means the pick was no longer on the clock or the team was taken.
+	RecordPick(ctx context.Context, draftID int, pick models.DraftPick, deadline *time.Time) error
+
+	// Queue returns the member's autopick queue, best team first
+	Queue(ctx context.Context, poolID, userID int) ([]models.DraftQueueEntry, error)
+	// SetQueue replaces the member's autopick queue with teamIDs in rank order
+	SetQueue(ctx context.Context, poolID, userID int, teamIDs []int) error
+	// SetAbsent flags or unflags a member as absent from the pool's draft
+	SetAbsent(ctx context.Context, poolID, userID, flaggedBy int, absent bool) error
+}
+
+type draftStore struct {
+	db *database.DB
+}
+
+const draftColumns = `
+	draft_id, pool_id, status, rounds, pick_seconds, scheduled_at, started_at,
+	completed_at, current_pick, pick_deadline, COALESCE(created_by, 0), created_at, updated_at`
+
+func scanDraft(row rowScanner, draft *models.Draft) error {
+	return row.Scan(
+		&draft.DraftID, &draft.PoolID, &draft.Status, &draft.Rounds, &draft.PickSeconds,
+		&draft.ScheduledAt, &draft.StartedAt, &draft.CompletedAt, &draft.CurrentPick,
+		&draft.PickDeadline, &draft.CreatedBy, &draft.CreatedAt, &draft.UpdatedAt,
+	)
+}
+
+func (s *draftStore) list(ctx context.Context, query string, args ...interface{}) ([]models.Draft, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var drafts []models.Draft
+	for rows.Next() {
+		var draft models.Draft
+		if err := scanDraft(rows, &draft); err != nil {
+			return nil, err
+		}
+		drafts = append(drafts, draft)
+	}
+
+	return drafts, rows.Err()
+}
+
+func (s *draftStore) Create(ctx context.Context, draft models.Draft) (int, error) {
+	id, err := s.db.InsertReturningID(ctx, `
+		INSERT INTO drafts (pool_id, status, rounds, pick_seconds, scheduled_at, created_by)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		"draft_id", draft.PoolID, models.DraftStatusScheduled, draft.Rounds, draft.PickSeconds,
+		draft.ScheduledAt.UTC(), draft.CreatedBy,
+	)
+	if err != nil {
+		if s.db.IsUniqueViolation(err) {
+			return 0, ErrConflict
+		}
+		return 0, err
+	}
+	return int(id), nil
+}
+
+func (s *draftStore) GetByPool(ctx context.Context, poolID int) (*models.Draft, error) {
+	var draft models.Draft
+	row := s.db.QueryRowContext(ctx, "SELECT "+draftColumns+" FROM drafts WHERE pool_id = ?", poolID)
+	if err := scanDraft(row, &draft); err != nil {
+		return nil, notFound(err)
+	}
+	return &draft, nil
+}
+
+func (s *draftStore) Reschedule(ctx context.Context, draftID int, scheduledAt time.Time, pickSeconds int) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE drafts SET scheduled_at = ?, pick_seconds = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE draft_id = ? AND status = ?`,
+		scheduledAt.UTC(), pickSeconds, draftID, models.DraftStatusScheduled,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return ErrConflict
+	}
+	return nil
+}
+
+func (s *draftStore) ListDueToStart(ctx context.Context, now time.Time) ([]models.Draft, error) {
+	return s.list(ctx,
+		"SELECT "+draftColumns+" FROM drafts WHERE status = ? AND scheduled_at <= ? ORDER BY scheduled_at",
+		models.DraftStatusScheduled, now.UTC(),
+	)
+}
+
+func (s *draftStore) ListExpired(ctx context.Context, now time.Time) ([]models.Draft, error) {
+	return s.list(ctx,
+		"SELECT "+draftColumns+" FROM drafts WHERE status = ? AND pick_deadline <= ? ORDER BY pick_deadline",
+		models.DraftStatusInProgress, now.UTC(),
+	)
+}
+
+func (s *draftStore) Start(ctx context.Context, draftID int, order []int, rounds int, startedAt, deadline time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE drafts
+		SET status = ?, rounds = ?, started_at = ?, current_pick = 1, pick_deadline = ?,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE draft_id = ? AND status = ?`,
+		models.DraftStatusInProgress, rounds, startedAt.UTC(), deadline.UTC(),
+		draftID, models.DraftStatusScheduled,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return ErrConflict
+	}
+
+	for i, userID := range order {
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO draft_order (draft_id, slot, user_id) VALUES (?, ?, ?)",
+			draftID, i+1, userID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *draftStore) Order(ctx context.Context, draftID int) ([]models.DraftSlot, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT o.slot, o.user_id, up.display_name, a.user_id IS NOT NULL
+		FROM draft_order o
+		JOIN drafts d ON o.draft_id = d.draft_id
+		JOIN user_profiles up ON o.user_id = up.user_id
+		LEFT JOIN draft_absences a ON a.pool_id = d.pool_id AND a.user_id = o.user_id
+		WHERE o.draft_id = ?
+		ORDER BY o.slot`,
+		draftID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var order []models.DraftSlot
+	for rows.Next() {
+		var slot models.DraftSlot
+		if err := rows.Scan(&slot.Slot, &slot.UserID, &slot.DisplayName, &slot.Absent); err != nil {
+			return nil, err
+		}
+		order = append(order, slot)
+	}
+
+	return order, rows.Err()
+}
+
+func (s *draftStore) Picks(ctx context.Context, draftID int) ([]models.DraftPick, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT dp.overall_pick, dp.round, dp.user_id, dp.team_id,
+		       t.team_abbreviation, t.team_name, dp.is_auto, dp.picked_at
+		FROM draft_picks dp
+		JOIN nfl_teams t ON dp.team_id = t.team_id
+		WHERE dp.draft_id = ?
+		ORDER BY dp.overall_pick`,
+		draftID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var picks []models.DraftPick
+	for rows.Next() {
+		var pick models.DraftPick
+		err := rows.Scan(
+			&pick.OverallPick, &pick.Round, &pick.UserID, &pick.TeamID,
+			&pick.TeamAbbreviation, &pick.TeamName, &pick.IsAuto, &pick.PickedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		picks = append(picks, pick)
+	}
+
+	return picks, rows.Err()
+}
+
+func (s *draftStore) RecordPick(ctx context.Context, draftID int, pick models.DraftPick, deadline *time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var next interface{}
+	if deadline != nil {
+		next = deadline.UTC()
+	}
+
+	// Advancing only from the expected pick guards against a manual pick
+	// racing the clock
+	result, err := tx.ExecContext(ctx, `
+		UPDATE drafts
+		SET current_pick = current_pick + 1, pick_deadline = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE draft_id = ? AND status = ? AND current_pick = ?`,
+		next, draftID, models.DraftStatusInProgress, pick.OverallPick,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return ErrConflict
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO draft_picks (draft_id, overall_pick, round, user_id, team_id, is_auto, picked_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		draftID, pick.OverallPick, pick.Round, pick.UserID, pick.TeamID, pick.IsAuto, pick.PickedAt.UTC(),
+	)
+	if err != nil {
+		if tx.IsUniqueViolation(err) {
+			return ErrConflict
+		}
+		return err
+	}
+
+	if deadline == nil {
+		if err := completeDraft(ctx, tx, draftID, pick.PickedAt); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// completeDraft copies the draft's selections into season_picks, using the
+// round as the pick order, and marks the draft completed
+func completeDraft(ctx context.Context, tx *database.Tx, draftID int, completedAt time.Time) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO season_picks (pool_id, user_id, team_id, pick_order)
+		SELECT d.pool_id, dp.user_id, dp.team_id, dp.round
+		FROM draft_picks dp
+		JOIN drafts d ON dp.draft_id = d.draft_id
+		WHERE dp.draft_id = ?`,
+		draftID,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE drafts SET status = ?, completed_at = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE draft_id = ?`,
+		models.DraftStatusCompleted, completedAt.UTC(), draftID,
+	)
+	return err
+}
+
+func (s *draftStore) Queue(ctx context.Context, poolID, userID int) ([]models.DraftQueueEntry, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT q.rank, q.team_id, t.team_abbreviation, t.team_name
+		FROM draft_queues q
+		JOIN nfl_teams t ON q.team_id = t.team_id
+		WHERE q.pool_id = ? AND q.user_id = ?
+		ORDER BY q.rank`,
+		poolID, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var queue []models.DraftQueueEntry
+	for rows.Next() {
+		var entry models.DraftQueueEntry
+		if err := rows.Scan(&entry.Rank, &entry.TeamID, &entry.TeamAbbreviation, &entry.TeamName); err != nil {
+			return nil, err
+		}
+		queue = append(queue, entry)
+	}
+
+	return queue, rows.Err()
+}
+
+func (s *draftStore) SetQueue(ctx context.Context, poolID, userID int, teamIDs []int) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM draft_queues WHERE pool_id = ? AND user_id = ?", poolID, userID)
+	if err != nil {
+		return err
+	}
+
+	for i, teamID := range teamIDs {
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO draft_queues (pool_id, user_id, rank, team_id) VALUES (?, ?, ?, ?)",
+			poolID, userID, i+1, teamID,
+		)
+		if err != nil {
+			if tx.IsUniqueViolation(err) {
+				return ErrConflict
+			}
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *draftStore) SetAbsent(ctx context.Context, poolID, userID, flaggedBy int, absent bool) error {
+	if !absent {
+		_, err := s.db.ExecContext(ctx, "DELETE FROM draft_absences WHERE pool_id = ? AND user_id = ?", poolID, userID)
+		return err
+	}
+
+	_, err := s.db.ExecContext(ctx,
+		"INSERT INTO draft_absences (pool_id, user_id, flagged_by) VALUES (?, ?, ?)",
+		poolID, userID, flaggedBy,
+	)
+	if s.db.IsUniqueViolation(err) {
+		// Already flagged
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,368 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"touchdown-tally/internal/database"
+	"touchdown-tally/internal/models"
+)
+
+// GameFilter narrows a game listing; nil or empty fields are ignored
+type GameFilter struct {
+	SeasonYear *int
+	Week       *int
+	Status     string
+	GameType   string
+	// Final limits the listing to completed and corrected games
+	Final bool
+}
+
+// GameStore reads NFL games together with both teams
+type GameStore interface {
+	List(ctx context.Context, filter GameFilter) ([]models.GameWithTeams, error)
+	Get(ctx context.Context, gameID int) (*models.GameWithTeams, error)
+	// FirstKickoff returns the earliest game time in the week, or ErrNotFound
+	// if the week has no games yet
+	FirstKickoff(ctx context.Context, seasonYear, week int) (time.Time, error)
+	// Upsert writes games from a score feed keyed by external_id, adopting
+	// a hand-loaded row for the same matchup that has no external_id yet.
+	// Status changes must follow models.GameStatusPath, and new games must
+	// have a known status; games that do not are left untouched and
+	// reported as rejected. Overridden games are left untouched.
+	Upsert(ctx context.Context, games []models.NFLGame) (*UpsertResult, error)
+
+	// Correct writes a hand correction of a game's score and status, marks
+	// the game overridden and records the change in game_corrections. The
+	// status is written as given, without models.GameStatusPath; see
+	// corrections.Service. The correction's original values must still
+	// match the game, or Correct returns ErrConflict.
+	Correct(ctx context.Context, correction *models.GameCorrection) error
+	// Corrections returns a game's correction history, newest first
+	Corrections(ctx context.Context, gameID int) ([]models.GameCorrection, error)
+}
+
+// UpsertResult reports what GameStore.Upsert did
+type UpsertResult struct {
+	// Changed counts games added or updated
+	Changed int
+	// Updated lists the IDs of the games added or updated
+	Updated []int
+	// Transitions lists every status change made, one step at a time
+	Transitions []models.GameTransition
+	// Rejected lists status changes the feed asked for that are not allowed.
+	// A new game with an unknown status is listed with GameID 0 and an
+	// empty From.
+	Rejected []models.GameTransition
+}
+
+type gameStore struct {
+	db *database.DB
+}
+
+// gameWithTeamsSelect joins a game to its home (ht) and away (at) teams.
+// Rows must be read with scanGameWithTeams.
+const gameWithTeamsSelect = `
+	SELECT g.game_id, COALESCE(g.external_id, ''), g.season_year, g.week, g.game_type,
+	       g.home_team_id, g.away_team_id, g.game_date, g.home_score, g.away_score,
+	       g.status, g.quarter, COALESCE(g.time_remaining, ''), g.overridden, g.last_updated, g.created_at,
+	       ht.team_name, ht.team_abbreviation, ht.city, ht.conference, ht.division,
+	       ht.logo_url, ht.primary_color, ht.secondary_color, ht.created_at,
+	       at.team_name, at.team_abbreviation, at.city, at.conference, at.division,
+	       at.logo_url, at.primary_color, at.secondary_color, at.created_at
+	FROM nfl_games g
+	JOIN nfl_teams ht ON g.home_team_id = ht.team_id
+	JOIN nfl_teams at ON g.away_team_id = at.team_id`
+
+func scanGameWithTeams(row rowScanner, game *models.GameWithTeams) error {
+	err := row.Scan(
+		&game.GameID, &game.ExternalID, &game.SeasonYear, &game.Week, &game.GameType,
+		&game.HomeTeamID, &game.AwayTeamID, &game.GameDate, &game.HomeScore, &game.AwayScore,
+		&game.Status, &game.Quarter, &game.TimeRemaining, &game.Overridden, &game.LastUpdated, &game.CreatedAt,
+		&game.HomeTeam.TeamName, &game.HomeTeam.TeamAbbreviation, &game.HomeTeam.City,
+		&game.HomeTeam.Conference, &game.HomeTeam.Division, &game.HomeTeam.LogoURL,
+		&game.HomeTeam.PrimaryColor, &game.HomeTeam.SecondaryColor, &game.HomeTeam.CreatedAt,
+		&game.AwayTeam.TeamName, &game.AwayTeam.TeamAbbreviation, &game.AwayTeam.City,
+		&game.AwayTeam.Conference, &game.AwayTeam.Division, &game.AwayTeam.LogoURL,
+		&game.AwayTeam.PrimaryColor, &game.AwayTeam.SecondaryColor, &game.AwayTeam.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	game.HomeTeam.TeamID = game.HomeTeamID
+	game.AwayTeam.TeamID = game.AwayTeamID
+	return nil
+}
+
+func (s *gameStore) List(ctx context.Context, filter GameFilter) ([]models.GameWithTeams, error) {
+	query := gameWithTeamsSelect + " WHERE 1=1"
+	var args []interface{}
+
+	if filter.SeasonYear != nil {
+		query += " AND g.season_year = ?"
+		args = append(args, *filter.SeasonYear)
+	}
+
+	if filter.Week != nil {
+		query += " AND g.week = ?"
+		args = append(args, *filter.Week)
+	}
+
+	if filter.GameType != "" {
+		query += " AND g.game_type = ?"
+		args = append(args, filter.GameType)
+	}
+
+	if filter.Final {
+		query += " AND g.status IN (?, ?)"
+		args = append(args, models.GameStatusCompleted, models.GameStatusCorrected)
+	}
+
+	if filter.Status != "" {
+		query += " AND g.status = ?"
+		args = append(args, filter.Status)
+	}
+
+	query += " ORDER BY g.game_date, g.game_id"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var games []models.GameWithTeams
+	for rows.Next() {
+		var game models.GameWithTeams
+		if err := scanGameWithTeams(rows, &game); err != nil {
+			return nil, err
+		}
+		games = append(games, game)
+	}
+
+	return games, rows.Err()
+}
+
+func (s *gameStore) Get(ctx context.Context, gameID int) (*models.GameWithTeams, error) {
+	var game models.GameWithTeams
+	row := s.db.QueryRowContext(ctx, gameWithTeamsSelect+" WHERE g.game_id = ?", gameID)
+	if err := scanGameWithTeams(row, &game); err != nil {
+		return nil, notFound(err)
+	}
+	return &game, nil
+}
+
+func (s *gameStore) FirstKickoff(ctx context.Context, seasonYear, week int) (time.Time, error) {
+	var kickoff time.Time
+	err := s.db.QueryRowContext(ctx, `
+		SELECT game_date FROM nfl_games
+		WHERE season_year = ? AND week = ?
+		ORDER BY game_date
+		LIMIT 1`,
+		seasonYear, week,
+	).Scan(&kickoff)
+	if err != nil {
+		return time.Time{}, notFound(err)
+	}
+	return kickoff, nil
+}
+
+func (s *gameStore) Upsert(ctx context.Context, games []models.NFLGame) (*UpsertResult, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	result := &UpsertResult{}
+	for _, game := range games {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE nfl_games SET external_id = ?
+			WHERE external_id IS NULL AND season_year = ? AND week = ?
+			AND home_team_id = ? AND away_team_id = ?`,
+			game.ExternalID, game.SeasonYear, game.Week, game.HomeTeamID, game.AwayTeamID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		var current models.NFLGame
+		err = tx.QueryRowContext(ctx, `
+			SELECT game_id, status, home_score, away_score, overridden FROM nfl_games WHERE external_id = ?`,
+			game.ExternalID,
+		).Scan(&current.GameID, &current.Status, &current.HomeScore, &current.AwayScore, &current.Overridden)
+		if errors.Is(err, sql.ErrNoRows) {
+			if !models.ValidGameStatus(game.Status) {
+				result.Rejected = append(result.Rejected, models.GameTransition{
+					SeasonYear: game.SeasonYear,
+					Week:       game.Week,
+					To:         game.Status,
+				})
+				continue
+			}
+
+			id, err := tx.InsertReturningID(ctx, `
+				INSERT INTO nfl_games (external_id, season_year, week, game_type, home_team_id, away_team_id,
+				                       game_date, home_score, away_score, status, quarter, time_remaining)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`, "game_id",
+				game.ExternalID, game.SeasonYear, game.Week, game.GameType, game.HomeTeamID, game.AwayTeamID,
+				game.GameDate, game.HomeScore, game.AwayScore, game.Status, game.Quarter, game.TimeRemaining,
+			)
+			if err != nil {
+				return nil, err
+			}
+			result.Changed++
+			result.Updated = append(result.Updated, int(id))
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// A feed never overrides a commissioner's correction
+		if current.Overridden || current.Status == models.GameStatusCorrected {
+			continue
+		}
+		game.Status = feedStatus(current, game)
+
+		transition := models.GameTransition{
+			GameID:     current.GameID,
+			SeasonYear: game.SeasonYear,
+			Week:       game.Week,
+			From:       current.Status,
+			To:         game.Status,
+		}
+		path, ok := models.GameStatusPath(current.Status, game.Status)
+		if !ok {
+			result.Rejected = append(result.Rejected, transition)
+			continue
+		}
+
+		// Only touch rows that differ so last_updated marks real changes
+		updated, err := tx.ExecContext(ctx, `
+			UPDATE nfl_games
+			SET season_year = ?, week = ?, game_type = ?, home_team_id = ?, away_team_id = ?, game_date = ?,
+			    home_score = ?, away_score = ?, status = ?, quarter = ?, time_remaining = ?,
+			    last_updated = CURRENT_TIMESTAMP
+			WHERE game_id = ? AND (season_year != ? OR week != ? OR game_type != ? OR home_team_id != ? OR away_team_id != ?
+			OR game_date != ? OR home_score != ? OR away_score != ? OR status != ? OR quarter != ?
+			OR COALESCE(time_remaining, '') != ?)`,
+			game.SeasonYear, game.Week, game.GameType, game.HomeTeamID, game.AwayTeamID, game.GameDate,
+			game.HomeScore, game.AwayScore, game.Status, game.Quarter, game.TimeRemaining,
+			current.GameID, game.SeasonYear, game.Week, game.GameType, game.HomeTeamID, game.AwayTeamID,
+			game.GameDate, game.HomeScore, game.AwayScore, game.Status, game.Quarter, game.TimeRemaining,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if n, err := updated.RowsAffected(); err == nil && n > 0 {
+			result.Changed++
+			result.Updated = append(result.Updated, current.GameID)
+		}
+
+		for _, status := range path {
+			transition.To = status
+			result.Transitions = append(result.Transitions, transition)
+			transition.From = status
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	for i := range result.Transitions {
+		result.Transitions[i].At = now
+	}
+	return result, nil
+}
+
+func (s *gameStore) Correct(ctx context.Context, correction *models.GameCorrection) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	updated, err := tx.ExecContext(ctx, `
+		UPDATE nfl_games
+		SET home_score = ?, away_score = ?, status = ?, overridden = ?, last_updated = CURRENT_TIMESTAMP
+		WHERE game_id = ? AND home_score = ? AND away_score = ? AND status = ?`,
+		correction.HomeScore, correction.AwayScore, correction.Status, true,
+		correction.GameID, correction.OriginalHomeScore, correction.OriginalAwayScore, correction.OriginalStatus,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := updated.RowsAffected(); err == nil && n == 0 {
+		return ErrConflict
+	}
+
+	id, err := tx.InsertReturningID(ctx, `
+		INSERT INTO game_corrections (game_id, corrected_by, reason, original_home_score, original_away_score,
+		                              original_status, home_score, away_score, status)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`, "correction_id",
+		correction.GameID, correction.CorrectedBy, correction.Reason, correction.OriginalHomeScore,
+		correction.OriginalAwayScore, correction.OriginalStatus, correction.HomeScore, correction.AwayScore,
+		correction.Status,
+	)
+	if err != nil {
+		return err
+	}
+	correction.CorrectionID = int(id)
+
+	return tx.Commit()
+}
+
+func (s *gameStore) Corrections(ctx context.Context, gameID int) ([]models.GameCorrection, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT gc.correction_id, gc.game_id, COALESCE(gc.corrected_by, 0), COALESCE(up.display_name, ''),
+		       gc.reason, gc.original_home_score, gc.original_away_score, gc.original_status,
+		       gc.home_score, gc.away_score, gc.status, gc.created_at
+		FROM game_corrections gc
+		LEFT JOIN user_profiles up ON gc.corrected_by = up.user_id
+		WHERE gc.game_id = ?
+		ORDER BY gc.created_at DESC, gc.correction_id DESC`,
+		gameID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	corrections := []models.GameCorrection{}
+	for rows.Next() {
+		var correction models.GameCorrection
+		err := rows.Scan(
+			&correction.CorrectionID, &correction.GameID, &correction.CorrectedBy, &correction.CorrectedByName,
+			&correction.Reason, &correction.OriginalHomeScore, &correction.OriginalAwayScore, &correction.OriginalStatus,
+			&correction.HomeScore, &correction.AwayScore, &correction.Status, &correction.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		corrections = append(corrections, correction)
+	}
+
+	return corrections, rows.Err()
+}
+
+// feedStatus reads a feed's status for a game already stored as current. A
+// postponed game the feed lists as scheduled again has been rescheduled, and
+// a final game whose score the feed changes has been corrected.
+func feedStatus(current, game models.NFLGame) string {
+	switch {
+	case game.Status == models.GameStatusScheduled &&
+		(current.Status == models.GameStatusPostponed || current.Status == models.GameStatusRescheduled):
+		return models.GameStatusRescheduled
+	case current.Status == models.GameStatusCompleted && game.Status == models.GameStatusCompleted &&
+		(current.HomeScore != game.HomeScore || current.AwayScore != game.AwayScore):
+		return models.GameStatusCorrected
+	default:
+		return game.Status
+	}
+}
@@ -0,0 +1,88 @@
+package memstore
+
+import (
+	"context"
+	"time"
+
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
+)
+
+// draftStore records which pools draft their teams. Running a draft is not
+// modelled.
+type draftStore struct {
+	db *DB
+}
+
+func (s *draftStore) Create(ctx context.Context, draft models.Draft) (int, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	for _, existing := range s.db.drafts {
+		if existing.PoolID == draft.PoolID {
+			return 0, store.ErrConflict
+		}
+	}
+
+	now := s.db.Now()
+	draft.DraftID = s.db.nextID()
+	draft.Status = models.DraftStatusScheduled
+	draft.ScheduledAt = draft.ScheduledAt.UTC()
+	draft.CreatedAt = now
+	draft.UpdatedAt = now
+	s.db.drafts[draft.DraftID] = &draft
+	return draft.DraftID, nil
+}
+
+func (s *draftStore) GetByPool(ctx context.Context, poolID int) (*models.Draft, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	for _, draft := range s.db.drafts {
+		if draft.PoolID == poolID {
+			copied := *draft
+			return &copied, nil
+		}
+	}
+	return nil, store.ErrNotFound
+}
+
+func (s *draftStore) Reschedule(ctx context.Context, draftID int, scheduledAt time.Time, pickSeconds int) error {
+	return ErrUnsupported
+}
+
+func (s *draftStore) ListDueToStart(ctx context.Context, now time.Time) ([]models.Draft, error) {
+	return nil, ErrUnsupported
+}
+
+func (s *draftStore) ListExpired(ctx context.Context, now time.Time) ([]models.Draft, error) {
+	return nil, ErrUnsupported
+}
+
+func (s *draftStore) Start(ctx context.Context, draftID int, order []int, rounds int, startedAt, deadline time.Time) error {
+	return ErrUnsupported
+}
+
+func (s *draftStore) Order(ctx context.Context, draftID int) ([]models.DraftSlot, error) {
+	return nil, ErrUnsupported
+}
+
+func (s *draftStore) Picks(ctx context.Context, draftID int) ([]models.DraftPick, error) {
+	return nil, ErrUnsupported
+}
+
+func (s *draftStore) RecordPick(ctx context.Context, draftID int, pick models.DraftPick, deadline *time.Time) error {
+	return ErrUnsupported
+}
+
+func (s *draftStore) Queue(ctx context.Context, poolID, userID int) ([]models.DraftQueueEntry, error) {
+	return nil, ErrUnsupported
+}
+
+func (s *draftStore) SetQueue(ctx context.Context, poolID, userID int, teamIDs []int) error {
+	return ErrUnsupported
+}
+
+func (s *draftStore) SetAbsent(ctx context.Context, poolID, userID, flaggedBy int, absent bool) error {
+	return ErrUnsupported
+}
@@ -0,0 +1,138 @@
+package memstore
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
+)
+
+// gameStore serves games loaded with DB.AddGame and their corrections.
+// Score feed writes are not modelled.
+type gameStore struct {
+	db *DB
+}
+
+// withTeams joins a game to both teams. The caller holds db.mu.
+func (s *gameStore) withTeams(game *models.NFLGame) models.GameWithTeams {
+	joined := models.GameWithTeams{NFLGame: *game}
+	if team, ok := s.db.teams[game.HomeTeamID]; ok {
+		joined.HomeTeam = *team
+	}
+	if team, ok := s.db.teams[game.AwayTeamID]; ok {
+		joined.AwayTeam = *team
+	}
+	return joined
+}
+
+func (s *gameStore) List(ctx context.Context, filter store.GameFilter) ([]models.GameWithTeams, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	var games []models.GameWithTeams
+	for _, game := range s.db.games {
+		if filter.SeasonYear != nil && game.SeasonYear != *filter.SeasonYear {
+			continue
+		}
+		if filter.Week != nil && game.Week != *filter.Week {
+			continue
+		}
+		if filter.Status != "" && game.Status != filter.Status {
+			continue
+		}
+		if filter.GameType != "" && game.GameType != filter.GameType {
+			continue
+		}
+		if filter.Final && !game.IsFinal() {
+			continue
+		}
+		games = append(games, s.withTeams(game))
+	}
+	sort.Slice(games, func(i, j int) bool {
+		a, b := games[i], games[j]
+		if !a.GameDate.Equal(b.GameDate) {
+			return a.GameDate.Before(b.GameDate)
+		}
+		return a.GameID < b.GameID
+	})
+	return games, nil
+}
+
+func (s *gameStore) Get(ctx context.Context, gameID int) (*models.GameWithTeams, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	game, ok := s.db.games[gameID]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	joined := s.withTeams(game)
+	return &joined, nil
+}
+
+func (s *gameStore) FirstKickoff(ctx context.Context, seasonYear, week int) (time.Time, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	var first time.Time
+	for _, game := range s.db.games {
+		if game.SeasonYear != seasonYear || game.Week != week {
+			continue
+		}
+		if first.IsZero() || game.GameDate.Before(first) {
+			first = game.GameDate
+		}
+	}
+	if first.IsZero() {
+		return time.Time{}, store.ErrNotFound
+	}
+	return first, nil
+}
+
+func (s *gameStore) Upsert(ctx context.Context, games []models.NFLGame) (*store.UpsertResult, error) {
+	return nil, ErrUnsupported
+}
+
+func (s *gameStore) Correct(ctx context.Context, correction *models.GameCorrection) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	game, ok := s.db.games[correction.GameID]
+	if !ok || game.HomeScore != correction.OriginalHomeScore ||
+		game.AwayScore != correction.OriginalAwayScore || game.Status != correction.OriginalStatus {
+		return store.ErrConflict
+	}
+
+	now := s.db.Now()
+	game.HomeScore = correction.HomeScore
+	game.AwayScore = correction.AwayScore
+	game.Status = correction.Status
+	game.Overridden = true
+	game.LastUpdated = now
+
+	correction.CorrectionID = s.db.nextID()
+	logged := *correction
+	logged.CreatedAt = now
+	s.db.corrections = append(s.db.corrections, logged)
+	return nil
+}
+
+func (s *gameStore) Corrections(ctx context.Context, gameID int) ([]models.GameCorrection, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	corrections := []models.GameCorrection{}
+	for i := len(s.db.corrections) - 1; i >= 0; i-- {
+		correction := s.db.corrections[i]
+		if correction.GameID != gameID {
+			continue
+		}
+		if profile, ok := s.db.profiles[correction.CorrectedBy]; ok {
+			correction.CorrectedByName = profile.DisplayName
+		}
+		corrections = append(corrections, correction)
+	}
+	return corrections, nil
+}
@@ -0,0 +1,101 @@
+// Package memstore provides in-memory implementations of the store
+// repositories for unit tests. They keep the same error and ordering
+// contracts as the SQL repositories but none of the data survives the DB.
+package memstore
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
+)
+
+// ErrUnsupported is returned by repository methods the fakes do not model
+var ErrUnsupported = errors.New("memstore: not supported")
+
+// DB holds the tables shared by the in-memory repositories, so that joins
+// such as a pick's team or a pool's creator name resolve across them
+type DB struct {
+	mu sync.Mutex
+
+	// Now stamps created_at and joined_at values; tests may replace it
+	Now func() time.Time
+
+	accounts    map[string]*models.EmailAccount
+	profiles    map[int]*models.UserProfile
+	teams       map[int]*models.NFLTeam
+	games       map[int]*models.NFLGame
+	corrections []models.GameCorrection
+	pools       map[int]*models.Pool
+	memberships []membership
+	picks       map[int]*models.SeasonPick
+	overrides   []models.PickOverride
+	drafts      map[int]*models.Draft
+
+	lastID int
+}
+
+type membership struct {
+	poolID   int
+	userID   int
+	role     string
+	joinedAt time.Time
+}
+
+// New creates an empty in-memory database
+func New() *DB {
+	return &DB{
+		Now:      func() time.Time { return time.Now().UTC() },
+		accounts: make(map[string]*models.EmailAccount),
+		profiles: make(map[int]*models.UserProfile),
+		teams:    make(map[int]*models.NFLTeam),
+		games:    make(map[int]*models.NFLGame),
+		pools:    make(map[int]*models.Pool),
+		picks:    make(map[int]*models.SeasonPick),
+		drafts:   make(map[int]*models.Draft),
+	}
+}
+
+// Store returns repositories backed by db. Users, Teams, Games, Pools, Picks
+// and Drafts are in-memory; the chat, weekly, survivor and tiebreaker
+// repositories are left nil.
+func (db *DB) Store() *store.Store {
+	return &store.Store{
+		Users:  &userStore{db: db},
+		Teams:  &teamStore{db: db},
+		Games:  &gameStore{db: db},
+		Pools:  &poolStore{db: db},
+		Picks:  &pickStore{db: db},
+		Drafts: &draftStore{db: db},
+	}
+}
+
+// AddTeam loads an NFL team, keeping its TeamID
+func (db *DB) AddTeam(team models.NFLTeam) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.teams[team.TeamID] = &team
+}
+
+// AddGame loads an NFL game, assigning a GameID when it has none, and
+// returns the game's ID
+func (db *DB) AddGame(game models.NFLGame) int {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if game.GameID == 0 {
+		game.GameID = db.nextID()
+	}
+	db.games[game.GameID] = &game
+	return game.GameID
+}
+
+// nextID hands out row IDs. IDs are unique across tables, which the SQL
+// stores do not promise but no caller may rely on either way.
+func (db *DB) nextID() int {
+	db.lastID++
+	return db.lastID
+}
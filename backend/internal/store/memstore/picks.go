@@ -0,0 +1,287 @@
+package memstore
+
+import (
+	"context"
+	"sort"
+
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
+)
+
+type pickStore struct {
+	db *DB
+}
+
+// withTeam joins a pick to its team, reporting false when the team is not
+// loaded, as the SQL join would drop the row. The caller holds db.mu.
+func (s *pickStore) withTeam(pick *models.SeasonPick) (models.PickWithTeam, bool) {
+	team, ok := s.db.teams[pick.TeamID]
+	if !ok {
+		return models.PickWithTeam{}, false
+	}
+	return models.PickWithTeam{SeasonPick: *pick, Team: *team}, true
+}
+
+// list returns the joined picks that match keep. The caller holds db.mu.
+func (s *pickStore) list(keep func(*models.SeasonPick) bool) []models.PickWithTeam {
+	var picks []models.PickWithTeam
+	for _, pick := range s.db.picks {
+		if !keep(pick) {
+			continue
+		}
+		if joined, ok := s.withTeam(pick); ok {
+			picks = append(picks, joined)
+		}
+	}
+	return picks
+}
+
+// conflicts reports whether a pick other than pickID already holds the team
+// or the user's slot in the pool. The caller holds db.mu.
+func (s *pickStore) conflicts(pickID, poolID, userID, teamID, pickOrder int) bool {
+	for _, other := range s.db.picks {
+		if other.PickID == pickID || other.PoolID != poolID {
+			continue
+		}
+		if other.TeamID == teamID || (other.UserID == userID && other.PickOrder == pickOrder) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *pickStore) ListByPool(ctx context.Context, poolID int) ([]models.PickWithTeam, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	picks := s.list(func(p *models.SeasonPick) bool {
+		_, ok := s.db.profiles[p.UserID]
+		return p.PoolID == poolID && ok
+	})
+	sort.Slice(picks, func(i, j int) bool {
+		a, b := picks[i], picks[j]
+		aName, bName := s.db.profiles[a.UserID].DisplayName, s.db.profiles[b.UserID].DisplayName
+		if aName != bName {
+			return aName < bName
+		}
+		if a.UserID != b.UserID {
+			return a.UserID < b.UserID
+		}
+		return a.PickOrder < b.PickOrder
+	})
+	return picks, nil
+}
+
+func (s *pickStore) ListByUser(ctx context.Context, userID int, poolID *int) ([]models.PickWithTeam, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	picks := s.list(func(p *models.SeasonPick) bool {
+		return p.UserID == userID && (poolID == nil || p.PoolID == *poolID)
+	})
+	sort.Slice(picks, func(i, j int) bool {
+		a, b := picks[i], picks[j]
+		if a.PoolID != b.PoolID {
+			return a.PoolID < b.PoolID
+		}
+		return a.PickOrder < b.PickOrder
+	})
+	return picks, nil
+}
+
+func (s *pickStore) ListShared(ctx context.Context, viewerID int, filter store.PickFilter) ([]models.PickWithTeam, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	shared := make(map[int]bool)
+	for _, m := range s.db.memberships {
+		if m.userID == viewerID {
+			shared[m.poolID] = true
+		}
+	}
+	teams := make(map[int]bool, len(filter.TeamIDs))
+	for _, teamID := range filter.TeamIDs {
+		teams[teamID] = true
+	}
+
+	picks := s.list(func(p *models.SeasonPick) bool {
+		return shared[p.PoolID] &&
+			(filter.UserID == nil || p.UserID == *filter.UserID) &&
+			(len(teams) == 0 || teams[p.TeamID])
+	})
+	sort.Slice(picks, func(i, j int) bool {
+		a, b := picks[i], picks[j]
+		if a.PoolID != b.PoolID {
+			return a.PoolID < b.PoolID
+		}
+		if a.UserID != b.UserID {
+			return a.UserID < b.UserID
+		}
+		return a.PickOrder < b.PickOrder
+	})
+	return picks, nil
+}
+
+func (s *pickStore) Get(ctx context.Context, pickID int) (*models.PickWithTeam, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	pick, ok := s.db.picks[pickID]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	joined, ok := s.withTeam(pick)
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	return &joined, nil
+}
+
+func (s *pickStore) TeamTaken(ctx context.Context, poolID, teamID, excludePickID int) (bool, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	for _, pick := range s.db.picks {
+		if pick.PoolID == poolID && pick.TeamID == teamID && pick.PickID != excludePickID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *pickStore) OrderTaken(ctx context.Context, poolID, userID, pickOrder int) (bool, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	for _, pick := range s.db.picks {
+		if pick.PoolID == poolID && pick.UserID == userID && pick.PickOrder == pickOrder {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *pickStore) Create(ctx context.Context, poolID, userID, teamID, pickOrder int, override *models.PickOverride) (int, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if s.conflicts(0, poolID, userID, teamID, pickOrder) {
+		return 0, store.ErrConflict
+	}
+
+	now := s.db.Now()
+	pick := &models.SeasonPick{
+		PickID:    s.db.nextID(),
+		PoolID:    poolID,
+		UserID:    userID,
+		TeamID:    teamID,
+		PickOrder: pickOrder,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	s.db.picks[pick.PickID] = pick
+	s.logOverride(pick.PickID, override)
+	return pick.PickID, nil
+}
+
+func (s *pickStore) Update(ctx context.Context, pickID, teamID, pickOrder int, override *models.PickOverride) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	pick, ok := s.db.picks[pickID]
+	if !ok {
+		return store.ErrNotFound
+	}
+	if s.conflicts(pickID, pick.PoolID, pick.UserID, teamID, pickOrder) {
+		return store.ErrConflict
+	}
+
+	pick.TeamID = teamID
+	pick.PickOrder = pickOrder
+	pick.UpdatedAt = s.db.Now()
+	s.logOverride(pickID, override)
+	return nil
+}
+
+func (s *pickStore) Delete(ctx context.Context, pickID int, override *models.PickOverride) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if _, ok := s.db.picks[pickID]; !ok {
+		return store.ErrNotFound
+	}
+	delete(s.db.picks, pickID)
+	s.logOverride(pickID, override)
+	return nil
+}
+
+// logOverride records a commissioner change to pickID, if there is one.
+// The caller holds db.mu.
+func (s *pickStore) logOverride(pickID int, override *models.PickOverride) {
+	if override == nil {
+		return
+	}
+
+	override.PickID = &pickID
+	logged := *override
+	logged.OverrideID = s.db.nextID()
+	logged.CreatedAt = s.db.Now()
+	s.db.overrides = append(s.db.overrides, logged)
+}
+
+func (s *pickStore) ListOverrides(ctx context.Context, poolID int) ([]models.PickOverride, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	var overrides []models.PickOverride
+	for i := len(s.db.overrides) - 1; i >= 0; i-- {
+		override := s.db.overrides[i]
+		if override.PoolID != poolID {
+			continue
+		}
+		if profile, ok := s.db.profiles[override.CommissionerID]; ok {
+			override.CommissionerName = profile.DisplayName
+		}
+		overrides = append(overrides, override)
+	}
+	return overrides, nil
+}
+
+func (s *pickStore) SetPoints(ctx context.Context, points map[int]int) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	now := s.db.Now()
+	for pickID, value := range points {
+		if pick, ok := s.db.picks[pickID]; ok && pick.PointsScored != value {
+			pick.PointsScored = value
+			pick.UpdatedAt = now
+		}
+	}
+	return nil
+}
+
+func (s *pickStore) PoolsOwning(ctx context.Context, teamIDs []int) ([]int, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	wanted := make(map[int]bool, len(teamIDs))
+	for _, teamID := range teamIDs {
+		wanted[teamID] = true
+	}
+
+	owning := make(map[int]bool)
+	for _, pick := range s.db.picks {
+		pool, ok := s.db.pools[pick.PoolID]
+		if ok && wanted[pick.TeamID] && pool.IsActive == models.PoolStatusActive {
+			owning[pick.PoolID] = true
+		}
+	}
+
+	var poolIDs []int
+	for poolID := range owning {
+		poolIDs = append(poolIDs, poolID)
+	}
+	sort.Ints(poolIDs)
+	return poolIDs, nil
+}
@@ -0,0 +1,288 @@
+package memstore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
+)
+
+type poolStore struct {
+	db *DB
+}
+
+// view copies a pool with its creator's name and member count, as the SQL
+// store's poolColumns read it. The caller holds db.mu.
+func (s *poolStore) view(pool *models.Pool) models.Pool {
+	copied := *pool
+	copied.CreatorName = ""
+	if profile, ok := s.db.profiles[pool.CreatedBy]; ok {
+		copied.CreatorName = profile.DisplayName
+	}
+	copied.CurrentMembers = s.count(pool.ID, "")
+	return copied
+}
+
+// count returns the number of members of the pool holding role, or of any
+// role when role is empty. The caller holds db.mu.
+func (s *poolStore) count(poolID int, role string) int {
+	n := 0
+	for _, m := range s.db.memberships {
+		if m.poolID == poolID && (role == "" || m.role == role) {
+			n++
+		}
+	}
+	return n
+}
+
+// member returns the index of the user's membership, or -1. The caller
+// holds db.mu.
+func (s *poolStore) member(poolID, userID int) int {
+	for i, m := range s.db.memberships {
+		if m.poolID == poolID && m.userID == userID {
+			return i
+		}
+	}
+	return -1
+}
+
+// newestFirst orders pools by created_at DESC, pool_id DESC
+func newestFirst(pools []models.Pool) {
+	sort.Slice(pools, func(i, j int) bool {
+		a, b := pools[i], pools[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.ID > b.ID
+	})
+}
+
+func (s *poolStore) Create(ctx context.Context, pool store.NewPool) (int, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	now := s.db.Now()
+	created := &models.Pool{
+		ID:          s.db.nextID(),
+		Name:        pool.Name,
+		Description: pool.Description,
+		MaxPlayers:  pool.MaxMembers,
+		Season:      pool.SeasonYear,
+		PoolType:    pool.PoolType,
+		EntryFee:    pool.EntryFee,
+		IsActive:    models.PoolStatusActive,
+		CreatedBy:   pool.CommissionerID,
+		Settings:    pool.Settings,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	s.db.pools[created.ID] = created
+	s.db.memberships = append(s.db.memberships, membership{
+		poolID:   created.ID,
+		userID:   pool.CommissionerID,
+		role:     models.RoleCommissioner,
+		joinedAt: now,
+	})
+	return created.ID, nil
+}
+
+func (s *poolStore) Get(ctx context.Context, poolID int) (*models.Pool, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	pool, ok := s.db.pools[poolID]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	view := s.view(pool)
+	return &view, nil
+}
+
+func (s *poolStore) ListForMember(ctx context.Context, userID int) ([]models.Pool, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	var pools []models.Pool
+	for _, m := range s.db.memberships {
+		if m.userID != userID {
+			continue
+		}
+		view := s.view(s.db.pools[m.poolID])
+		view.UserRole = m.role
+		pools = append(pools, view)
+	}
+	newestFirst(pools)
+	return pools, nil
+}
+
+func (s *poolStore) ListAvailable(ctx context.Context, userID int) ([]models.Pool, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	var pools []models.Pool
+	for _, pool := range s.db.pools {
+		if pool.IsActive != models.PoolStatusActive || s.member(pool.ID, userID) >= 0 {
+			continue
+		}
+		view := s.view(pool)
+		if view.CurrentMembers >= view.MaxPlayers {
+			continue
+		}
+		pools = append(pools, view)
+	}
+	newestFirst(pools)
+	return pools, nil
+}
+
+func (s *poolStore) ListActive(ctx context.Context, poolType string) ([]models.Pool, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	var pools []models.Pool
+	for _, pool := range s.db.pools {
+		if pool.IsActive != models.PoolStatusActive || (poolType != "" && pool.PoolType != poolType) {
+			continue
+		}
+		pools = append(pools, s.view(pool))
+	}
+	sort.Slice(pools, func(i, j int) bool { return pools[i].ID < pools[j].ID })
+	return pools, nil
+}
+
+func (s *poolStore) SetStatus(ctx context.Context, poolID int, status string) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	pool, ok := s.db.pools[poolID]
+	if !ok {
+		return store.ErrNotFound
+	}
+	pool.IsActive = status
+	pool.UpdatedAt = s.db.Now()
+	return nil
+}
+
+func (s *poolStore) ListMembers(ctx context.Context, poolID int) ([]models.PoolMember, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	// memberships are kept in insertion order, which matches joined_at,
+	// membership_id
+	var members []models.PoolMember
+	for _, m := range s.db.memberships {
+		if m.poolID != poolID {
+			continue
+		}
+		profile, ok := s.db.profiles[m.userID]
+		if !ok {
+			continue
+		}
+		members = append(members, models.PoolMember{
+			UserID:      m.userID,
+			Role:        m.role,
+			JoinedAt:    m.joinedAt,
+			DisplayName: profile.DisplayName,
+			Username:    profile.Username,
+		})
+	}
+	return members, nil
+}
+
+func (s *poolStore) MemberRole(ctx context.Context, poolID, userID int) (string, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	i := s.member(poolID, userID)
+	if i < 0 {
+		return "", store.ErrNotFound
+	}
+	return s.db.memberships[i].role, nil
+}
+
+func (s *poolStore) IsMember(ctx context.Context, poolID, userID int) (bool, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	return s.member(poolID, userID) >= 0, nil
+}
+
+func (s *poolStore) CountMembers(ctx context.Context, poolID int) (int, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	return s.count(poolID, ""), nil
+}
+
+func (s *poolStore) CountRole(ctx context.Context, poolID int, role string) (int, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	return s.count(poolID, role), nil
+}
+
+func (s *poolStore) AddMember(ctx context.Context, poolID, userID int, role string) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if !validRole(role) {
+		return fmt.Errorf("unknown role %q", role)
+	}
+	if s.member(poolID, userID) >= 0 {
+		return store.ErrConflict
+	}
+	s.db.memberships = append(s.db.memberships, membership{
+		poolID:   poolID,
+		userID:   userID,
+		role:     role,
+		joinedAt: s.db.Now(),
+	})
+	return nil
+}
+
+func (s *poolStore) RemoveMember(ctx context.Context, poolID, userID int) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	i := s.member(poolID, userID)
+	if i < 0 {
+		return store.ErrNotFound
+	}
+	s.db.memberships = append(s.db.memberships[:i], s.db.memberships[i+1:]...)
+	return nil
+}
+
+func (s *poolStore) SetMemberRole(ctx context.Context, poolID, userID int, role string) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	i := s.member(poolID, userID)
+	if i < 0 {
+		return store.ErrNotFound
+	}
+	s.db.memberships[i].role = role
+	return nil
+}
+
+func (s *poolStore) UpdateSettings(ctx context.Context, poolID int, settings models.PoolSettings) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	pool, ok := s.db.pools[poolID]
+	if !ok {
+		return store.ErrNotFound
+	}
+	pool.Settings = settings
+	pool.UpdatedAt = s.db.Now()
+	return nil
+}
+
+// validRole reports whether role is one of the seeded roles rows
+func validRole(role string) bool {
+	switch role {
+	case models.RoleCommissioner, models.RoleModerator, models.RoleMember:
+		return true
+	}
+	return false
+}
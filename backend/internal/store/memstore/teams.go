@@ -0,0 +1,52 @@
+package memstore
+
+import (
+	"context"
+	"sort"
+
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
+)
+
+type teamStore struct {
+	db *DB
+}
+
+func (s *teamStore) List(ctx context.Context, filter store.TeamFilter) ([]models.NFLTeam, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	var teams []models.NFLTeam
+	for _, team := range s.db.teams {
+		if filter.Conference != "" && team.Conference != filter.Conference {
+			continue
+		}
+		if filter.Division != "" && team.Division != filter.Division {
+			continue
+		}
+		teams = append(teams, *team)
+	}
+	sort.Slice(teams, func(i, j int) bool {
+		a, b := teams[i], teams[j]
+		if a.Conference != b.Conference {
+			return a.Conference < b.Conference
+		}
+		if a.Division != b.Division {
+			return a.Division < b.Division
+		}
+		return a.TeamName < b.TeamName
+	})
+	return teams, nil
+}
+
+func (s *teamStore) Get(ctx context.Context, teamID int) (*models.NFLTeam, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	team, ok := s.db.teams[teamID]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	copied := *team
+	return &copied, nil
+}
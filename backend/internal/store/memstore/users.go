@@ -0,0 +1,95 @@
+package memstore
+
+import (
+	"context"
+	"sort"
+
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
+)
+
+type userStore struct {
+	db *DB
+}
+
+func (s *userStore) Register(ctx context.Context, email, passwordHash, username, displayName string) (*models.UserProfile, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if _, ok := s.db.accounts[email]; ok {
+		return nil, store.ErrEmailExists
+	}
+
+	now := s.db.Now()
+	account := &models.EmailAccount{
+		EmailID:      s.db.nextID(),
+		EmailAddress: email,
+		PasswordHash: passwordHash,
+		CreatedAt:    now,
+	}
+	profile := &models.UserProfile{
+		UserID:      s.db.nextID(),
+		EmailID:     account.EmailID,
+		Username:    username,
+		DisplayName: displayName,
+		CreatedAt:   now,
+	}
+	s.db.accounts[email] = account
+	s.db.profiles[profile.UserID] = profile
+
+	copied := *profile
+	return &copied, nil
+}
+
+func (s *userStore) GetAccountByEmail(ctx context.Context, email string) (*models.EmailAccount, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	account, ok := s.db.accounts[email]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	copied := *account
+	return &copied, nil
+}
+
+func (s *userStore) GetAccount(ctx context.Context, emailID int) (*models.EmailAccount, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	for _, account := range s.db.accounts {
+		if account.EmailID == emailID {
+			copied := *account
+			return &copied, nil
+		}
+	}
+	return nil, store.ErrNotFound
+}
+
+func (s *userStore) ListProfiles(ctx context.Context, emailID int) ([]models.UserProfile, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	var profiles []models.UserProfile
+	for _, profile := range s.db.profiles {
+		if profile.EmailID == emailID {
+			profiles = append(profiles, *profile)
+		}
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].UserID < profiles[j].UserID
+	})
+	return profiles, nil
+}
+
+func (s *userStore) GetProfile(ctx context.Context, userID int) (*models.UserProfile, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	profile, ok := s.db.profiles[userID]
+	if !ok {
+		return nil, store.ErrNotFound
+	}
+	copied := *profile
+	return &copied, nil
+}
@@ -0,0 +1,352 @@
+package store
+
+import (
+	"context"
+	"strings"
+
+	"touchdown-tally/internal/database"
+	"touchdown-tally/internal/models"
+)
+
+// PickFilter narrows ListShared to one member's picks, or to picks of the
+// given teams
+type PickFilter struct {
+	UserID  *int
+	TeamIDs []int
+}
+
+// PickStore manages season picks
+type PickStore interface {
+	ListByPool(ctx context.Context, poolID int) ([]models.PickWithTeam, error)
+	// ListByUser returns the user's picks, limited to one pool when poolID is non-nil
+	ListByUser(ctx context.Context, userID int, poolID *int) ([]models.PickWithTeam, error)
+	// ListShared returns the picks matching filter in every pool viewerID
+	// belongs to, ordered by pool, member and pick order
+	ListShared(ctx context.Context, viewerID int, filter PickFilter) ([]models.PickWithTeam, error)
+	Get(ctx context.Context, pickID int) (*models.PickWithTeam, error)
+	// TeamTaken reports whether another pick in the pool already holds the team
+	TeamTaken(ctx context.Context, poolID, teamID, excludePickID int) (bool, error)
+	// OrderTaken reports whether the user already has a pick in the given slot
+	OrderTaken(ctx context.Context, poolID, userID, pickOrder int) (bool, error)
+
+	// Create returns ErrConflict if the team or slot is already taken.
+	// Create, Update and Delete write a non-nil override to the audit log in
+	// the same transaction as the change, filling in its pick ID.
+	Create(ctx context.Context, poolID, userID, teamID, pickOrder int, override *models.PickOverride) (int, error)
+	Update(ctx context.Context, pickID, teamID, pickOrder int, override *models.PickOverride) error
+	Delete(ctx context.Context, pickID int, override *models.PickOverride) error
+	// ListOverrides returns the pool's override audit log, newest first
+	ListOverrides(ctx context.Context, poolID int) ([]models.PickOverride, error)
+
+	// SetPoints writes points_scored for each pick ID in one transaction
+	SetPoints(ctx context.Context, points map[int]int) error
+
+	// PoolsOwning returns the active pools in which a member holds any of
+	// the teams as a season pick
+	PoolsOwning(ctx context.Context, teamIDs []int) ([]int, error)
+}
+
+type pickStore struct {
+	db *database.DB
+}
+
+// pickWithTeamSelect joins a pick to its team. Rows must be read with
+// scanPickWithTeam.
+const pickWithTeamSelect = `
+	SELECT p.pick_id, p.pool_id, p.user_id, p.team_id, p.pick_order,
+	       p.points_scored, p.is_eliminated, p.elimination_week, p.created_at, p.updated_at,
+	       t.team_name, t.team_abbreviation, t.city, t.conference, t.division,
+	       t.logo_url, t.primary_color, t.secondary_color, t.created_at
+	FROM season_picks p
+	JOIN nfl_teams t ON p.team_id = t.team_id`
+
+func scanPickWithTeam(row rowScanner, pick *models.PickWithTeam) error {
+	err := row.Scan(
+		&pick.PickID, &pick.PoolID, &pick.UserID, &pick.TeamID, &pick.PickOrder,
+		&pick.PointsScored, &pick.IsEliminated, &pick.EliminationWeek, &pick.CreatedAt, &pick.UpdatedAt,
+		&pick.Team.TeamName, &pick.Team.TeamAbbreviation, &pick.Team.City,
+		&pick.Team.Conference, &pick.Team.Division, &pick.Team.LogoURL,
+		&pick.Team.PrimaryColor, &pick.Team.SecondaryColor, &pick.Team.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	pick.Team.TeamID = pick.TeamID
+	return nil
+}
+
+func (s *pickStore) list(ctx context.Context, query string, args ...interface{}) ([]models.PickWithTeam, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var picks []models.PickWithTeam
+	for rows.Next() {
+		var pick models.PickWithTeam
+		if err := scanPickWithTeam(rows, &pick); err != nil {
+			return nil, err
+		}
+		picks = append(picks, pick)
+	}
+
+	return picks, rows.Err()
+}
+
+func (s *pickStore) ListByPool(ctx context.Context, poolID int) ([]models.PickWithTeam, error) {
+	return s.list(ctx, pickWithTeamSelect+`
+		JOIN user_profiles u ON p.user_id = u.user_id
+		WHERE p.pool_id = ?
+		ORDER BY u.display_name, p.user_id, p.pick_order`,
+		poolID,
+	)
+}
+
+func (s *pickStore) ListByUser(ctx context.Context, userID int, poolID *int) ([]models.PickWithTeam, error) {
+	query := pickWithTeamSelect + " WHERE p.user_id = ?"
+	args := []interface{}{userID}
+
+	if poolID != nil {
+		query += " AND p.pool_id = ?"
+		args = append(args, *poolID)
+	}
+
+	query += " ORDER BY p.pool_id, p.pick_order"
+	return s.list(ctx, query, args...)
+}
+
+func (s *pickStore) ListShared(ctx context.Context, viewerID int, filter PickFilter) ([]models.PickWithTeam, error) {
+	query := pickWithTeamSelect + `
+		WHERE p.pool_id IN (SELECT pool_id FROM pool_memberships WHERE user_id = ?)`
+	args := []interface{}{viewerID}
+
+	if filter.UserID != nil {
+		query += " AND p.user_id = ?"
+		args = append(args, *filter.UserID)
+	}
+	if len(filter.TeamIDs) > 0 {
+		query += " AND p.team_id IN (?" + strings.Repeat(", ?", len(filter.TeamIDs)-1) + ")"
+		for _, teamID := range filter.TeamIDs {
+			args = append(args, teamID)
+		}
+	}
+
+	query += " ORDER BY p.pool_id, p.user_id, p.pick_order"
+	return s.list(ctx, query, args...)
+}
+
+func (s *pickStore) Get(ctx context.Context, pickID int) (*models.PickWithTeam, error) {
+	var pick models.PickWithTeam
+	row := s.db.QueryRowContext(ctx, pickWithTeamSelect+" WHERE p.pick_id = ?", pickID)
+	if err := scanPickWithTeam(row, &pick); err != nil {
+		return nil, notFound(err)
+	}
+	return &pick, nil
+}
+
+func (s *pickStore) TeamTaken(ctx context.Context, poolID, teamID, excludePickID int) (bool, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM season_picks WHERE pool_id = ? AND team_id = ? AND pick_id != ?",
+		poolID, teamID, excludePickID,
+	).Scan(&count)
+	return count > 0, err
+}
+
+func (s *pickStore) OrderTaken(ctx context.Context, poolID, userID, pickOrder int) (bool, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM season_picks WHERE pool_id = ? AND user_id = ? AND pick_order = ?",
+		poolID, userID, pickOrder,
+	).Scan(&count)
+	return count > 0, err
+}
+
+func (s *pickStore) Create(ctx context.Context, poolID, userID, teamID, pickOrder int, override *models.PickOverride) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	pickID, err := tx.InsertReturningID(ctx, `
+		INSERT INTO season_picks (pool_id, user_id, team_id, pick_order)
+		VALUES (?, ?, ?, ?)`,
+		"pick_id", poolID, userID, teamID, pickOrder,
+	)
+	if err != nil {
+		if tx.IsUniqueViolation(err) {
+			return 0, ErrConflict
+		}
+		return 0, err
+	}
+
+	if err := logOverride(ctx, tx, int(pickID), override); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return int(pickID), nil
+}
+
+func (s *pickStore) Update(ctx context.Context, pickID, teamID, pickOrder int, override *models.PickOverride) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE season_picks
+		SET team_id = ?, pick_order = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE pick_id = ?`,
+		teamID, pickOrder, pickID,
+	)
+	if err != nil {
+		if tx.IsUniqueViolation(err) {
+			return ErrConflict
+		}
+		return err
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+
+	if err := logOverride(ctx, tx, pickID, override); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *pickStore) Delete(ctx context.Context, pickID int, override *models.PickOverride) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM season_picks WHERE pick_id = ?", pickID)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+
+	if err := logOverride(ctx, tx, pickID, override); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// logOverride records a commissioner change to pickID, if there is one
+func logOverride(ctx context.Context, tx *database.Tx, pickID int, override *models.PickOverride) error {
+	if override == nil {
+		return nil
+	}
+
+	override.PickID = &pickID
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO pick_overrides (pool_id, pick_id, user_id, commissioner_id, action, team_id, pick_order, note)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		override.PoolID, pickID, override.UserID, override.CommissionerID,
+		override.Action, override.TeamID, override.PickOrder, override.Note,
+	)
+	return err
+}
+
+func (s *pickStore) ListOverrides(ctx context.Context, poolID int) ([]models.PickOverride, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT o.override_id, o.pool_id, o.pick_id, COALESCE(o.user_id, 0), COALESCE(o.commissioner_id, 0),
+		       o.action, o.team_id, o.pick_order, o.note, o.created_at, COALESCE(up.display_name, '')
+		FROM pick_overrides o
+		LEFT JOIN user_profiles up ON o.commissioner_id = up.user_id
+		WHERE o.pool_id = ?
+		ORDER BY o.created_at DESC, o.override_id DESC`,
+		poolID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var overrides []models.PickOverride
+	for rows.Next() {
+		var override models.PickOverride
+		err := rows.Scan(
+			&override.OverrideID, &override.PoolID, &override.PickID, &override.UserID, &override.CommissionerID,
+			&override.Action, &override.TeamID, &override.PickOrder, &override.Note, &override.CreatedAt,
+			&override.CommissionerName,
+		)
+		if err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, override)
+	}
+
+	return overrides, rows.Err()
+}
+
+func (s *pickStore) SetPoints(ctx context.Context, points map[int]int) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for pickID, value := range points {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE season_picks
+			SET points_scored = ?, updated_at = CURRENT_TIMESTAMP
+			WHERE pick_id = ? AND points_scored != ?`,
+			value, pickID, value,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *pickStore) PoolsOwning(ctx context.Context, teamIDs []int) ([]int, error) {
+	if len(teamIDs) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, 0, len(teamIDs)+1)
+	args = append(args, models.PoolStatusActive)
+	for _, teamID := range teamIDs {
+		args = append(args, teamID)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT DISTINCT p.pool_id
+		FROM season_picks sp
+		JOIN pools p ON sp.pool_id = p.pool_id
+		WHERE p.status = ? AND sp.team_id IN (?`+strings.Repeat(", ?", len(teamIDs)-1)+`)
+		ORDER BY p.pool_id`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var poolIDs []int
+	for rows.Next() {
+		var poolID int
+		if err := rows.Scan(&poolID); err != nil {
+			return nil, err
+		}
+		poolIDs = append(poolIDs, poolID)
+	}
+
+	return poolIDs, rows.Err()
+}
@@ -0,0 +1,383 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"touchdown-tally/internal/database"
+	"touchdown-tally/internal/models"
+)
+
+// NewPool holds the values needed to create a pool. PrizeStructure is
+// stored as serialized JSON.
+type NewPool struct {
+	Name           string
+	Description    string
+	CommissionerID int
+	SeasonYear     int
+	MaxMembers     int
+	EntryFee       float64
+	PoolType       string
+	PrizeStructure string
+	Settings       models.PoolSettings
+}
+
+// PoolStore manages pools and their memberships
+type PoolStore interface {
+	// Create inserts the pool and makes its creator the commissioner
+	Create(ctx context.Context, pool NewPool) (int, error)
+	Get(ctx context.Context, poolID int) (*models.Pool, error)
+	ListForMember(ctx context.Context, userID int) ([]models.Pool, error)
+	// ListAvailable returns active pools with open seats the user has not joined
+	ListAvailable(ctx context.Context, userID int) ([]models.Pool, error)
+	// ListActive returns every active pool of the given type, or of any type
+	// when poolType is empty
+	ListActive(ctx context.Context, poolType string) ([]models.Pool, error)
+	SetStatus(ctx context.Context, poolID int, status string) error
+
+	ListMembers(ctx context.Context, poolID int) ([]models.PoolMember, error)
+	// MemberRole returns the user's role name, or ErrNotFound if they are not a member
+	MemberRole(ctx context.Context, poolID, userID int) (string, error)
+	IsMember(ctx context.Context, poolID, userID int) (bool, error)
+	CountMembers(ctx context.Context, poolID int) (int, error)
+	CountRole(ctx context.Context, poolID int, role string) (int, error)
+	// AddMember returns ErrConflict if the user already belongs to the pool
+	AddMember(ctx context.Context, poolID, userID int, role string) error
+	RemoveMember(ctx context.Context, poolID, userID int) error
+	// SetMemberRole changes a member's role, returning ErrNotFound if they
+	// are not a member
+	SetMemberRole(ctx context.Context, poolID, userID int, role string) error
+
+	UpdateSettings(ctx context.Context, poolID int, settings models.PoolSettings) error
+}
+
+type poolStore struct {
+	db *database.DB
+}
+
+// poolColumns and poolFrom read a pool with its creator's name and member
+// count. Rows must be read with scanPool.
+const poolColumns = `
+	p.pool_id, p.pool_name, COALESCE(p.description, ''), p.max_members, p.season_year,
+	p.pool_type, p.entry_fee, p.status, COALESCE(p.commissioner_id, 0), p.settings,
+	p.created_at, p.updated_at,
+	COALESCE(up.display_name, ''),
+	(SELECT COUNT(*) FROM pool_memberships WHERE pool_id = p.pool_id)`
+
+const poolFrom = `
+	FROM pools p
+	LEFT JOIN user_profiles up ON p.commissioner_id = up.user_id`
+
+func scanPool(row rowScanner, pool *models.Pool, extra ...interface{}) error {
+	var settings []byte
+	dest := []interface{}{
+		&pool.ID, &pool.Name, &pool.Description, &pool.MaxPlayers, &pool.Season,
+		&pool.PoolType, &pool.EntryFee, &pool.IsActive, &pool.CreatedBy, &settings,
+		&pool.CreatedAt, &pool.UpdatedAt,
+		&pool.CreatorName, &pool.CurrentMembers,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+
+	decoded, err := decodeSettings(settings)
+	if err != nil {
+		return err
+	}
+	pool.Settings = decoded
+	return nil
+}
+
+// decodeSettings reads a pools.settings value on top of the defaults, so
+// pools created before a setting existed pick up its default
+func decodeSettings(raw []byte) (models.PoolSettings, error) {
+	settings := models.DefaultPoolSettings()
+	if len(raw) == 0 {
+		return settings, nil
+	}
+	if err := json.Unmarshal(raw, &settings); err != nil {
+		return settings, fmt.Errorf("decode pool settings: %w", err)
+	}
+	return settings, nil
+}
+
+func (s *poolStore) Create(ctx context.Context, pool NewPool) (int, error) {
+	settings, err := json.Marshal(pool.Settings)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	poolID, err := tx.InsertReturningID(ctx, `
+		INSERT INTO pools (pool_name, description, commissioner_id, season_year, max_members,
+		                   entry_fee, prize_structure, pool_type, settings)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		"pool_id", pool.Name, pool.Description, pool.CommissionerID, pool.SeasonYear,
+		pool.MaxMembers, pool.EntryFee, pool.PrizeStructure, pool.PoolType, string(settings),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := addMember(ctx, tx, int(poolID), pool.CommissionerID, models.RoleCommissioner); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return int(poolID), nil
+}
+
+func (s *poolStore) Get(ctx context.Context, poolID int) (*models.Pool, error) {
+	var pool models.Pool
+	row := s.db.QueryRowContext(ctx, "SELECT "+poolColumns+poolFrom+" WHERE p.pool_id = ?", poolID)
+	if err := scanPool(row, &pool); err != nil {
+		return nil, notFound(err)
+	}
+	return &pool, nil
+}
+
+func (s *poolStore) ListForMember(ctx context.Context, userID int) ([]models.Pool, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+poolColumns+", r.role_name"+poolFrom+`
+		JOIN pool_memberships pm ON p.pool_id = pm.pool_id
+		JOIN roles r ON pm.role_id = r.role_id
+		WHERE pm.user_id = ?
+		ORDER BY p.created_at DESC, p.pool_id DESC`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pools []models.Pool
+	for rows.Next() {
+		var pool models.Pool
+		if err := scanPool(rows, &pool, &pool.UserRole); err != nil {
+			return nil, err
+		}
+		pools = append(pools, pool)
+	}
+
+	return pools, rows.Err()
+}
+
+func (s *poolStore) ListAvailable(ctx context.Context, userID int) ([]models.Pool, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT "+poolColumns+poolFrom+`
+		WHERE p.status = 'active'
+		AND p.pool_id NOT IN (SELECT pool_id FROM pool_memberships WHERE user_id = ?)
+		AND (SELECT COUNT(*) FROM pool_memberships WHERE pool_id = p.pool_id) < p.max_members
+		ORDER BY p.created_at DESC, p.pool_id DESC`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pools []models.Pool
+	for rows.Next() {
+		var pool models.Pool
+		if err := scanPool(rows, &pool); err != nil {
+			return nil, err
+		}
+		pools = append(pools, pool)
+	}
+
+	return pools, rows.Err()
+}
+
+func (s *poolStore) ListActive(ctx context.Context, poolType string) ([]models.Pool, error) {
+	query := "SELECT " + poolColumns + poolFrom + " WHERE p.status = ?"
+	args := []interface{}{models.PoolStatusActive}
+	if poolType != "" {
+		query += " AND p.pool_type = ?"
+		args = append(args, poolType)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query+" ORDER BY p.pool_id", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pools []models.Pool
+	for rows.Next() {
+		var pool models.Pool
+		if err := scanPool(rows, &pool); err != nil {
+			return nil, err
+		}
+		pools = append(pools, pool)
+	}
+
+	return pools, rows.Err()
+}
+
+func (s *poolStore) SetStatus(ctx context.Context, poolID int, status string) error {
+	result, err := s.db.ExecContext(ctx,
+		"UPDATE pools SET status = ?, updated_at = CURRENT_TIMESTAMP WHERE pool_id = ?",
+		status, poolID,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *poolStore) ListMembers(ctx context.Context, poolID int) ([]models.PoolMember, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT pm.user_id, r.role_name, pm.joined_at, up.display_name, up.username
+		FROM pool_memberships pm
+		JOIN user_profiles up ON pm.user_id = up.user_id
+		JOIN roles r ON pm.role_id = r.role_id
+		WHERE pm.pool_id = ?
+		ORDER BY pm.joined_at ASC, pm.membership_id ASC`,
+		poolID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []models.PoolMember
+	for rows.Next() {
+		var member models.PoolMember
+		if err := rows.Scan(&member.UserID, &member.Role, &member.JoinedAt, &member.DisplayName, &member.Username); err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+
+	return members, rows.Err()
+}
+
+func (s *poolStore) MemberRole(ctx context.Context, poolID, userID int) (string, error) {
+	var role string
+	err := s.db.QueryRowContext(ctx, `
+		SELECT r.role_name FROM pool_memberships pm
+		JOIN roles r ON pm.role_id = r.role_id
+		WHERE pm.pool_id = ? AND pm.user_id = ?`,
+		poolID, userID,
+	).Scan(&role)
+	if err != nil {
+		return "", notFound(err)
+	}
+	return role, nil
+}
+
+func (s *poolStore) IsMember(ctx context.Context, poolID, userID int) (bool, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM pool_memberships WHERE pool_id = ? AND user_id = ?",
+		poolID, userID,
+	).Scan(&count)
+	return count > 0, err
+}
+
+func (s *poolStore) CountMembers(ctx context.Context, poolID int) (int, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM pool_memberships WHERE pool_id = ?",
+		poolID,
+	).Scan(&count)
+	return count, err
+}
+
+func (s *poolStore) CountRole(ctx context.Context, poolID int, role string) (int, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM pool_memberships pm
+		JOIN roles r ON pm.role_id = r.role_id
+		WHERE pm.pool_id = ? AND r.role_name = ?`,
+		poolID, role,
+	).Scan(&count)
+	return count, err
+}
+
+func (s *poolStore) AddMember(ctx context.Context, poolID, userID int, role string) error {
+	err := addMember(ctx, s.db, poolID, userID, role)
+	if err != nil && s.db.IsUniqueViolation(err) {
+		return ErrConflict
+	}
+	return err
+}
+
+func (s *poolStore) RemoveMember(ctx context.Context, poolID, userID int) error {
+	result, err := s.db.ExecContext(ctx,
+		"DELETE FROM pool_memberships WHERE pool_id = ? AND user_id = ?",
+		poolID, userID,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *poolStore) SetMemberRole(ctx context.Context, poolID, userID int, role string) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE pool_memberships
+		SET role_id = (SELECT role_id FROM roles WHERE role_name = ?)
+		WHERE pool_id = ? AND user_id = ?`,
+		role, poolID, userID,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *poolStore) UpdateSettings(ctx context.Context, poolID int, settings models.PoolSettings) error {
+	raw, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+
+	result, err := s.db.ExecContext(ctx,
+		"UPDATE pools SET settings = ?, updated_at = CURRENT_TIMESTAMP WHERE pool_id = ?",
+		string(raw), poolID,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// execer is satisfied by *database.DB and *database.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+func addMember(ctx context.Context, db execer, poolID, userID int, role string) error {
+	result, err := db.ExecContext(ctx, `
+		INSERT INTO pool_memberships (pool_id, user_id, role_id, joined_at)
+		SELECT ?, ?, role_id, CURRENT_TIMESTAMP FROM roles WHERE role_name = ?`,
+		poolID, userID, role,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("unknown role %q", role)
+	}
+	return nil
+}
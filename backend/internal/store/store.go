@@ -0,0 +1,63 @@
+// Package store contains the typed repositories that own all SQL access.
+// Handlers depend on the interfaces defined here rather than on *sql.DB.
+package store
+
+import (
+	"database/sql"
+	"errors"
+
+	"touchdown-tally/internal/database"
+)
+
+var (
+	// ErrNotFound is returned when a requested row does not exist
+	ErrNotFound = errors.New("not found")
+
+	// ErrConflict is returned when a write violates a unique constraint
+	ErrConflict = errors.New("conflict")
+)
+
+// Store bundles the repositories backed by a single database
+type Store struct {
+	Users  UserStore
+	Teams  TeamStore
+	Games  GameStore
+	Pools  PoolStore
+	Picks  PickStore
+	Chat   ChatStore
+	Drafts DraftStore
+
+	WeeklyPicks WeeklyPickStore
+	Survivor    SurvivorStore
+	Tiebreakers TiebreakerStore
+}
+
+// New creates SQL-backed repositories for db
+func New(db *database.DB) *Store {
+	return &Store{
+		Users:  &userStore{db: db},
+		Teams:  &teamStore{db: db},
+		Games:  &gameStore{db: db},
+		Pools:  &poolStore{db: db},
+		Picks:  &pickStore{db: db},
+		Chat:   &chatStore{db: db},
+		Drafts: &draftStore{db: db},
+
+		WeeklyPicks: &weeklyPickStore{db: db},
+		Survivor:    &survivorStore{db: db},
+		Tiebreakers: &tiebreakerStore{db: db},
+	}
+}
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// notFound maps sql.ErrNoRows to ErrNotFound
+func notFound(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	return err
+}
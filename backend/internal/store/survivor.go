@@ -0,0 +1,157 @@
+package store
+
+import (
+	"context"
+
+	"touchdown-tally/internal/database"
+	"touchdown-tally/internal/models"
+)
+
+// SurvivorStore manages survivor pool picks and eliminations
+type SurvivorStore interface {
+	// Picks returns every pick in the pool ordered by week then member
+	Picks(ctx context.Context, poolID int) ([]models.SurvivorPick, error)
+	// SavePick creates or replaces the member's pick for the week. It
+	// returns ErrConflict if the member already used the team in another week.
+	SavePick(ctx context.Context, pick models.SurvivorPick) error
+	// SetResult grades a pick and, when eliminated is non-nil, knocks its
+	// member out in the same transaction
+	SetResult(ctx context.Context, pickID int, result string, eliminated *models.SurvivorElimination) error
+
+	Eliminations(ctx context.Context, poolID int) ([]models.SurvivorElimination, error)
+	// Eliminate knocks a member out and marks their season picks in the
+	// pool eliminated. A member who is already out keeps their original
+	// elimination.
+	Eliminate(ctx context.Context, elimination models.SurvivorElimination) error
+}
+
+type survivorStore struct {
+	db *database.DB
+}
+
+func (s *survivorStore) Picks(ctx context.Context, poolID int) ([]models.SurvivorPick, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT sp.survivor_pick_id, sp.pool_id, sp.user_id, up.display_name, sp.week,
+		       sp.team_id, t.team_abbreviation, t.team_name, sp.game_id, sp.result, sp.is_auto,
+		       sp.created_at, sp.updated_at
+		FROM survivor_picks sp
+		JOIN user_profiles up ON sp.user_id = up.user_id
+		JOIN nfl_teams t ON sp.team_id = t.team_id
+		WHERE sp.pool_id = ?
+		ORDER BY sp.week, up.display_name, sp.user_id`,
+		poolID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var picks []models.SurvivorPick
+	for rows.Next() {
+		var pick models.SurvivorPick
+		err := rows.Scan(
+			&pick.SurvivorPickID, &pick.PoolID, &pick.UserID, &pick.DisplayName, &pick.Week,
+			&pick.TeamID, &pick.TeamAbbreviation, &pick.TeamName, &pick.GameID, &pick.Result, &pick.IsAuto,
+			&pick.CreatedAt, &pick.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		picks = append(picks, pick)
+	}
+
+	return picks, rows.Err()
+}
+
+func (s *survivorStore) SavePick(ctx context.Context, pick models.SurvivorPick) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO survivor_picks (pool_id, user_id, week, team_id, game_id, is_auto)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (pool_id, user_id, week) DO UPDATE
+		SET team_id = excluded.team_id, game_id = excluded.game_id, is_auto = excluded.is_auto,
+		    result = 'pending', updated_at = CURRENT_TIMESTAMP`,
+		pick.PoolID, pick.UserID, pick.Week, pick.TeamID, pick.GameID, pick.IsAuto,
+	)
+	if err != nil && s.db.IsUniqueViolation(err) {
+		return ErrConflict
+	}
+	return err
+}
+
+func (s *survivorStore) SetResult(ctx context.Context, pickID int, result string, eliminated *models.SurvivorElimination) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx,
+		"UPDATE survivor_picks SET result = ?, updated_at = CURRENT_TIMESTAMP WHERE survivor_pick_id = ?",
+		result, pickID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if eliminated != nil {
+		if err := eliminate(ctx, tx, *eliminated); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *survivorStore) Eliminations(ctx context.Context, poolID int) ([]models.SurvivorElimination, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT pool_id, user_id, week, reason, created_at
+		FROM survivor_eliminations
+		WHERE pool_id = ?
+		ORDER BY week, user_id`,
+		poolID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var eliminations []models.SurvivorElimination
+	for rows.Next() {
+		var e models.SurvivorElimination
+		if err := rows.Scan(&e.PoolID, &e.UserID, &e.Week, &e.Reason, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		eliminations = append(eliminations, e)
+	}
+
+	return eliminations, rows.Err()
+}
+
+func (s *survivorStore) Eliminate(ctx context.Context, elimination models.SurvivorElimination) error {
+	return eliminate(ctx, s.db, elimination)
+}
+
+// eliminate records the elimination and marks the member's season picks in
+// the pool as eliminated in the same week
+func eliminate(ctx context.Context, db execer, elimination models.SurvivorElimination) error {
+	result, err := db.ExecContext(ctx, `
+		INSERT INTO survivor_eliminations (pool_id, user_id, week, reason)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (pool_id, user_id) DO NOTHING`,
+		elimination.PoolID, elimination.UserID, elimination.Week, elimination.Reason,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil || n == 0 {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, `
+		UPDATE season_picks
+		SET is_eliminated = ?, elimination_week = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE pool_id = ? AND user_id = ?`,
+		true, elimination.Week, elimination.PoolID, elimination.UserID,
+	)
+	return err
+}
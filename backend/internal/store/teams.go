@@ -0,0 +1,79 @@
+package store
+
+import (
+	"context"
+
+	"touchdown-tally/internal/database"
+	"touchdown-tally/internal/models"
+)
+
+// TeamFilter narrows a team listing; empty fields are ignored
+type TeamFilter struct {
+	Conference string
+	Division   string
+}
+
+// TeamStore reads the NFL team reference data
+type TeamStore interface {
+	List(ctx context.Context, filter TeamFilter) ([]models.NFLTeam, error)
+	Get(ctx context.Context, teamID int) (*models.NFLTeam, error)
+}
+
+type teamStore struct {
+	db *database.DB
+}
+
+const teamColumns = `
+	team_id, team_name, team_abbreviation, city, conference, division,
+	logo_url, primary_color, secondary_color, created_at`
+
+func scanTeam(row rowScanner, team *models.NFLTeam) error {
+	return row.Scan(
+		&team.TeamID, &team.TeamName, &team.TeamAbbreviation, &team.City,
+		&team.Conference, &team.Division, &team.LogoURL, &team.PrimaryColor,
+		&team.SecondaryColor, &team.CreatedAt,
+	)
+}
+
+func (s *teamStore) List(ctx context.Context, filter TeamFilter) ([]models.NFLTeam, error) {
+	query := "SELECT " + teamColumns + " FROM nfl_teams WHERE 1=1"
+	var args []interface{}
+
+	if filter.Conference != "" {
+		query += " AND conference = ?"
+		args = append(args, filter.Conference)
+	}
+
+	if filter.Division != "" {
+		query += " AND division = ?"
+		args = append(args, filter.Division)
+	}
+
+	query += " ORDER BY conference, division, team_name"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var teams []models.NFLTeam
+	for rows.Next() {
+		var team models.NFLTeam
+		if err := scanTeam(rows, &team); err != nil {
+			return nil, err
+		}
+		teams = append(teams, team)
+	}
+
+	return teams, rows.Err()
+}
+
+func (s *teamStore) Get(ctx context.Context, teamID int) (*models.NFLTeam, error) {
+	var team models.NFLTeam
+	row := s.db.QueryRowContext(ctx, "SELECT "+teamColumns+" FROM nfl_teams WHERE team_id = ?", teamID)
+	if err := scanTeam(row, &team); err != nil {
+		return nil, notFound(err)
+	}
+	return &team, nil
+}
@@ -0,0 +1,62 @@
+package store
+
+import (
+	"context"
+
+	"touchdown-tally/internal/database"
+	"touchdown-tally/internal/models"
+)
+
+// TiebreakerStore manages members' tiebreaker predictions
+type TiebreakerStore interface {
+	// List returns the pool's predictions for a game ordered by member
+	List(ctx context.Context, poolID, gameID int) ([]models.Tiebreaker, error)
+	// Save creates or replaces the member's prediction for the game
+	Save(ctx context.Context, poolID, userID, gameID, totalPoints int) error
+}
+
+type tiebreakerStore struct {
+	db *database.DB
+}
+
+func (s *tiebreakerStore) List(ctx context.Context, poolID, gameID int) ([]models.Tiebreaker, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT tb.tiebreaker_id, tb.pool_id, tb.user_id, up.display_name, tb.game_id,
+		       tb.total_points, tb.created_at, tb.updated_at
+		FROM tiebreakers tb
+		JOIN user_profiles up ON tb.user_id = up.user_id
+		WHERE tb.pool_id = ? AND tb.game_id = ?
+		ORDER BY up.display_name, tb.user_id`,
+		poolID, gameID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tiebreakers []models.Tiebreaker
+	for rows.Next() {
+		var tb models.Tiebreaker
+		err := rows.Scan(
+			&tb.TiebreakerID, &tb.PoolID, &tb.UserID, &tb.DisplayName, &tb.GameID,
+			&tb.TotalPoints, &tb.CreatedAt, &tb.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		tiebreakers = append(tiebreakers, tb)
+	}
+
+	return tiebreakers, rows.Err()
+}
+
+func (s *tiebreakerStore) Save(ctx context.Context, poolID, userID, gameID, totalPoints int) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO tiebreakers (pool_id, user_id, game_id, total_points)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (pool_id, user_id, game_id) DO UPDATE
+		SET total_points = excluded.total_points, updated_at = CURRENT_TIMESTAMP`,
+		poolID, userID, gameID, totalPoints,
+	)
+	return err
+}
@@ -0,0 +1,130 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"touchdown-tally/internal/database"
+	"touchdown-tally/internal/models"
+)
+
+var (
+	// ErrEmailExists is returned when registering an email that is already in use
+	ErrEmailExists = errors.New("email already registered")
+
+	// ErrUsernameExists is returned when a profile name is reused for the same email
+	ErrUsernameExists = errors.New("username already exists for this email")
+)
+
+// UserStore manages email accounts and the profiles attached to them
+type UserStore interface {
+	// Register creates an email account and its first profile in one transaction
+	Register(ctx context.Context, email, passwordHash, username, displayName string) (*models.UserProfile, error)
+	GetAccountByEmail(ctx context.Context, email string) (*models.EmailAccount, error)
+	GetAccount(ctx context.Context, emailID int) (*models.EmailAccount, error)
+	ListProfiles(ctx context.Context, emailID int) ([]models.UserProfile, error)
+	GetProfile(ctx context.Context, userID int) (*models.UserProfile, error)
+}
+
+type userStore struct {
+	db *database.DB
+}
+
+func (s *userStore) Register(ctx context.Context, email, passwordHash, username, displayName string) (*models.UserProfile, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	emailID, err := tx.InsertReturningID(ctx,
+		"INSERT INTO email_accounts (email_address, password_hash) VALUES (?, ?)",
+		"email_id", email, passwordHash,
+	)
+	if err != nil {
+		if tx.IsUniqueViolation(err) {
+			return nil, ErrEmailExists
+		}
+		return nil, err
+	}
+
+	userID, err := tx.InsertReturningID(ctx,
+		"INSERT INTO user_profiles (email_id, username, display_name) VALUES (?, ?, ?)",
+		"user_id", emailID, username, displayName,
+	)
+	if err != nil {
+		if tx.IsUniqueViolation(err) {
+			return nil, ErrUsernameExists
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &models.UserProfile{
+		UserID:      int(userID),
+		EmailID:     int(emailID),
+		Username:    username,
+		DisplayName: displayName,
+	}, nil
+}
+
+func (s *userStore) GetAccountByEmail(ctx context.Context, email string) (*models.EmailAccount, error) {
+	var account models.EmailAccount
+	err := s.db.QueryRowContext(ctx,
+		"SELECT email_id, email_address, password_hash, created_at FROM email_accounts WHERE email_address = ?",
+		email,
+	).Scan(&account.EmailID, &account.EmailAddress, &account.PasswordHash, &account.CreatedAt)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &account, nil
+}
+
+func (s *userStore) GetAccount(ctx context.Context, emailID int) (*models.EmailAccount, error) {
+	var account models.EmailAccount
+	err := s.db.QueryRowContext(ctx,
+		"SELECT email_id, email_address, password_hash, created_at FROM email_accounts WHERE email_id = ?",
+		emailID,
+	).Scan(&account.EmailID, &account.EmailAddress, &account.PasswordHash, &account.CreatedAt)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &account, nil
+}
+
+func (s *userStore) ListProfiles(ctx context.Context, emailID int) ([]models.UserProfile, error) {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT user_id, email_id, username, display_name, created_at FROM user_profiles WHERE email_id = ? ORDER BY created_at",
+		emailID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var profiles []models.UserProfile
+	for rows.Next() {
+		var profile models.UserProfile
+		if err := rows.Scan(&profile.UserID, &profile.EmailID, &profile.Username, &profile.DisplayName, &profile.CreatedAt); err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, rows.Err()
+}
+
+func (s *userStore) GetProfile(ctx context.Context, userID int) (*models.UserProfile, error) {
+	var profile models.UserProfile
+	err := s.db.QueryRowContext(ctx,
+		"SELECT user_id, email_id, username, display_name, created_at FROM user_profiles WHERE user_id = ?",
+		userID,
+	).Scan(&profile.UserID, &profile.EmailID, &profile.Username, &profile.DisplayName, &profile.CreatedAt)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	return &profile, nil
+}
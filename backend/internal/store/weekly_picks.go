@@ -0,0 +1,137 @@
+package store
+
+import (
+	"context"
+
+	"touchdown-tally/internal/database"
+	"touchdown-tally/internal/models"
+)
+
+// WeeklyPickStore manages per-game picks for weekly pools
+type WeeklyPickStore interface {
+	// List returns the pool's picks for games in seasonYear, limited to one
+	// week when week is non-nil, ordered by kickoff then member
+	List(ctx context.Context, poolID, seasonYear int, week *int) ([]models.WeeklyPick, error)
+	// Save creates or replaces the member's pick for each game, clearing any
+	// grade it had. A zero confidence is stored as NULL.
+	Save(ctx context.Context, poolID, userID int, picks []models.WeeklyPickInput) error
+	// Grade marks picks on completed games correct or incorrect and clears
+	// grades on games that are no longer final. It returns how many picks
+	// changed.
+	Grade(ctx context.Context) (int64, error)
+}
+
+type weeklyPickStore struct {
+	db *database.DB
+}
+
+func (s *weeklyPickStore) List(ctx context.Context, poolID, seasonYear int, week *int) ([]models.WeeklyPick, error) {
+	query := `
+		SELECT wp.weekly_pick_id, wp.pool_id, wp.user_id, up.display_name, wp.game_id, g.week,
+		       wp.picked_team_id, wp.confidence, wp.is_correct, wp.created_at, wp.updated_at
+		FROM weekly_picks wp
+		JOIN nfl_games g ON wp.game_id = g.game_id
+		JOIN user_profiles up ON wp.user_id = up.user_id
+		WHERE wp.pool_id = ? AND g.season_year = ?`
+	args := []interface{}{poolID, seasonYear}
+
+	if week != nil {
+		query += " AND g.week = ?"
+		args = append(args, *week)
+	}
+
+	query += " ORDER BY g.game_date, g.game_id, up.display_name, wp.user_id"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var picks []models.WeeklyPick
+	for rows.Next() {
+		var pick models.WeeklyPick
+		err := rows.Scan(
+			&pick.WeeklyPickID, &pick.PoolID, &pick.UserID, &pick.DisplayName, &pick.GameID, &pick.Week,
+			&pick.TeamID, &pick.Confidence, &pick.IsCorrect, &pick.CreatedAt, &pick.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		picks = append(picks, pick)
+	}
+
+	return picks, rows.Err()
+}
+
+func (s *weeklyPickStore) Save(ctx context.Context, poolID, userID int, picks []models.WeeklyPickInput) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, pick := range picks {
+		var confidence *int
+		if pick.Confidence > 0 {
+			confidence = &pick.Confidence
+		}
+
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO weekly_picks (pool_id, user_id, game_id, picked_team_id, confidence)
+			VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT (pool_id, user_id, game_id) DO UPDATE
+			SET picked_team_id = excluded.picked_team_id, confidence = excluded.confidence,
+			    is_correct = NULL, updated_at = CURRENT_TIMESTAMP`,
+			poolID, userID, pick.GameID, pick.TeamID, confidence,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// pickCorrect is true when a weekly pick named the winner of its final
+// game. Ties grade every pick as incorrect.
+const pickCorrect = `(
+	SELECT g.home_score != g.away_score AND weekly_picks.picked_team_id =
+	       CASE WHEN g.home_score > g.away_score THEN g.home_team_id ELSE g.away_team_id END
+	FROM nfl_games g WHERE g.game_id = weekly_picks.game_id)`
+
+func (s *weeklyPickStore) Grade(ctx context.Context) (int64, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	graded, err := tx.ExecContext(ctx, `
+		UPDATE weekly_picks SET is_correct = `+pickCorrect+`, updated_at = CURRENT_TIMESTAMP
+		WHERE game_id IN (SELECT game_id FROM nfl_games WHERE status IN (?, ?))
+		AND (is_correct IS NULL OR is_correct != `+pickCorrect+`)`,
+		models.GameStatusCompleted, models.GameStatusCorrected,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	cleared, err := tx.ExecContext(ctx, `
+		UPDATE weekly_picks SET is_correct = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE is_correct IS NOT NULL
+		AND game_id IN (SELECT game_id FROM nfl_games WHERE status NOT IN (?, ?))`,
+		models.GameStatusCompleted, models.GameStatusCorrected,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	gradedCount, _ := graded.RowsAffected()
+	clearedCount, _ := cleared.RowsAffected()
+	return gradedCount + clearedCount, nil
+}
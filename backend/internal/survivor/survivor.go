@@ -0,0 +1,501 @@
+// Package survivor runs survivor pools, where each member backs one team a
+// week, can never back the same team twice, and is knocked out the first
+// time their team fails to win.
+package survivor
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
+	"touchdown-tally/pkg/logger"
+)
+
+// processInterval is how often picks are graded and missed weeks resolved
+const processInterval = time.Minute
+
+// minEntrants is how many members a pool needs before it can be won
+const minEntrants = 2
+
+// finalWeek is the last week of the regular season. Members still alive
+// once it is settled split the pool.
+const finalWeek = 18
+
+var (
+	ErrNotSurvivorPool = errors.New("pool is not a survivor pool")
+	ErrPoolFinished    = errors.New("survivor pool has already finished")
+	ErrWeekLocked      = errors.New("week has kicked off and picks are locked")
+	ErrEliminated      = errors.New("you have been eliminated from this pool")
+	ErrTeamNotPlaying  = errors.New("team is not playing this week")
+	ErrTeamUsed        = errors.New("team has already been used in an earlier week")
+)
+
+// Service records survivor picks and knocks members out as games finish
+type Service struct {
+	store  *store.Store
+	logger *logger.Logger
+	now    func() time.Time
+
+	// mu serializes picks with the processor so an auto-assigned pick
+	// never races a member's own
+	mu sync.Mutex
+}
+
+// NewService creates a survivor service
+func NewService(st *store.Store, logger *logger.Logger) *Service {
+	return &Service{
+		store:  st,
+		logger: logger,
+		now:    func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// schedule is a season's regular-season games grouped by week, in kickoff order
+type schedule struct {
+	games map[int]models.NFLGame
+	weeks map[int][]models.NFLGame
+}
+
+func (s *Service) schedule(ctx context.Context, season int) (*schedule, error) {
+	games, err := s.store.Games.List(ctx, store.GameFilter{SeasonYear: &season})
+	if err != nil {
+		return nil, err
+	}
+
+	sched := &schedule{
+		games: make(map[int]models.NFLGame, len(games)),
+		weeks: make(map[int][]models.NFLGame),
+	}
+	for _, game := range games {
+		sched.games[game.GameID] = game.NFLGame
+		if game.GameType == models.GameTypeRegular {
+			sched.weeks[game.Week] = append(sched.weeks[game.Week], game.NFLGame)
+		}
+	}
+	return sched, nil
+}
+
+// deadline returns the week's first kickoff, or false if it has no games
+func (sc *schedule) deadline(week int) (time.Time, bool) {
+	games := sc.weeks[week]
+	if len(games) == 0 {
+		return time.Time{}, false
+	}
+	return games[0].GameDate, true
+}
+
+// settled reports whether every game in the week is final
+func (sc *schedule) settled(week int) bool {
+	games := sc.weeks[week]
+	for _, game := range games {
+		if !game.IsFinal() {
+			return false
+		}
+	}
+	return len(games) > 0
+}
+
+func (sc *schedule) weekNumbers() []int {
+	weeks := make([]int, 0, len(sc.weeks))
+	for week := range sc.weeks {
+		weeks = append(weeks, week)
+	}
+	sort.Ints(weeks)
+	return weeks
+}
+
+// Board returns every member's run through the pool. Other members' picks
+// for a week stay hidden until that week has kicked off.
+func (s *Service) Board(ctx context.Context, pool *models.Pool, userID int) (*models.SurvivorBoard, error) {
+	if pool.PoolType != models.PoolTypeSurvivor {
+		return nil, ErrNotSurvivorPool
+	}
+
+	members, err := s.store.Pools.ListMembers(ctx, pool.ID)
+	if err != nil {
+		return nil, err
+	}
+	picks, err := s.store.Survivor.Picks(ctx, pool.ID)
+	if err != nil {
+		return nil, err
+	}
+	eliminations, err := s.store.Survivor.Eliminations(ctx, pool.ID)
+	if err != nil {
+		return nil, err
+	}
+	sched, err := s.schedule(ctx, pool.Season)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[int]models.SurvivorElimination, len(eliminations))
+	for _, e := range eliminations {
+		out[e.UserID] = e
+	}
+
+	board := &models.SurvivorBoard{
+		PoolID:   pool.ID,
+		Season:   pool.Season,
+		Finished: pool.IsActive == models.PoolStatusCompleted,
+		Winners:  []int{},
+		Entries:  make([]models.SurvivorEntry, 0, len(members)),
+	}
+	if board.Finished {
+		board.Winners = winners(members, out)
+	}
+
+	index := make(map[int]int, len(members))
+	for _, member := range members {
+		entry := models.SurvivorEntry{
+			UserID:      member.UserID,
+			DisplayName: member.DisplayName,
+			Alive:       true,
+			Picks:       []models.SurvivorPick{},
+		}
+		if e, ok := out[member.UserID]; ok {
+			week := e.Week
+			entry.Alive = false
+			entry.EliminationWeek = &week
+			entry.EliminationReason = e.Reason
+		}
+		for _, winner := range board.Winners {
+			entry.Winner = entry.Winner || winner == member.UserID
+		}
+		index[member.UserID] = len(board.Entries)
+		board.Entries = append(board.Entries, entry)
+	}
+
+	now := s.now()
+	for _, pick := range picks {
+		i, ok := index[pick.UserID]
+		if !ok {
+			continue
+		}
+		deadline, ok := sched.deadline(pick.Week)
+		locked := ok && !now.Before(deadline)
+		if pick.UserID == userID || locked {
+			board.Entries[i].Picks = append(board.Entries[i].Picks, pick)
+		}
+	}
+
+	return board, nil
+}
+
+// winners returns the members who won a finished pool: everyone still
+// alive, or if nobody is, everyone knocked out in the final week
+func winners(members []models.PoolMember, out map[int]models.SurvivorElimination) []int {
+	var alive []int
+	last := 0
+	for _, member := range members {
+		e, ok := out[member.UserID]
+		if !ok {
+			alive = append(alive, member.UserID)
+		} else if e.Week > last {
+			last = e.Week
+		}
+	}
+	if len(alive) > 0 {
+		return alive
+	}
+
+	split := []int{}
+	for _, member := range members {
+		if out[member.UserID].Week == last {
+			split = append(split, member.UserID)
+		}
+	}
+	return split
+}
+
+// Pick backs teamID for the member in week. The pick can be changed until the
+// week's first kickoff, but never to a team the member used in another week.
+func (s *Service) Pick(ctx context.Context, pool *models.Pool, userID, week, teamID int) (*models.SurvivorBoard, error) {
+	if pool.PoolType != models.PoolTypeSurvivor {
+		return nil, ErrNotSurvivorPool
+	}
+	if pool.IsActive == models.PoolStatusCompleted {
+		return nil, ErrPoolFinished
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sched, err := s.schedule(ctx, pool.Season)
+	if err != nil {
+		return nil, err
+	}
+	deadline, ok := sched.deadline(week)
+	if !ok {
+		return nil, ErrTeamNotPlaying
+	}
+	if !s.now().Before(deadline) {
+		return nil, ErrWeekLocked
+	}
+
+	var game *models.NFLGame
+	for i, g := range sched.weeks[week] {
+		if g.HomeTeamID == teamID || g.AwayTeamID == teamID {
+			game = &sched.weeks[week][i]
+			break
+		}
+	}
+	if game == nil {
+		return nil, ErrTeamNotPlaying
+	}
+
+	eliminations, err := s.store.Survivor.Eliminations(ctx, pool.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range eliminations {
+		if e.UserID == userID {
+			return nil, ErrEliminated
+		}
+	}
+
+	picks, err := s.store.Survivor.Picks(ctx, pool.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, pick := range picks {
+		if pick.UserID == userID && pick.TeamID == teamID && pick.Week != week {
+			return nil, ErrTeamUsed
+		}
+	}
+
+	err = s.store.Survivor.SavePick(ctx, models.SurvivorPick{
+		PoolID: pool.ID,
+		UserID: userID,
+		Week:   week,
+		TeamID: teamID,
+		GameID: game.GameID,
+	})
+	if errors.Is(err, store.ErrConflict) {
+		return nil, ErrTeamUsed
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Survivor pick saved", "pool_id", pool.ID, "user_id", userID, "week", week, "team_id", teamID)
+	return s.Board(ctx, pool, userID)
+}
+
+// Process grades and resolves every active survivor pool
+func (s *Service) Process(ctx context.Context) error {
+	pools, err := s.store.Pools.ListActive(ctx, models.PoolTypeSurvivor)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range pools {
+		if err := s.process(ctx, &pools[i]); err != nil {
+			s.logger.Error("Failed to process survivor pool", "pool_id", pools[i].ID, "error", err)
+		}
+	}
+	return nil
+}
+
+// process grades picks on final games, deals with members who let a week
+// kick off without a pick, then finishes the pool once it has a winner.
+// A member only answers for weeks that kick off after they joined.
+func (s *Service) process(ctx context.Context, pool *models.Pool) error {
+	sched, err := s.schedule(ctx, pool.Season)
+	if err != nil {
+		return err
+	}
+	members, err := s.store.Pools.ListMembers(ctx, pool.ID)
+	if err != nil {
+		return err
+	}
+	picks, err := s.store.Survivor.Picks(ctx, pool.ID)
+	if err != nil {
+		return err
+	}
+	eliminations, err := s.store.Survivor.Eliminations(ctx, pool.ID)
+	if err != nil {
+		return err
+	}
+
+	out := make(map[int]models.SurvivorElimination, len(eliminations))
+	for _, e := range eliminations {
+		out[e.UserID] = e
+	}
+	knockOut := func(userID, week int, reason string) *models.SurvivorElimination {
+		if _, ok := out[userID]; ok {
+			return nil
+		}
+		e := models.SurvivorElimination{PoolID: pool.ID, UserID: userID, Week: week, Reason: reason}
+		out[userID] = e
+		s.logger.Info("Survivor member eliminated", "pool_id", pool.ID, "user_id", userID, "week", week, "reason", reason)
+		return &e
+	}
+
+	picked := make(map[int]map[int]bool)
+	used := make(map[int]map[int]bool)
+	for _, pick := range picks {
+		if picked[pick.Week] == nil {
+			picked[pick.Week] = make(map[int]bool)
+		}
+		picked[pick.Week][pick.UserID] = true
+		if used[pick.UserID] == nil {
+			used[pick.UserID] = make(map[int]bool)
+		}
+		used[pick.UserID][pick.TeamID] = true
+
+		game, ok := sched.games[pick.GameID]
+		if pick.Result != models.SurvivorResultPending || !ok || !game.IsFinal() {
+			continue
+		}
+
+		result := models.SurvivorResultWin
+		var eliminated *models.SurvivorElimination
+		switch game.WinnerID() {
+		case pick.TeamID:
+		case 0:
+			result = models.SurvivorResultTie
+			if pool.Settings.Survivor.TieEliminates {
+				eliminated = knockOut(pick.UserID, pick.Week, models.EliminatedByTie)
+			}
+		default:
+			result = models.SurvivorResultLoss
+			eliminated = knockOut(pick.UserID, pick.Week, models.EliminatedByLoss)
+		}
+		if err := s.store.Survivor.SetResult(ctx, pick.SurvivorPickID, result, eliminated); err != nil {
+			return err
+		}
+	}
+
+	var opened time.Time
+	for i, member := range members {
+		if i == 0 || member.JoinedAt.Before(opened) {
+			opened = member.JoinedAt
+		}
+	}
+
+	now := s.now()
+	var weeks []int
+	for _, week := range sched.weekNumbers() {
+		deadline, _ := sched.deadline(week)
+		if deadline.Before(opened) {
+			continue
+		}
+		weeks = append(weeks, week)
+		if now.Before(deadline) {
+			continue
+		}
+
+		for _, member := range members {
+			if deadline.Before(member.JoinedAt) {
+				continue
+			}
+			if _, ok := out[member.UserID]; ok || picked[week][member.UserID] {
+				continue
+			}
+			if err := s.missedPick(ctx, pool, sched, week, member.UserID, used, knockOut); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(members) < minEntrants {
+		return nil
+	}
+	for _, week := range weeks {
+		if !sched.settled(week) {
+			return nil
+		}
+
+		alive := 0
+		for _, member := range members {
+			if e, ok := out[member.UserID]; !ok || e.Week > week {
+				alive++
+			}
+		}
+		if alive <= 1 || week >= finalWeek {
+			if err := s.store.Pools.SetStatus(ctx, pool.ID, models.PoolStatusCompleted); err != nil {
+				return err
+			}
+			s.logger.Info("Survivor pool finished", "pool_id", pool.ID, "week", week, "winners", winners(members, out))
+			return nil
+		}
+	}
+	return nil
+}
+
+// missedPick handles a member who let the week kick off without a pick:
+// either backing the first unused team whose game has not started, in
+// kickoff order with home teams first, or knocking them out when the pool
+// does not auto-assign or no such team is left
+func (s *Service) missedPick(ctx context.Context, pool *models.Pool, sched *schedule, week, userID int,
+	used map[int]map[int]bool, knockOut func(userID, week int, reason string) *models.SurvivorElimination) error {
+	if pool.Settings.Survivor.MissedPick == models.MissedPickAutoAssign {
+		now := s.now()
+		for _, game := range sched.weeks[week] {
+			if !upcoming(game, now) {
+				continue
+			}
+			for _, teamID := range []int{game.HomeTeamID, game.AwayTeamID} {
+				if used[userID][teamID] {
+					continue
+				}
+
+				err := s.store.Survivor.SavePick(ctx, models.SurvivorPick{
+					PoolID: pool.ID,
+					UserID: userID,
+					Week:   week,
+					TeamID: teamID,
+					GameID: game.GameID,
+					IsAuto: true,
+				})
+				if err != nil {
+					return err
+				}
+				if used[userID] == nil {
+					used[userID] = make(map[int]bool)
+				}
+				used[userID][teamID] = true
+				s.logger.Info("Survivor pick auto-assigned", "pool_id", pool.ID, "user_id", userID, "week", week, "team_id", teamID)
+				return nil
+			}
+		}
+	}
+
+	if e := knockOut(userID, week, models.EliminatedByMissedPick); e != nil {
+		return s.store.Survivor.Eliminate(ctx, *e)
+	}
+	return nil
+}
+
+// upcoming reports whether the game is still scheduled to kick off after now
+func upcoming(game models.NFLGame, now time.Time) bool {
+	switch game.Status {
+	case models.GameStatusScheduled, models.GameStatusRescheduled:
+		return now.Before(game.GameDate)
+	}
+	return false
+}
+
+// Run processes survivor pools until ctx is cancelled
+func (s *Service) Run(ctx context.Context) {
+	ticker := time.NewTicker(processInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Process(ctx); err != nil {
+				s.logger.Error("Failed to process survivor pools", "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,333 @@
+package survivor
+
+import (
+	"context"
+	"io"
+	"log"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"touchdown-tally/internal/database"
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store"
+	"touchdown-tally/pkg/logger"
+)
+
+// kickoff is when week 1 opens
+var kickoff = time.Date(2025, 9, 7, 17, 0, 0, 0, time.UTC)
+
+// league is a survivor pool over a migrated SQLite database. Week 1 has an
+// early game, BUF at home to MIA, and a late game, KC at home to DEN, three
+// hours later.
+type league struct {
+	db      *database.DB
+	store   *store.Store
+	service *Service
+	teams   map[string]int
+	early   int
+	late    int
+	pool    *models.Pool
+	// commissioner has backed BUF in week 1; member has not picked
+	commissioner int
+	member       int
+}
+
+func newLeague(t *testing.T, missedPick, lateStatus string) *league {
+	t.Helper()
+	ctx := context.Background()
+
+	db, err := database.Connect("sqlite://" + filepath.Join(t.TempDir(), "survivor.db"))
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := database.Migrate(db); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	l := &league{db: db, store: store.New(db), teams: make(map[string]int)}
+	quiet := &logger.Logger{Logger: log.New(io.Discard, "", 0)}
+	l.service = NewService(l.store, quiet)
+
+	teams, err := l.store.Teams.List(ctx, store.TeamFilter{})
+	if err != nil {
+		t.Fatalf("list teams: %v", err)
+	}
+	for _, team := range teams {
+		l.teams[team.TeamAbbreviation] = team.TeamID
+	}
+	games := []models.NFLGame{
+		{ExternalID: "early", HomeTeamID: l.teams["BUF"], AwayTeamID: l.teams["MIA"], GameDate: kickoff, Status: models.GameStatusInProgress},
+		{ExternalID: "late", HomeTeamID: l.teams["KC"], AwayTeamID: l.teams["DEN"], GameDate: kickoff.Add(3 * time.Hour), Status: lateStatus},
+	}
+	for i := range games {
+		games[i].SeasonYear = 2025
+		games[i].Week = 1
+		games[i].GameType = models.GameTypeRegular
+	}
+	result, err := l.store.Games.Upsert(ctx, games)
+	if err != nil || len(result.Updated) != 2 {
+		t.Fatalf("upsert games = %+v, %v", result, err)
+	}
+	l.early, l.late = result.Updated[0], result.Updated[1]
+
+	for _, name := range []string{"commish", "member"} {
+		profile, err := l.store.Users.Register(ctx, name+"@example.com", "hash", name, name)
+		if err != nil {
+			t.Fatalf("register %s: %v", name, err)
+		}
+		if name == "commish" {
+			l.commissioner = profile.UserID
+		} else {
+			l.member = profile.UserID
+		}
+	}
+
+	settings := models.DefaultPoolSettings()
+	settings.Survivor.MissedPick = missedPick
+	poolID, err := l.store.Pools.Create(ctx, store.NewPool{
+		Name:           "Last One Standing",
+		CommissionerID: l.commissioner,
+		SeasonYear:     2025,
+		MaxMembers:     10,
+		PoolType:       models.PoolTypeSurvivor,
+		Settings:       settings,
+	})
+	if err != nil {
+		t.Fatalf("create pool: %v", err)
+	}
+	if err := l.store.Pools.AddMember(ctx, poolID, l.member, models.RoleMember); err != nil {
+		t.Fatalf("add member: %v", err)
+	}
+	l.joined(t, l.commissioner, kickoff.Add(-7*24*time.Hour))
+	l.joined(t, l.member, kickoff.Add(-7*24*time.Hour))
+	if l.pool, err = l.store.Pools.Get(ctx, poolID); err != nil {
+		t.Fatalf("get pool: %v", err)
+	}
+
+	err = l.store.Survivor.SavePick(ctx, models.SurvivorPick{
+		PoolID: poolID,
+		UserID: l.commissioner,
+		Week:   1,
+		TeamID: l.teams["BUF"],
+		GameID: l.early,
+	})
+	if err != nil {
+		t.Fatalf("save pick: %v", err)
+	}
+	return l
+}
+
+// joined backdates when the user joined the pool
+func (l *league) joined(t *testing.T, userID int, at time.Time) {
+	t.Helper()
+
+	_, err := l.db.Exec("UPDATE pool_memberships SET joined_at = ? WHERE user_id = ?", at, userID)
+	if err != nil {
+		t.Fatalf("set joined_at: %v", err)
+	}
+}
+
+// process runs the processor as if it were now
+func (l *league) process(t *testing.T, now time.Time) {
+	t.Helper()
+
+	l.service.now = func() time.Time { return now }
+	if err := l.service.process(context.Background(), l.pool); err != nil {
+		t.Fatalf("process: %v", err)
+	}
+}
+
+// week1 returns the user's week 1 pick and elimination, if any
+func (l *league) week1(t *testing.T, userID int) (*models.SurvivorPick, *models.SurvivorElimination) {
+	t.Helper()
+	ctx := context.Background()
+
+	picks, err := l.store.Survivor.Picks(ctx, l.pool.ID)
+	if err != nil {
+		t.Fatalf("picks: %v", err)
+	}
+	eliminations, err := l.store.Survivor.Eliminations(ctx, l.pool.ID)
+	if err != nil {
+		t.Fatalf("eliminations: %v", err)
+	}
+
+	var pick *models.SurvivorPick
+	for i := range picks {
+		if picks[i].UserID == userID && picks[i].Week == 1 {
+			pick = &picks[i]
+		}
+	}
+	var out *models.SurvivorElimination
+	for i := range eliminations {
+		if eliminations[i].UserID == userID {
+			out = &eliminations[i]
+		}
+	}
+	return pick, out
+}
+
+func TestMissedPick(t *testing.T) {
+	tests := []struct {
+		name       string
+		missedPick string
+		lateStatus string
+		// joined and now are relative to week 1's first kickoff
+		joined time.Duration
+		now    time.Duration
+		// wantTeam is the team auto-assigned, if any
+		wantTeam string
+		wantOut  string
+	}{
+		{
+			name:       "eliminated when the pool does not auto-assign",
+			missedPick: models.MissedPickEliminate,
+			lateStatus: models.GameStatusScheduled,
+			joined:     -time.Hour,
+			now:        time.Hour,
+			wantOut:    models.EliminatedByMissedPick,
+		},
+		{
+			name:       "assigned a team from a game still to kick off",
+			missedPick: models.MissedPickAutoAssign,
+			lateStatus: models.GameStatusScheduled,
+			joined:     -time.Hour,
+			now:        time.Hour,
+			wantTeam:   "KC",
+		},
+		{
+			name:       "rescheduled games count as still to kick off",
+			missedPick: models.MissedPickAutoAssign,
+			lateStatus: models.GameStatusRescheduled,
+			joined:     -time.Hour,
+			now:        time.Hour,
+			wantTeam:   "KC",
+		},
+		{
+			name:       "eliminated when every game is underway",
+			missedPick: models.MissedPickAutoAssign,
+			lateStatus: models.GameStatusInProgress,
+			joined:     -time.Hour,
+			now:        4 * time.Hour,
+			wantOut:    models.EliminatedByMissedPick,
+		},
+		{
+			name:       "eliminated when the late game is postponed",
+			missedPick: models.MissedPickAutoAssign,
+			lateStatus: models.GameStatusPostponed,
+			joined:     -time.Hour,
+			now:        time.Hour,
+			wantOut:    models.EliminatedByMissedPick,
+		},
+		{
+			name:       "not answerable for a week that kicked off before joining",
+			missedPick: models.MissedPickEliminate,
+			lateStatus: models.GameStatusScheduled,
+			joined:     30 * time.Minute,
+			now:        time.Hour,
+		},
+		{
+			name:       "nothing happens before kickoff",
+			missedPick: models.MissedPickEliminate,
+			lateStatus: models.GameStatusScheduled,
+			joined:     -time.Hour,
+			now:        -time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLeague(t, tt.missedPick, tt.lateStatus)
+			l.joined(t, l.member, kickoff.Add(tt.joined))
+			l.process(t, kickoff.Add(tt.now))
+
+			pick, out := l.week1(t, l.member)
+			switch {
+			case tt.wantTeam == "" && pick != nil:
+				t.Errorf("assigned %s", pick.TeamAbbreviation)
+			case tt.wantTeam != "" && pick == nil:
+				t.Errorf("no pick assigned, want %s", tt.wantTeam)
+			case pick != nil && (pick.TeamAbbreviation != tt.wantTeam || !pick.IsAuto || pick.GameID != l.late):
+				t.Errorf("assigned %+v, want %s in the late game", pick, tt.wantTeam)
+			}
+			switch {
+			case out == nil && tt.wantOut != "":
+				t.Errorf("still alive, want eliminated by %s", tt.wantOut)
+			case out != nil && out.Reason != tt.wantOut:
+				t.Errorf("eliminated by %s in week %d, want %q", out.Reason, out.Week, tt.wantOut)
+			}
+
+			if _, out := l.week1(t, l.commissioner); out != nil {
+				t.Errorf("commissioner eliminated by %s despite picking", out.Reason)
+			}
+		})
+	}
+}
+
+func TestLossEliminates(t *testing.T) {
+	tests := []struct {
+		name          string
+		home, away    int
+		tieEliminates bool
+		wantResult    string
+		wantOut       string
+	}{
+		{name: "win", home: 24, away: 10, wantResult: models.SurvivorResultWin},
+		{name: "loss", home: 10, away: 24, wantResult: models.SurvivorResultLoss, wantOut: models.EliminatedByLoss},
+		{name: "tie survives", home: 17, away: 17, wantResult: models.SurvivorResultTie},
+		{name: "tie eliminates", home: 17, away: 17, tieEliminates: true, wantResult: models.SurvivorResultTie, wantOut: models.EliminatedByTie},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			l := newLeague(t, models.MissedPickAutoAssign, models.GameStatusScheduled)
+			l.pool.Settings.Survivor.TieEliminates = tt.tieEliminates
+			if _, err := l.store.Picks.Create(ctx, l.pool.ID, l.commissioner, l.teams["BUF"], 1, nil); err != nil {
+				t.Fatalf("create season pick: %v", err)
+			}
+
+			final := models.NFLGame{
+				ExternalID: "early",
+				SeasonYear: 2025,
+				Week:       1,
+				GameType:   models.GameTypeRegular,
+				HomeTeamID: l.teams["BUF"],
+				AwayTeamID: l.teams["MIA"],
+				GameDate:   kickoff,
+				HomeScore:  tt.home,
+				AwayScore:  tt.away,
+				Status:     models.GameStatusCompleted,
+			}
+			if _, err := l.store.Games.Upsert(ctx, []models.NFLGame{final}); err != nil {
+				t.Fatalf("finish game: %v", err)
+			}
+			l.process(t, kickoff.Add(time.Hour))
+
+			pick, out := l.week1(t, l.commissioner)
+			if pick == nil || pick.Result != tt.wantResult {
+				t.Fatalf("pick = %+v, want result %s", pick, tt.wantResult)
+			}
+			switch {
+			case out == nil && tt.wantOut != "":
+				t.Fatalf("still alive, want eliminated by %s", tt.wantOut)
+			case out != nil && (out.Reason != tt.wantOut || out.Week != 1):
+				t.Fatalf("eliminated by %s in week %d, want %q in week 1", out.Reason, out.Week, tt.wantOut)
+			}
+
+			// The member's season picks carry the elimination too
+			seasonPicks, err := l.store.Picks.ListByUser(ctx, l.commissioner, &l.pool.ID)
+			if err != nil || len(seasonPicks) != 1 {
+				t.Fatalf("season picks = %+v, %v", seasonPicks, err)
+			}
+			seasonPick := seasonPicks[0]
+			if seasonPick.IsEliminated != (tt.wantOut != "") {
+				t.Errorf("season pick is_eliminated = %t", seasonPick.IsEliminated)
+			}
+			if tt.wantOut != "" && (seasonPick.EliminationWeek == nil || *seasonPick.EliminationWeek != 1) {
+				t.Errorf("season pick elimination_week = %v, want 1", seasonPick.EliminationWeek)
+			}
+		})
+	}
+}
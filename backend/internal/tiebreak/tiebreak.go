@@ -0,0 +1,137 @@
+// Package tiebreak manages members' predictions of the total points scored
+// in a pool's tiebreaker game.
+package tiebreak
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/picklock"
+	"touchdown-tally/internal/store"
+	"touchdown-tally/pkg/logger"
+)
+
+var (
+	ErrNoGame       = errors.New("the tiebreaker game has not been scheduled yet")
+	ErrWeekRequired = errors.New("week is required for monday night tiebreakers")
+	ErrLocked       = errors.New("tiebreaker game has kicked off and predictions are locked")
+)
+
+// Game returns the pool's tiebreaker game. Super Bowl pools always use the
+// Super Bowl. Monday-night pools use the last game to kick off in week or,
+// for season standings where week is nil, the most recent such game to
+// finish. It returns ErrNoGame when there is no game to use yet.
+func Game(ctx context.Context, st *store.Store, pool *models.Pool, week *int) (*models.GameWithTeams, error) {
+	filter := store.GameFilter{SeasonYear: &pool.Season}
+	if pool.Settings.Tiebreak.Game == models.TiebreakGameSuperBowl {
+		filter.GameType = models.GameTypeSuperBowl
+	} else {
+		filter.Week = week
+	}
+
+	games, err := st.Games.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(games) == 0 {
+		return nil, ErrNoGame
+	}
+	if filter.Week != nil || filter.GameType != "" {
+		return &games[len(games)-1], nil
+	}
+
+	// Games come in kickoff order, so the last one seen in a week is its
+	// Monday-night game
+	last := make(map[int]int)
+	for i, game := range games {
+		last[game.Week] = i
+	}
+	weeks := make([]int, 0, len(last))
+	for w := range last {
+		weeks = append(weeks, w)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(weeks)))
+
+	for _, w := range weeks {
+		if game := games[last[w]]; game.IsFinal() {
+			return &game, nil
+		}
+	}
+	return nil, ErrNoGame
+}
+
+// Service records tiebreaker predictions
+type Service struct {
+	store  *store.Store
+	locks  *picklock.Checker
+	logger *logger.Logger
+}
+
+// NewService creates a tiebreaker service
+func NewService(st *store.Store, locks *picklock.Checker, logger *logger.Logger) *Service {
+	return &Service{
+		store:  st,
+		locks:  locks,
+		logger: logger,
+	}
+}
+
+// Get returns the tiebreaker game for week with the member's own prediction,
+// and everyone's once the game has kicked off. Week is ignored by Super Bowl
+// pools.
+func (s *Service) Get(ctx context.Context, pool *models.Pool, userID int, week *int) (*models.TiebreakerGame, error) {
+	game, err := s.game(ctx, pool, week)
+	if err != nil {
+		return nil, err
+	}
+
+	predictions, err := s.store.Tiebreakers.List(ctx, pool.ID, game.GameID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.TiebreakerGame{
+		PoolID:      pool.ID,
+		Game:        *game,
+		Locked:      s.locks.GameLocked(game.NFLGame),
+		Predictions: []models.Tiebreaker{},
+	}
+	for i, prediction := range predictions {
+		if prediction.UserID == userID {
+			result.MyEntry = &predictions[i]
+		}
+	}
+	if result.Locked {
+		result.Predictions = append(result.Predictions, predictions...)
+	}
+
+	return result, nil
+}
+
+// Submit saves the member's predicted total for the tiebreaker game, which
+// can be changed until it kicks off
+func (s *Service) Submit(ctx context.Context, pool *models.Pool, userID int, week *int, totalPoints int) (*models.TiebreakerGame, error) {
+	game, err := s.game(ctx, pool, week)
+	if err != nil {
+		return nil, err
+	}
+	if s.locks.GameLocked(game.NFLGame) {
+		return nil, ErrLocked
+	}
+
+	if err := s.store.Tiebreakers.Save(ctx, pool.ID, userID, game.GameID, totalPoints); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Tiebreaker saved", "pool_id", pool.ID, "user_id", userID, "game_id", game.GameID)
+	return s.Get(ctx, pool, userID, week)
+}
+
+func (s *Service) game(ctx context.Context, pool *models.Pool, week *int) (*models.GameWithTeams, error) {
+	if week == nil && pool.Settings.Tiebreak.Game == models.TiebreakGameMondayNight {
+		return nil, ErrWeekRequired
+	}
+	return Game(ctx, s.store, pool, week)
+}
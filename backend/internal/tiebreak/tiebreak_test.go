@@ -0,0 +1,73 @@
+package tiebreak
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"touchdown-tally/internal/models"
+	"touchdown-tally/internal/store/memstore"
+)
+
+func TestGame(t *testing.T) {
+	db := memstore.New()
+	db.AddTeam(models.NFLTeam{TeamID: 1, TeamName: "Bills"})
+	db.AddTeam(models.NFLTeam{TeamID: 2, TeamName: "Dolphins"})
+
+	sunday := time.Date(2025, 9, 7, 17, 0, 0, 0, time.UTC)
+	monday := sunday.Add(27 * time.Hour)
+	week := 7 * 24 * time.Hour
+	add := func(w int, gameType string, kickoff time.Time, status string) int {
+		return db.AddGame(models.NFLGame{
+			SeasonYear: 2025,
+			Week:       w,
+			GameType:   gameType,
+			HomeTeamID: 1,
+			AwayTeamID: 2,
+			GameDate:   kickoff,
+			Status:     status,
+		})
+	}
+	add(1, models.GameTypeRegular, sunday, models.GameStatusCompleted)
+	week1Monday := add(1, models.GameTypeRegular, monday, models.GameStatusCompleted)
+	add(2, models.GameTypeRegular, sunday.Add(week), models.GameStatusCompleted)
+	week2Monday := add(2, models.GameTypeRegular, monday.Add(week), models.GameStatusInProgress)
+	superBowl := add(22, models.GameTypeSuperBowl, sunday.Add(21*week), models.GameStatusScheduled)
+
+	tests := []struct {
+		name    string
+		season  int
+		game    string
+		week    int
+		want    int
+		wantErr error
+	}{
+		{name: "super bowl", season: 2025, game: models.TiebreakGameSuperBowl, want: superBowl},
+		{name: "super bowl pools ignore the week", season: 2025, game: models.TiebreakGameSuperBowl, week: 1, want: superBowl},
+		{name: "monday night in a finished week", season: 2025, game: models.TiebreakGameMondayNight, week: 1, want: week1Monday},
+		{name: "monday night still being played", season: 2025, game: models.TiebreakGameMondayNight, week: 2, want: week2Monday},
+		{name: "season standings use the latest final monday night", season: 2025, game: models.TiebreakGameMondayNight, want: week1Monday},
+		{name: "week without games", season: 2025, game: models.TiebreakGameMondayNight, week: 5, wantErr: ErrNoGame},
+		{name: "season without a super bowl", season: 2024, game: models.TiebreakGameSuperBowl, wantErr: ErrNoGame},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := &models.Pool{Season: tt.season, Settings: models.DefaultPoolSettings()}
+			pool.Settings.Tiebreak.Game = tt.game
+			var week *int
+			if tt.week != 0 {
+				week = &tt.week
+			}
+
+			game, err := Game(context.Background(), db.Store(), pool, week)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Game error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && game.GameID != tt.want {
+				t.Errorf("Game = %d (week %d), want %d", game.GameID, game.Week, tt.want)
+			}
+		})
+	}
+}
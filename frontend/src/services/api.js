@@ -69,6 +69,7 @@ export const picksAPI = {
   deletePick: (pickId) => api.delete(`/picks/${pickId}`),
   getPicksByUser: (userId) => api.get(`/picks/user/${userId}`),
   getPicksByGame: (gameId) => api.get(`/picks/game/${gameId}`),
+  getPoolPicks: (poolId) => api.get(`/picks/pool/${poolId}`),
 }
 
 // Pools API
@@ -100,6 +101,7 @@ export const chatAPI = {
 export class WebSocketService {
   constructor() {
     this.socket = null
+    this.token = null
     this.listeners = new Map()
     this.reconnectAttempts = 0
     this.maxReconnectAttempts = 5
@@ -110,6 +112,7 @@ export class WebSocketService {
     const wsUrl = import.meta.env.VITE_WS_URL || 'ws://localhost:8080/ws'
     const url = `${wsUrl}?token=${token}`
     
+    this.token = token
     this.socket = new WebSocket(url)
     
     this.socket.onopen = () => {
@@ -137,11 +140,27 @@ export class WebSocketService {
   }
 
   disconnect() {
+    this.close()
+    this.token = null
+    this.listeners.clear()
+  }
+
+  // The socket follows the pools the user belonged to when it connected,
+  // so open a new one after joining or creating a pool
+  reconnect() {
+    if (!this.token) return
+    this.close()
+    this.reconnectAttempts = 0
+    this.connect(this.token)
+  }
+
+  // close drops the socket without the automatic reconnect
+  close() {
     if (this.socket) {
+      this.socket.onclose = null
       this.socket.close()
       this.socket = null
     }
-    this.listeners.clear()
   }
 
   attemptReconnect(token) {
@@ -1,5 +1,5 @@
 import { ref, computed, reactive } from 'vue'
-import { authAPI, wsService } from './api.js'
+import { authAPI, wsService } from '../services/api.js'
 
 // Auth store
 export const useAuthStore = () => {
@@ -12,7 +12,7 @@ export const useAuthStore = () => {
     loading.value = true
     try {
       const response = await authAPI.login(credentials)
-      const { token: authToken, user: userData } = response.data
+      const { token: authToken, user: userData } = response.data.data
       
       token.value = authToken
       user.value = userData
@@ -39,7 +39,7 @@ export const useAuthStore = () => {
     loading.value = true
     try {
       const response = await authAPI.register(userData)
-      return { success: true, data: response.data }
+      return { success: true, data: response.data.data }
     } catch (error) {
       console.error('Registration error:', error)
       return { 
@@ -95,10 +95,10 @@ export const useGameStore = () => {
   const fetchGames = async (params = {}) => {
     loading.value = true
     try {
-      const { gamesAPI } = await import('./api.js')
+      const { gamesAPI } = await import('../services/api.js')
       const response = await gamesAPI.getAll(params)
-      games.value = response.data
-      return { success: true, data: response.data }
+      games.value = response.data.data
+      return { success: true, data: response.data.data }
     } catch (error) {
       console.error('Error fetching games:', error)
       return { success: false, error: error.message }
@@ -110,10 +110,10 @@ export const useGameStore = () => {
   const fetchCurrentWeekGames = async () => {
     loading.value = true
     try {
-      const { gamesAPI } = await import('./api.js')
+      const { gamesAPI } = await import('../services/api.js')
       const response = await gamesAPI.getCurrent()
-      currentWeekGames.value = response.data
-      return { success: true, data: response.data }
+      currentWeekGames.value = response.data.data.games
+      return { success: true, data: response.data.data }
     } catch (error) {
       console.error('Error fetching current week games:', error)
       return { success: false, error: error.message }
@@ -122,6 +122,28 @@ export const useGameStore = () => {
     }
   }
 
+  // WebSocket game_update handler: copy the live score, clock and status
+  // onto any loaded copy of the game
+  const handleGameUpdate = (event) => {
+    const update = event.data
+    for (const list of [games.value, currentWeekGames.value]) {
+      if (!Array.isArray(list)) continue
+      const game = list.find(g => g.game_id === update.game_id)
+      if (game) {
+        Object.assign(game, {
+          home_score: update.home_score,
+          away_score: update.away_score,
+          status: update.status,
+          quarter: update.quarter,
+          time_remaining: update.time_remaining,
+          last_updated: update.last_updated
+        })
+      }
+    }
+  }
+
+  wsService.subscribe('game_update', handleGameUpdate)
+
   return {
     games,
     currentWeekGames,
@@ -129,7 +151,8 @@ export const useGameStore = () => {
     currentWeek,
     currentSeason,
     fetchGames,
-    fetchCurrentWeekGames
+    fetchCurrentWeekGames,
+    handleGameUpdate
   }
 }
 
@@ -142,16 +165,16 @@ export const usePicksStore = () => {
   const fetchMyPicks = async (params = {}) => {
     loading.value = true
     try {
-      const { picksAPI } = await import('./api.js')
+      const { picksAPI } = await import('../services/api.js')
       const response = await picksAPI.getMyPicks(params)
-      picks.value = response.data
+      picks.value = response.data.data
       
       // Update userPicks map for quick lookup
-      response.data.forEach(pick => {
+      picks.value.forEach(pick => {
         userPicks.set(pick.game_id, pick)
       })
       
-      return { success: true, data: response.data }
+      return { success: true, data: response.data.data }
     } catch (error) {
       console.error('Error fetching picks:', error)
       return { success: false, error: error.message }
@@ -162,11 +185,11 @@ export const usePicksStore = () => {
 
   const makePick = async (pickData) => {
     try {
-      const { picksAPI } = await import('./api.js')
+      const { picksAPI } = await import('../services/api.js')
       const response = await picksAPI.createPick(pickData)
       
       // Update local state
-      const newPick = response.data
+      const newPick = response.data.data
       picks.value.push(newPick)
       userPicks.set(newPick.game_id, newPick)
       
@@ -179,11 +202,11 @@ export const usePicksStore = () => {
 
   const updatePick = async (pickId, pickData) => {
     try {
-      const { picksAPI } = await import('./api.js')
+      const { picksAPI } = await import('../services/api.js')
       const response = await picksAPI.updatePick(pickId, pickData)
       
       // Update local state
-      const updatedPick = response.data
+      const updatedPick = response.data.data
       const index = picks.value.findIndex(p => p.pick_id === pickId)
       if (index !== -1) {
         picks.value[index] = updatedPick
@@ -215,6 +238,7 @@ export const usePicksStore = () => {
 // Pools store
 export const usePoolsStore = () => {
   const pools = ref([])
+  const availablePools = ref([])
   const currentPool = ref(null)
   const poolMembers = ref([])
   const loading = ref(false)
@@ -222,10 +246,11 @@ export const usePoolsStore = () => {
   const fetchPools = async () => {
     loading.value = true
     try {
-      const { poolsAPI } = await import('./api.js')
+      const { poolsAPI } = await import('../services/api.js')
       const response = await poolsAPI.getAll()
-      pools.value = response.data
-      return { success: true, data: response.data }
+      pools.value = response.data.data.member_pools
+      availablePools.value = response.data.data.available_pools
+      return { success: true, data: response.data.data }
     } catch (error) {
       console.error('Error fetching pools:', error)
       return { success: false, error: error.message }
@@ -236,13 +261,14 @@ export const usePoolsStore = () => {
 
   const joinPool = async (poolId, joinData = {}) => {
     try {
-      const { poolsAPI } = await import('./api.js')
+      const { poolsAPI } = await import('../services/api.js')
       const response = await poolsAPI.join(poolId, joinData)
       
-      // Refresh pools list
+      // Refresh pools list and follow the new pool's live events
       await fetchPools()
+      wsService.reconnect()
       
-      return { success: true, data: response.data }
+      return { success: true, data: response.data.data }
     } catch (error) {
       console.error('Error joining pool:', error)
       return { success: false, error: error.message }
@@ -251,13 +277,14 @@ export const usePoolsStore = () => {
 
   const createPool = async (poolData) => {
     try {
-      const { poolsAPI } = await import('./api.js')
+      const { poolsAPI } = await import('../services/api.js')
       const response = await poolsAPI.create(poolData)
       
-      // Add to local pools
-      pools.value.push(response.data)
+      // Add to local pools and follow its live events
+      pools.value.push(response.data.data)
+      wsService.reconnect()
       
-      return { success: true, data: response.data }
+      return { success: true, data: response.data.data }
     } catch (error) {
       console.error('Error creating pool:', error)
       return { success: false, error: error.message }
@@ -266,6 +293,7 @@ export const usePoolsStore = () => {
 
   return {
     pools,
+    availablePools,
     currentPool,
     poolMembers,
     loading,
@@ -278,16 +306,18 @@ export const usePoolsStore = () => {
 // Standings store
 export const useStandingsStore = () => {
   const standings = ref([])
+  const standingsPoolId = ref(null)
   const userStats = ref(null)
   const loading = ref(false)
 
   const fetchStandings = async (poolId, params = {}) => {
     loading.value = true
     try {
-      const { standingsAPI } = await import('./api.js')
+      const { standingsAPI } = await import('../services/api.js')
       const response = await standingsAPI.getPoolStandings(poolId, params)
-      standings.value = response.data
-      return { success: true, data: response.data }
+      standings.value = response.data.data.standings
+      standingsPoolId.value = Number(poolId)
+      return { success: true, data: response.data.data }
     } catch (error) {
       console.error('Error fetching standings:', error)
       return { success: false, error: error.message }
@@ -298,22 +328,33 @@ export const useStandingsStore = () => {
 
   const fetchUserStats = async (userId, poolId) => {
     try {
-      const { standingsAPI } = await import('./api.js')
+      const { standingsAPI } = await import('../services/api.js')
       const response = await standingsAPI.getUserStats(userId, poolId)
-      userStats.value = response.data
-      return { success: true, data: response.data }
+      userStats.value = response.data.data
+      return { success: true, data: response.data.data }
     } catch (error) {
       console.error('Error fetching user stats:', error)
       return { success: false, error: error.message }
     }
   }
 
+  // WebSocket standings_update handler: the server recomputes a pool's
+  // standings after its games change, so take them as they are
+  const handleStandingsUpdate = (event) => {
+    if (event.pool_id === standingsPoolId.value) {
+      standings.value = event.data.standings
+    }
+  }
+
+  wsService.subscribe('standings_update', handleStandingsUpdate)
+
   return {
     standings,
     userStats,
     loading,
     fetchStandings,
-    fetchUserStats
+    fetchUserStats,
+    handleStandingsUpdate
   }
 }
 
@@ -325,10 +366,10 @@ export const useChatStore = () => {
   const fetchMessages = async (poolId, params = {}) => {
     loading.value = true
     try {
-      const { chatAPI } = await import('./api.js')
+      const { chatAPI } = await import('../services/api.js')
       const response = await chatAPI.getMessages(poolId, params)
-      messages.value = response.data
-      return { success: true, data: response.data }
+      messages.value = response.data.data.messages
+      return { success: true, data: response.data.data }
     } catch (error) {
       console.error('Error fetching messages:', error)
       return { success: false, error: error.message }
@@ -339,13 +380,13 @@ export const useChatStore = () => {
 
   const sendMessage = async (messageData) => {
     try {
-      const { chatAPI } = await import('./api.js')
+      const { chatAPI } = await import('../services/api.js')
       const response = await chatAPI.sendMessage(messageData)
       
       // Add to local messages
-      messages.value.push(response.data)
+      messages.value.push(response.data.data)
       
-      return { success: true, data: response.data }
+      return { success: true, data: response.data.data }
     } catch (error) {
       console.error('Error sending message:', error)
       return { success: false, error: error.message }
@@ -363,12 +404,23 @@ export const useChatStore = () => {
     }
   }
 
+  // WebSocket message_deleted handler: drop messages a moderator removed
+  const handleMessageDeleted = (event) => {
+    const removed = new Set(event.data.message_ids || [])
+    if (Array.isArray(messages.value)) {
+      messages.value = messages.value.filter(m => !removed.has(m.id))
+    }
+  }
+
+  wsService.subscribe('message_deleted', handleMessageDeleted)
+
   return {
     messages,
     loading,
     fetchMessages,
     sendMessage,
     addMessage,
-    handleNewMessage
+    handleNewMessage,
+    handleMessageDeleted
   }
 }